@@ -4,6 +4,8 @@ import (
 	"domain-max/pkg/api"
 	"domain-max/pkg/config"
 	"domain-max/pkg/database"
+	"domain-max/pkg/dns/providers"
+	"domain-max/pkg/dns/services"
 	"domain-max/pkg/middleware"
 	"domain-max/pkg/utils"
 	"log"
@@ -38,6 +40,10 @@ func main() {
 
 	// 初始化API控制器
 	authAPI := api.NewAuthAPI(db, jwtService, passwordService, validationService)
+	providerManager := providers.NewProviderManager(providers.NewProviderFactory(), services.NewCredentialLoader(db, encryptionService))
+	dnsAPI := api.NewSimpleDNSAPI(db, providerManager, encryptionService, validationService)
+	recordAPI := api.NewDNSRecordAPI(services.NewRecordService(db, providerManager, encryptionService))
+	domainAPI := api.NewDomainAPI(services.NewDomainService(db, providerManager))
 
 	// 设置Gin模式
 	if cfg.IsProduction() {
@@ -58,7 +64,7 @@ func main() {
 	}
 
 	// 设置路由
-	setupAPIRoutes(router, authAPI, jwtService)
+	setupAPIRoutes(router, authAPI, dnsAPI, recordAPI, domainAPI, jwtService)
 
 	log.Printf("API服务器启动在端口 %s", cfg.Port)
 	log.Printf("环境: %s", cfg.Environment)
@@ -66,7 +72,7 @@ func main() {
 	log.Fatal(router.Run(":" + cfg.Port))
 }
 
-func setupAPIRoutes(router *gin.Engine, authAPI *api.AuthAPI, jwtService *utils.JWTService) {
+func setupAPIRoutes(router *gin.Engine, authAPI *api.AuthAPI, dnsAPI *api.SimpleDNSAPI, recordAPI *api.DNSRecordAPI, domainAPI *api.DomainAPI, jwtService *utils.JWTService) {
 	// 健康检查
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -108,23 +114,39 @@ func setupAPIRoutes(router *gin.Engine, authAPI *api.AuthAPI, jwtService *utils.
 		protected.POST("/auth/change-password", authAPI.ChangePassword)
 
 		// DNS提供商管理
-		providers := protected.Group("/dns-providers")
+		dnsProviders := protected.Group("/dns-providers")
 		{
-			providers.GET("", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{
-					"message": "DNS提供商功能待实现",
-				})
-			})
+			dnsProviders.GET("", dnsAPI.GetDNSProviders)
+			dnsProviders.GET("/types", dnsAPI.ListSupportedProviders)
+			dnsProviders.GET("/types/:type/schema", dnsAPI.GetProviderSchema)
+			dnsProviders.POST("", dnsAPI.CreateDNSProvider)
+			dnsProviders.PUT("/:id", dnsAPI.UpdateDNSProvider)
+			dnsProviders.DELETE("/:id", dnsAPI.DeleteDNSProvider)
+			dnsProviders.POST("/:id/test", dnsAPI.TestDNSProvider)
+		}
+
+		// 域名管理
+		domains := protected.Group("/domains")
+		{
+			domains.GET("", domainAPI.ListDomains)
+			domains.POST("", domainAPI.CreateDomain)
+			domains.POST("/import", domainAPI.ImportDomains)
+			domains.GET("/:id", domainAPI.GetDomain)
+			domains.DELETE("/:id", domainAPI.DeleteDomain)
 		}
 
 		// DNS记录管理
 		records := protected.Group("/dns-records")
 		{
-			records.GET("", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{
-					"message": "DNS记录功能待实现",
-				})
-			})
+			records.GET("", recordAPI.ListDNSRecords)
+			records.POST("", recordAPI.CreateDNSRecord)
+			records.POST("/batch", recordAPI.BatchCreateDNSRecords)
+			records.DELETE("/batch", recordAPI.BatchDeleteDNSRecords)
+			records.GET("/:id", recordAPI.GetDNSRecord)
+			records.PUT("/:id", recordAPI.UpdateDNSRecord)
+			records.DELETE("/:id", recordAPI.DeleteDNSRecord)
+			records.POST("/:id/enable", recordAPI.EnableDNSRecord)
+			records.POST("/:id/disable", recordAPI.DisableDNSRecord)
 		}
 
 		// 管理员路由
@@ -144,6 +166,9 @@ func setupAPIRoutes(router *gin.Engine, authAPI *api.AuthAPI, jwtService *utils.
 					"message": "系统统计功能待实现",
 				})
 			})
+
+			// 服务商域名ID缓存统计
+			admin.GET("/zone-cache", dnsAPI.GetZoneCacheStats)
 		}
 	}
 }
\ No newline at end of file
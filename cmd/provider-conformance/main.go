@@ -0,0 +1,163 @@
+// provider-conformance 启动CloudFlare、阿里云和DNSPod的API模拟器，将适配器指向模拟器后执行一致性检查
+// 其他服务商没有模拟器，不执行检查的原因见pkg/dns/providers/conformance_test.go中的conformanceExempt
+//
+// 用法: go run ./cmd/provider-conformance [-providers cloudflare,aliyun,dnspod,mock]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"domain-max/pkg/dns/providers"
+	"domain-max/pkg/dns/providers/conformance"
+	"domain-max/pkg/dns/providers/emulator"
+)
+
+// testDomain 模拟器中托管的测试域名
+const testDomain = "example.com"
+
+// target 一致性检查的目标服务商
+type target struct {
+	config    map[string]string
+	seed      func(count int) error
+	seedCount int
+	faults    conformance.FaultInjector
+	close     func()
+}
+
+func main() {
+	providerList := flag.String("providers", "cloudflare,aliyun,dnspod,mock", "执行检查的服务商类型，以逗号分隔")
+	timeout := flag.Duration("timeout", 2*time.Minute, "每个服务商的检查超时时间")
+	flag.Parse()
+
+	// 缩短重试等待时间，限流检查只需要等待一次重试
+	factory := providers.NewProviderFactory()
+	factory.SetRetryConfig(providers.RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  100 * time.Millisecond,
+		MaxDelay:      2 * time.Second,
+		BackoffFactor: 2.0,
+	})
+
+	failed := false
+	for _, providerType := range strings.Split(*providerList, ",") {
+		providerType = strings.TrimSpace(providerType)
+		if providerType == "" {
+			continue
+		}
+
+		t, err := newTarget(providerType)
+		if err != nil {
+			log.Fatalf("启动%s模拟器失败: %v", providerType, err)
+		}
+		// 模拟器不限制请求频率，关闭客户端限流以加快检查
+		t.config["rate_limit"] = "0"
+
+		provider, err := factory.CreateProvider(providerType, t.config)
+		if err != nil {
+			t.close()
+			log.Fatalf("创建%s服务商失败: %v", providerType, err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		results := conformance.Suite{
+			Provider:  provider,
+			Domain:    testDomain,
+			Seed:      t.seed,
+			SeedCount: t.seedCount,
+			Faults:    t.faults,
+		}.Run(ctx)
+		cancel()
+		t.close()
+
+		printResults(providerType, results)
+		if !conformance.Passed(results) {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// newTarget 启动服务商对应的模拟器，分页检查写入的记录数超过适配器单页的记录数
+func newTarget(providerType string) (*target, error) {
+	switch providerType {
+	case "cloudflare":
+		e := emulator.NewCloudflare()
+		e.AddZone(testDomain)
+		return &target{
+			config:    e.Config(),
+			seed:      func(count int) error { return e.SeedRecords(testDomain, count) },
+			seedCount: 250,
+			faults:    e,
+			close:     e.Close,
+		}, nil
+	case "aliyun":
+		e := emulator.NewAliyun()
+		e.AddZone(testDomain)
+		return &target{
+			config:    e.Config(),
+			seed:      func(count int) error { return e.SeedRecords(testDomain, count) },
+			seedCount: 1200,
+			faults:    e,
+			close:     e.Close,
+		}, nil
+	case "dnspod":
+		e := emulator.NewDNSPod()
+		e.AddZone(testDomain)
+		return &target{
+			config:    e.Config(),
+			seed:      func(count int) error { return e.SeedRecords(testDomain, count) },
+			seedCount: 3500,
+			faults:    e,
+			close:     e.Close,
+		}, nil
+	case "mock":
+		// 模拟DNS服务商没有HTTP接口，同一账号的实例共享数据，通过另一个实例写入记录和注入错误
+		config := map[string]string{"api_key": fmt.Sprintf("conformance-%d", time.Now().UnixNano()), "zones": testDomain}
+		mock, err := providers.NewMockProvider(providers.ProviderConfig{APIKey: config["api_key"], ExtraParams: config})
+		if err != nil {
+			return nil, err
+		}
+		return &target{
+			config: config,
+			seed: func(count int) error {
+				records := make([]providers.DNSRecord, count)
+				for i := range records {
+					records[i] = providers.DNSRecord{Name: fmt.Sprintf("seed-%d", i), Type: "TXT", Value: "seed", TTL: 600}
+				}
+				_, err := mock.BatchAddRecords(context.Background(), testDomain, records)
+				return err
+			},
+			seedCount: 100,
+			faults:    mock,
+			close:     func() {},
+		}, nil
+	}
+	return nil, fmt.Errorf("没有%s的模拟器", providerType)
+}
+
+// printResults 输出检查结果
+func printResults(providerType string, results []conformance.Result) {
+	fmt.Printf("== %s\n", providerType)
+	for _, result := range results {
+		status := "PASS"
+		switch {
+		case result.Skipped:
+			status = "SKIP"
+		case !result.Passed():
+			status = "FAIL"
+		}
+		fmt.Printf("  %-4s %s (%s)\n", status, result.Name, result.Duration.Round(time.Millisecond))
+		if result.Err != nil {
+			fmt.Printf("       %v\n", result.Err)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"domain-max/pkg/dns/models"
+	"domain-max/pkg/dns/services"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DNSRecordAPI DNS记录管理API控制器
+type DNSRecordAPI struct {
+	RecordService *services.RecordService
+}
+
+// NewDNSRecordAPI 创建DNS记录API实例
+func NewDNSRecordAPI(recordService *services.RecordService) *DNSRecordAPI {
+	return &DNSRecordAPI{
+		RecordService: recordService,
+	}
+}
+
+// BatchDeleteDNSRecordRequest DNS记录批量删除请求
+type BatchDeleteDNSRecordRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=50"`
+}
+
+// ListDNSRecords 获取DNS记录列表
+func (r *DNSRecordAPI) ListDNSRecords(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	domainID, _ := strconv.ParseUint(c.Query("domain_id"), 10, 32)
+
+	records, total, err := r.RecordService.ListRecords(services.RecordQuery{
+		UserID:   userID,
+		IsAdmin:  role == "admin",
+		DomainID: uint(domainID),
+		Domain:   c.Query("domain"),
+		Type:     c.Query("type"),
+		Page:     page,
+		Limit:    limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "获取DNS记录失败",
+			"code":    "RECORD_FETCH_ERROR",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"records": records,
+			"total":   total,
+		},
+	})
+}
+
+// GetDNSRecord 获取单条DNS记录
+func (r *DNSRecordAPI) GetDNSRecord(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	recordID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "无效的记录ID",
+			"code":    "INVALID_RECORD_ID",
+			"message": "记录ID必须是数字",
+		})
+		return
+	}
+
+	record, err := r.RecordService.GetRecord(recordID, userID, role == "admin")
+	if err != nil {
+		respondRecordError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    record,
+	})
+}
+
+// CreateDNSRecord 创建DNS记录
+func (r *DNSRecordAPI) CreateDNSRecord(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	var req models.CreateDNSRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "请求参数错误",
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	record, err := r.RecordService.CreateRecord(ctx, userID, role == "admin", req)
+	if err != nil {
+		respondRecordError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "DNS记录创建成功",
+		"data":    record,
+	})
+}
+
+// UpdateDNSRecord 更新DNS记录
+func (r *DNSRecordAPI) UpdateDNSRecord(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	recordID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "无效的记录ID",
+			"code":    "INVALID_RECORD_ID",
+			"message": "记录ID必须是数字",
+		})
+		return
+	}
+
+	var req models.UpdateDNSRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "请求参数错误",
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	record, err := r.RecordService.UpdateRecord(ctx, recordID, userID, role == "admin", req)
+	if err != nil {
+		respondRecordError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "DNS记录更新成功",
+		"data":    record,
+	})
+}
+
+// DeleteDNSRecord 删除DNS记录
+func (r *DNSRecordAPI) DeleteDNSRecord(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	recordID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "无效的记录ID",
+			"code":    "INVALID_RECORD_ID",
+			"message": "记录ID必须是数字",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := r.RecordService.DeleteRecord(ctx, recordID, userID, role == "admin"); err != nil {
+		respondRecordError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "DNS记录删除成功",
+	})
+}
+
+// EnableDNSRecord 启用已暂停的DNS记录
+func (r *DNSRecordAPI) EnableDNSRecord(c *gin.Context) {
+	r.setDNSRecordStatus(c, true)
+}
+
+// DisableDNSRecord 暂停DNS记录，暂停的记录保留在系统中，可以再次启用
+func (r *DNSRecordAPI) DisableDNSRecord(c *gin.Context) {
+	r.setDNSRecordStatus(c, false)
+}
+
+// setDNSRecordStatus 启用或暂停DNS记录
+func (r *DNSRecordAPI) setDNSRecordStatus(c *gin.Context, enabled bool) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	recordID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "无效的记录ID",
+			"code":    "INVALID_RECORD_ID",
+			"message": "记录ID必须是数字",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	record, err := r.RecordService.SetRecordStatus(ctx, recordID, userID, role == "admin", enabled)
+	if err != nil {
+		respondRecordError(c, err)
+		return
+	}
+
+	message := "DNS记录已暂停"
+	if enabled {
+		message = "DNS记录已启用"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"data":    record,
+	})
+}
+
+// BatchCreateDNSRecords 批量创建DNS记录
+func (r *DNSRecordAPI) BatchCreateDNSRecords(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	var req models.BatchDNSRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "请求参数错误",
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	records, err := r.RecordService.BatchCreateRecords(ctx, userID, role == "admin", req.Records)
+	if err != nil {
+		respondRecordError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "DNS记录批量创建成功",
+		"data":    records,
+	})
+}
+
+// BatchDeleteDNSRecords 批量删除DNS记录
+func (r *DNSRecordAPI) BatchDeleteDNSRecords(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	var req BatchDeleteDNSRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "请求参数错误",
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	deleted, failures := r.RecordService.BatchDeleteRecords(ctx, req.IDs, userID, role == "admin")
+
+	status := http.StatusOK
+	if len(failures) > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	c.JSON(status, gin.H{
+		"success": len(failures) == 0,
+		"data": gin.H{
+			"deleted":  deleted,
+			"failures": failures,
+		},
+	})
+}
+
+// respondRecordError 将记录服务的错误转换为HTTP响应
+func respondRecordError(c *gin.Context, err error) {
+	if respondProviderError(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, services.ErrDomainNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "域名不存在",
+			"code":    "DOMAIN_NOT_FOUND",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "DNS记录不存在",
+			"code":    "RECORD_NOT_FOUND",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidRecord):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "DNS记录无效",
+			"code":    "INVALID_RECORD",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrProviderUnavailable):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "DNS服务商不可用",
+			"code":    "PROVIDER_UNAVAILABLE",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrProviderOperation):
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "DNS服务商操作失败",
+			"code":    "PROVIDER_OPERATION_ERROR",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "DNS记录操作失败",
+			"code":    "RECORD_OPERATION_ERROR",
+			"message": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"domain-max/pkg/dns/models"
+	"domain-max/pkg/dns/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DomainAPI 域名接入API控制器
+type DomainAPI struct {
+	DomainService *services.DomainService
+}
+
+// NewDomainAPI 创建域名API实例
+func NewDomainAPI(domainService *services.DomainService) *DomainAPI {
+	return &DomainAPI{
+		DomainService: domainService,
+	}
+}
+
+// ListDomains 获取域名列表
+func (d *DomainAPI) ListDomains(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	domains, err := d.DomainService.ListDomains(userID, role == "admin")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "获取域名列表失败",
+			"code":    "DOMAIN_FETCH_ERROR",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    domains,
+	})
+}
+
+// GetDomain 获取单个域名
+func (d *DomainAPI) GetDomain(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	domainID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "无效的域名ID",
+			"code":    "INVALID_DOMAIN_ID",
+			"message": "域名ID必须是数字",
+		})
+		return
+	}
+
+	domain, err := d.DomainService.GetDomain(domainID, userID, role == "admin")
+	if err != nil {
+		respondDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    domain,
+	})
+}
+
+// CreateDomain 接入域名并绑定DNS服务商凭据
+func (d *DomainAPI) CreateDomain(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	var req models.CreateDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "请求参数错误",
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	domain, err := d.DomainService.CreateDomain(ctx, userID, role == "admin", req)
+	if err != nil {
+		respondDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "域名接入成功",
+		"data":    domain,
+	})
+}
+
+// ImportDomains 导入DNS服务商账号下的全部域名
+func (d *DomainAPI) ImportDomains(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	var req models.ImportDomainsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "请求参数错误",
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	result, err := d.DomainService.ImportDomains(ctx, userID, role == "admin", req)
+	if err != nil {
+		respondDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "域名导入完成",
+		"data":    result,
+	})
+}
+
+// DeleteDomain 删除域名
+func (d *DomainAPI) DeleteDomain(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	domainID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "无效的域名ID",
+			"code":    "INVALID_DOMAIN_ID",
+			"message": "域名ID必须是数字",
+		})
+		return
+	}
+
+	if err := d.DomainService.DeleteDomain(domainID, userID, role == "admin"); err != nil {
+		respondDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "域名删除成功",
+	})
+}
+
+// respondDomainError 将域名服务的错误转换为HTTP响应
+func respondDomainError(c *gin.Context, err error) {
+	if respondProviderError(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, services.ErrDomainNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "域名不存在",
+			"code":    "DOMAIN_NOT_FOUND",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrCredentialNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "DNS服务商凭据不存在",
+			"code":    "PROVIDER_NOT_FOUND",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrInvalidDomain):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "域名格式错误",
+			"code":    "INVALID_DOMAIN",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrDomainExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "域名已存在",
+			"code":    "DOMAIN_EXISTS",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrDomainInUse):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "域名下仍有DNS记录",
+			"code":    "DOMAIN_IN_USE",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrZoneNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "域名未在DNS服务商处托管",
+			"code":    "ZONE_NOT_FOUND",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrProviderUnavailable):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "DNS服务商不可用",
+			"code":    "PROVIDER_UNAVAILABLE",
+			"message": err.Error(),
+		})
+	case errors.Is(err, services.ErrProviderOperation):
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "DNS服务商操作失败",
+			"code":    "PROVIDER_OPERATION_ERROR",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "域名操作失败",
+			"code":    "DOMAIN_OPERATION_ERROR",
+			"message": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"domain-max/pkg/dns/providers"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getUserFromContext 从上下文中获取认证中间件写入的用户信息
+func getUserFromContext(c *gin.Context) (userID uint, username, email, role string, ok bool) {
+	value, exists := c.Get("user_id")
+	if !exists {
+		return 0, "", "", "", false
+	}
+
+	userID, ok = value.(uint)
+	if !ok {
+		return 0, "", "", "", false
+	}
+
+	return userID, c.GetString("username"), c.GetString("email"), c.GetString("user_role"), true
+}
+
+// parseIDParam 解析路径中的数字ID参数
+func parseIDParam(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+// respondProviderError 根据服务商错误分类返回对应的HTTP状态码，未分类的错误返回false
+func respondProviderError(c *gin.Context, err error) bool {
+	if delay := providers.RetryAfter(err); delay > 0 {
+		c.Header("Retry-After", strconv.Itoa(int((delay+time.Second-1)/time.Second)))
+	}
+
+	switch {
+	case errors.Is(err, providers.ErrRateLimited):
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":   "DNS服务商请求频率超限",
+			"code":    "PROVIDER_RATE_LIMITED",
+			"message": err.Error(),
+		})
+	case errors.Is(err, providers.ErrCircuitOpen):
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "DNS服务商暂时不可用",
+			"code":    "PROVIDER_CIRCUIT_OPEN",
+			"message": err.Error(),
+		})
+	case errors.Is(err, providers.ErrAuthFailed):
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "DNS服务商认证失败",
+			"code":    "PROVIDER_AUTH_FAILED",
+			"message": err.Error(),
+		})
+	case errors.Is(err, providers.ErrInvalidConfig):
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "DNS服务商配置错误",
+			"code":    "PROVIDER_CONFIG_ERROR",
+			"message": err.Error(),
+		})
+	case errors.Is(err, providers.ErrRecordConflict):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "DNS记录已存在",
+			"code":    "RECORD_CONFLICT",
+			"message": err.Error(),
+		})
+	case errors.Is(err, providers.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "DNS记录在服务商处不存在",
+			"code":    "PROVIDER_RECORD_NOT_FOUND",
+			"message": err.Error(),
+		})
+	case errors.Is(err, providers.ErrZoneNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "域名未在DNS服务商处托管",
+			"code":    "ZONE_NOT_FOUND",
+			"message": err.Error(),
+		})
+	case errors.Is(err, providers.ErrQuotaExceeded):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "DNS服务商配额不足",
+			"code":    "PROVIDER_QUOTA_EXCEEDED",
+			"message": err.Error(),
+		})
+	default:
+		return false
+	}
+	return true
+}
@@ -1,12 +1,12 @@
 package api
 
 import (
-	"context"
 	"domain-max/pkg/dns/models"
 	"domain-max/pkg/dns/providers"
 	"domain-max/pkg/utils"
+	"errors"
+	"log"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,16 +16,16 @@ import (
 // SimpleDNSAPI 简化的DNS管理API控制器
 type SimpleDNSAPI struct {
 	DB               *gorm.DB
-	ProviderFactory  *providers.ProviderFactory
+	ProviderManager  *providers.ProviderManager
 	EncryptionService *utils.EncryptionService
 	Validator        *utils.ValidationService
 }
 
 // NewSimpleDNSAPI 创建简化DNS API实例
-func NewSimpleDNSAPI(db *gorm.DB, factory *providers.ProviderFactory, encService *utils.EncryptionService, validator *utils.ValidationService) *SimpleDNSAPI {
+func NewSimpleDNSAPI(db *gorm.DB, manager *providers.ProviderManager, encService *utils.EncryptionService, validator *utils.ValidationService) *SimpleDNSAPI {
 	return &SimpleDNSAPI{
 		DB:               db,
-		ProviderFactory:  factory,
+		ProviderManager:  manager,
 		EncryptionService: encService,
 		Validator:        validator,
 	}
@@ -40,6 +40,15 @@ type DNSProviderRequest struct {
 	IsDefault   bool              `json:"is_default,omitempty"`
 }
 
+// UpdateDNSProviderRequest DNS提供商更新请求，未提供的字段保持不变
+type UpdateDNSProviderRequest struct {
+	Name        string            `json:"name,omitempty"`
+	Config      map[string]string `json:"config,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	IsDefault   *bool             `json:"is_default,omitempty"`
+	IsActive    *bool             `json:"is_active,omitempty"`
+}
+
 // GetDNSProviders 获取DNS提供商列表
 func (d *SimpleDNSAPI) GetDNSProviders(c *gin.Context) {
 	userID, _, _, role, ok := getUserFromContext(c)
@@ -96,7 +105,7 @@ func (d *SimpleDNSAPI) CreateDNSProvider(c *gin.Context) {
 	}
 
 	// 检查提供商类型是否支持
-	if !d.ProviderFactory.IsSupported(req.Type) {
+	if !d.ProviderManager.Factory().IsSupported(req.Type) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "不支持的DNS提供商类型",
 			"code":    "UNSUPPORTED_PROVIDER",
@@ -105,8 +114,14 @@ func (d *SimpleDNSAPI) CreateDNSProvider(c *gin.Context) {
 		return
 	}
 
+	// 按服务商的配置项校验凭据配置
+	if err := providers.ValidateProviderConfig(req.Type, req.Config); err != nil {
+		respondInvalidConfig(c, err)
+		return
+	}
+
 	// 验证配置的有效性
-	provider, err := d.ProviderFactory.CreateProvider(req.Type, req.Config)
+	provider, err := d.ProviderManager.Factory().CreateProvider(req.Type, req.Config)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "DNS提供商配置无效",
@@ -117,7 +132,7 @@ func (d *SimpleDNSAPI) CreateDNSProvider(c *gin.Context) {
 	}
 
 	// 测试连接
-	ctx := context.Background()
+	ctx := c.Request.Context()
 	if err := provider.TestConnection(ctx); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "DNS提供商连接测试失败",
@@ -126,6 +141,7 @@ func (d *SimpleDNSAPI) CreateDNSProvider(c *gin.Context) {
 		})
 		return
 	}
+	testedAt := time.Now()
 
 	// 加密配置信息
 	encryptedConfig, err := d.EncryptionService.EncryptJSON(req.Config)
@@ -147,6 +163,9 @@ func (d *SimpleDNSAPI) CreateDNSProvider(c *gin.Context) {
 		Description: req.Description,
 		IsDefault:   req.IsDefault && role == "admin", // 只有管理员可以设置默认
 		Status:      "active",
+		IsActive:    true,
+		LastTestAt:  &testedAt,
+		TestResult:  "success",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -159,6 +178,8 @@ func (d *SimpleDNSAPI) CreateDNSProvider(c *gin.Context) {
 		})
 		return
 	}
+	// 缓存测试连接时创建的实例，后续请求直接使用
+	d.ProviderManager.RegisterProvider(dnsProvider.ID, provider)
 
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
@@ -167,8 +188,8 @@ func (d *SimpleDNSAPI) CreateDNSProvider(c *gin.Context) {
 	})
 }
 
-// TestDNSProvider 测试DNS提供商连接
-func (d *SimpleDNSAPI) TestDNSProvider(c *gin.Context) {
+// UpdateDNSProvider 更新DNS提供商配置
+func (d *SimpleDNSAPI) UpdateDNSProvider(c *gin.Context) {
 	userID, _, _, role, ok := getUserFromContext(c)
 	if !ok {
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -178,7 +199,7 @@ func (d *SimpleDNSAPI) TestDNSProvider(c *gin.Context) {
 		return
 	}
 
-	providerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	providerID, err := parseIDParam(c, "id")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "无效的提供商ID",
@@ -188,14 +209,18 @@ func (d *SimpleDNSAPI) TestDNSProvider(c *gin.Context) {
 		return
 	}
 
-	// 查找DNS提供商
-	var dnsProvider models.DNSProvider
-	query := d.DB.Where("id = ?", providerID)
-	if role != "admin" {
-		query = query.Where("user_id = ?", userID)
+	var req UpdateDNSProviderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "请求参数错误",
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
 	}
 
-	if err := query.First(&dnsProvider).Error; err != nil {
+	dnsProvider, err := d.findProvider(providerID, userID, role)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error":   "DNS提供商不存在",
 			"code":    "PROVIDER_NOT_FOUND",
@@ -204,19 +229,201 @@ func (d *SimpleDNSAPI) TestDNSProvider(c *gin.Context) {
 		return
 	}
 
-	// 解密配置
-	config, err := d.EncryptionService.DecryptJSON(dnsProvider.Config)
+	updates := map[string]interface{}{
+		"updated_at": time.Now(),
+	}
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+		if *req.IsActive {
+			updates["status"] = "active"
+		} else {
+			updates["status"] = "inactive"
+		}
+	}
+	// 只有管理员可以设置默认
+	if req.IsDefault != nil && role == "admin" {
+		updates["is_default"] = *req.IsDefault
+	}
+
+	// 更新凭据时需要重新验证配置并测试连接
+	var provider providers.DNSProvider
+	if len(req.Config) > 0 {
+		if err := providers.ValidateProviderConfig(dnsProvider.Type, req.Config); err != nil {
+			respondInvalidConfig(c, err)
+			return
+		}
+
+		provider, err = d.ProviderManager.Factory().CreateProvider(dnsProvider.Type, req.Config)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "DNS提供商配置无效",
+				"code":    "INVALID_PROVIDER_CONFIG",
+				"message": err.Error(),
+			})
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := provider.TestConnection(ctx); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "DNS提供商连接测试失败",
+				"code":    "PROVIDER_CONNECTION_ERROR",
+				"message": err.Error(),
+			})
+			return
+		}
+
+		encryptedConfig, err := d.EncryptionService.EncryptJSON(req.Config)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "配置加密失败",
+				"code":    "CONFIG_ENCRYPTION_ERROR",
+				"message": "服务器内部错误",
+			})
+			return
+		}
+		updates["config"] = encryptedConfig
+		updates["last_test_at"] = time.Now()
+		updates["test_result"] = "success"
+	}
+
+	if err := d.DB.Model(dnsProvider).Updates(updates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "DNS提供商更新失败",
+			"code":    "PROVIDER_UPDATE_ERROR",
+			"message": err.Error(),
+		})
+		return
+	}
+	// 凭据已更新，缓存新配置创建的实例替换旧实例
+	if provider != nil {
+		d.ProviderManager.RegisterProvider(dnsProvider.ID, provider)
+	}
+
+	// 按字段更新不会回写内存中的记录，重新读取以返回更新后的数据
+	if err := d.DB.First(dnsProvider, dnsProvider.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "获取DNS提供商失败",
+			"code":    "PROVIDER_FETCH_ERROR",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "DNS提供商更新成功",
+		"data":    dnsProvider,
+	})
+}
+
+// DeleteDNSProvider 删除DNS提供商配置
+func (d *SimpleDNSAPI) DeleteDNSProvider(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	providerID, err := parseIDParam(c, "id")
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "无效的提供商ID",
+			"code":    "INVALID_PROVIDER_ID",
+			"message": "提供商ID必须是数字",
+		})
+		return
+	}
+
+	dnsProvider, err := d.findProvider(providerID, userID, role)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "DNS提供商不存在",
+			"code":    "PROVIDER_NOT_FOUND",
+			"message": "未找到指定的DNS提供商",
+		})
+		return
+	}
+
+	// 仍有域名绑定该凭据时不允许删除
+	var boundDomains int64
+	if err := d.DB.Model(&models.Domain{}).Where("dns_provider_id = ?", dnsProvider.ID).Count(&boundDomains).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "配置解密失败",
-			"code":    "CONFIG_DECRYPT_ERROR",
-			"message": "服务器内部错误",
+			"error":   "DNS提供商删除失败",
+			"code":    "PROVIDER_DELETE_ERROR",
+			"message": err.Error(),
+		})
+		return
+	}
+	if boundDomains > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "DNS提供商仍在使用中",
+			"code":    "PROVIDER_IN_USE",
+			"message": "请先解除域名与该DNS提供商的绑定",
+		})
+		return
+	}
+
+	if err := d.DB.Delete(dnsProvider).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "DNS提供商删除失败",
+			"code":    "PROVIDER_DELETE_ERROR",
+			"message": err.Error(),
+		})
+		return
+	}
+	d.ProviderManager.Invalidate(dnsProvider.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "DNS提供商删除成功",
+	})
+}
+
+// TestDNSProvider 测试DNS提供商连接
+func (d *SimpleDNSAPI) TestDNSProvider(c *gin.Context) {
+	userID, _, _, role, ok := getUserFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未找到用户信息",
+			"code":  "USER_NOT_FOUND",
+		})
+		return
+	}
+
+	providerID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "无效的提供商ID",
+			"code":    "INVALID_PROVIDER_ID",
+			"message": "提供商ID必须是数字",
+		})
+		return
+	}
+
+	// 查找DNS提供商
+	dnsProvider, err := d.findProvider(providerID, userID, role)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "DNS提供商不存在",
+			"code":    "PROVIDER_NOT_FOUND",
+			"message": "未找到指定的DNS提供商",
 		})
 		return
 	}
 
-	// 创建提供商实例并测试连接
-	provider, err := d.ProviderFactory.CreateProvider(dnsProvider.Type, config)
+	// 获取缓存的提供商实例并测试连接，未缓存时解密配置创建实例
+	ctx := c.Request.Context()
+	provider, err := d.ProviderManager.GetProvider(ctx, dnsProvider.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "创建提供商实例失败",
@@ -226,12 +433,13 @@ func (d *SimpleDNSAPI) TestDNSProvider(c *gin.Context) {
 		return
 	}
 
-	ctx := context.Background()
-	if err := provider.TestConnection(ctx); err != nil {
+	testErr := provider.TestConnection(ctx)
+	d.recordTestResult(dnsProvider, testErr)
+	if testErr != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":   "连接测试失败",
 			"code":    "CONNECTION_TEST_FAILED",
-			"message": err.Error(),
+			"message": testErr.Error(),
 		})
 		return
 	}
@@ -239,15 +447,97 @@ func (d *SimpleDNSAPI) TestDNSProvider(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "连接测试成功",
+		"data": gin.H{
+			"last_test_at": dnsProvider.LastTestAt,
+			"test_result":  dnsProvider.TestResult,
+		},
 	})
 }
 
-// ListSupportedProviders 获取支持的DNS提供商类型
+// ListSupportedProviders 获取支持的DNS提供商类型，包含显示名称、功能特性和配置表单
 func (d *SimpleDNSAPI) ListSupportedProviders(c *gin.Context) {
-	supportedTypes := d.ProviderFactory.GetSupportedTypes()
-	
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    supportedTypes,
+		"data":    providers.Definitions(),
+	})
+}
+
+// GetProviderSchema 获取DNS提供商类型的凭据配置项，用于生成配置表单
+func (d *SimpleDNSAPI) GetProviderSchema(c *gin.Context) {
+	definition, exists := providers.Lookup(c.Param("type"))
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "不支持的DNS提供商类型",
+			"code":    "UNSUPPORTED_PROVIDER",
+			"message": "当前不支持该DNS提供商",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"type":          definition.Type,
+			"display_name":  definition.DisplayName,
+			"config_schema": definition.ConfigSchema,
+		},
+	})
+}
+
+// GetZoneCacheStats 获取服务商域名ID缓存的命中统计
+func (d *SimpleDNSAPI) GetZoneCacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    providers.GetZoneCacheStats(),
 	})
-}
\ No newline at end of file
+}
+
+// respondInvalidConfig 返回凭据配置校验失败的响应，包含每个配置项的错误原因
+func respondInvalidConfig(c *gin.Context, err error) {
+	response := gin.H{
+		"error":   "DNS提供商配置无效",
+		"code":    "INVALID_PROVIDER_CONFIG",
+		"message": err.Error(),
+	}
+	var configErr *providers.ConfigError
+	if errors.As(err, &configErr) {
+		response["fields"] = configErr.Fields
+	}
+	c.JSON(http.StatusBadRequest, response)
+}
+
+// findProvider 查找当前用户有权操作的DNS提供商，非管理员只能操作自己创建的提供商
+func (d *SimpleDNSAPI) findProvider(providerID, userID uint, role string) (*models.DNSProvider, error) {
+	var dnsProvider models.DNSProvider
+	query := d.DB.Where("id = ?", providerID)
+	if role != "admin" {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if err := query.First(&dnsProvider).Error; err != nil {
+		return nil, err
+	}
+	return &dnsProvider, nil
+}
+
+// recordTestResult 保存最近一次连接测试的时间和结果
+func (d *SimpleDNSAPI) recordTestResult(dnsProvider *models.DNSProvider, testErr error) {
+	now := time.Now()
+	result := "success"
+	if testErr != nil {
+		result = testErr.Error()
+		// TestResult字段最长1000个字符
+		if runes := []rune(result); len(runes) > 1000 {
+			result = string(runes[:1000])
+		}
+	}
+
+	dnsProvider.LastTestAt = &now
+	dnsProvider.TestResult = result
+	if err := d.DB.Model(dnsProvider).Updates(map[string]interface{}{
+		"last_test_at": now,
+		"test_result":  result,
+	}).Error; err != nil {
+		log.Printf("保存DNS提供商测试结果失败: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	authmodels "domain-max/pkg/auth/models"
 	dnsmodels "domain-max/pkg/dns/models"
+	"domain-max/pkg/dns/providers"
 	emailmodels "domain-max/pkg/email/models"
 	"log"
 
@@ -92,81 +93,22 @@ func createIndexes(db *gorm.DB) error {
 
 // insertDefaultData 插入默认数据
 func insertDefaultData(db *gorm.DB) error {
-	// 插入默认DNS服务商配置
-	providers := []dnsmodels.DNSProvider{
-		{
-			Name:        "阿里云DNS",
-			Type:        "aliyun",
-			Description: "阿里云云解析DNS服务",
-			IsActive:    true,
-			SortOrder:   1,
-		},
-		{
-			Name:        "腾讯云DNSPod",
-			Type:        "dnspod",
-			Description: "腾讯云DNSPod服务",
-			IsActive:    true,
-			SortOrder:   2,
-		},
-		{
-			Name:        "CloudFlare",
-			Type:        "cloudflare",
-			Description: "CloudFlare DNS服务",
+	// 插入默认DNS服务商配置，服务商列表来自适配器的注册信息，跳过模拟服务商等开发用的服务商
+	var defaultProviders []dnsmodels.DNSProvider
+	for _, definition := range providers.Definitions() {
+		if definition.Development {
+			continue
+		}
+		defaultProviders = append(defaultProviders, dnsmodels.DNSProvider{
+			Name:        definition.DisplayName,
+			Type:        definition.Type,
+			Description: definition.Description,
 			IsActive:    true,
-			SortOrder:   3,
-		},
-		{
-			Name:        "华为云DNS",
-			Type:        "huawei",
-			Description: "华为云云解析服务",
-			IsActive:    false,
-			SortOrder:   4,
-		},
-		{
-			Name:        "百度云DNS",
-			Type:        "baidu",
-			Description: "百度智能云DNS服务",
-			IsActive:    false,
-			SortOrder:   5,
-		},
-		{
-			Name:        "西部数码DNS",
-			Type:        "west",
-			Description: "西部数码域名解析服务",
-			IsActive:    false,
-			SortOrder:   6,
-		},
-		{
-			Name:        "火山引擎DNS",
-			Type:        "volcengine",
-			Description: "火山引擎TrafficRoute DNS",
-			IsActive:    false,
-			SortOrder:   7,
-		},
-		{
-			Name:        "DNSLA",
-			Type:        "dnsla",
-			Description: "DNSLA专业DNS解析服务",
-			IsActive:    false,
-			SortOrder:   8,
-		},
-		{
-			Name:        "Namesilo",
-			Type:        "namesilo",
-			Description: "Namesilo域名DNS服务",
-			IsActive:    false,
-			SortOrder:   9,
-		},
-		{
-			Name:        "PowerDNS",
-			Type:        "powerdns",
-			Description: "PowerDNS开源DNS服务器",
-			IsActive:    false,
-			SortOrder:   10,
-		},
+			SortOrder:   definition.SortOrder,
+		})
 	}
 	
-	for _, provider := range providers {
+	for _, provider := range defaultProviders {
 		var existing dnsmodels.DNSProvider
 		result := db.Where("type = ?", provider.Type).First(&existing)
 		if result.Error != nil && result.Error.Error() == "record not found" {
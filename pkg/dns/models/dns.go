@@ -1,6 +1,8 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -14,16 +16,18 @@ import (
 
 // Domain 域名模型（根据文档规范优化）
 type Domain struct {
-	ID         uint           `json:"id" gorm:"primaryKey"`
-	UserID     uint           `json:"user_id" gorm:"not null;index"`                     // 域名所属用户
-	DomainName string         `json:"domain_name" gorm:"uniqueIndex;not null;size:255"` // 域名名称
-	Platform   string         `json:"platform" gorm:"not null;size:50;index"`           // DNS平台
-	APIKey     string         `json:"-" gorm:"not null;size:500"`                       // 加密存储的API密钥
-	APISecret  string         `json:"-" gorm:"not null;size:500"`                       // 加密存储的API密钥
-	IsActive   bool           `json:"is_active" gorm:"default:true;index"`              // 是否活跃
-	CreatedAt  time.Time      `json:"created_at" gorm:"index"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	UserID        uint           `json:"user_id" gorm:"not null;index"`                     // 域名所属用户
+	DomainName    string         `json:"domain_name" gorm:"uniqueIndex;not null;size:255"` // 域名名称
+	Platform      string         `json:"platform" gorm:"not null;size:50;index"`           // DNS平台
+	APIKey        string         `json:"-" gorm:"not null;size:500"`                       // 加密存储的API密钥
+	APISecret     string         `json:"-" gorm:"not null;size:500"`                       // 加密存储的API密钥
+	DNSProviderID *uint          `json:"dns_provider_id" gorm:"index"`                     // 绑定的DNS服务商凭据
+	ZoneID        string         `json:"zone_id" gorm:"size:100"`                          // 服务商侧的域名ID
+	IsActive      bool           `json:"is_active" gorm:"default:true;index"`              // 是否活跃
+	CreatedAt     time.Time      `json:"created_at" gorm:"index"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// 关联
 	SubDomains []SubDomain `json:"sub_domains,omitempty" gorm:"foreignKey:DomainID"`
@@ -38,6 +42,7 @@ type SubDomain struct {
 	RecordValue   string         `json:"record_value" gorm:"not null;size:1000"`                         // 记录值
 	TTL           int            `json:"ttl" gorm:"default:600;check:ttl >= 1 AND ttl <= 604800"`        // TTL值
 	Status        string         `json:"status" gorm:"default:active;size:20;index"`                     // 状态：active、inactive、pending
+	ExternalID    string         `json:"external_id" gorm:"size:100;index"`                              // DNS服务商记录ID
 	CreatedAt     time.Time      `json:"created_at" gorm:"index"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
@@ -61,9 +66,11 @@ type DNSRecord struct {
 	Priority   int            `json:"priority" gorm:"default:0"`                               // MX和SRV记录优先级
 	Weight     int            `json:"weight" gorm:"default:0"`                                 // SRV记录权重
 	Port       int            `json:"port" gorm:"default:0"`                                   // SRV记录端口
+	Line       string         `json:"line" gorm:"size:50"`                                     // 解析线路，为空时使用默认线路
 	ExternalID string         `json:"external_id" gorm:"size:100"`                             // DNS服务商记录ID
 	Status     string         `json:"status" gorm:"default:active;size:20"`                    // 记录状态
 	Comment    string         `json:"comment" gorm:"size:500"`                                 // 记录备注，增加长度
+	Extra      RecordExtra    `json:"extra,omitempty" gorm:"type:text"`                        // 服务商特有的记录属性
 	CreatedAt  time.Time      `json:"created_at" gorm:"index"`                                 // 添加时间索引
 	UpdatedAt  time.Time      `json:"updated_at"`
 	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
@@ -72,6 +79,42 @@ type DNSRecord struct {
 	Domain Domain `json:"domain,omitempty" gorm:"foreignKey:DomainID;constraint:OnDelete:CASCADE"`
 }
 
+// RecordExtra 服务商特有的记录属性，如CloudFlare的proxied、comment和tags，以JSON格式存储
+type RecordExtra map[string]string
+
+// Value 实现driver.Valuer接口
+func (e RecordExtra) Value() (driver.Value, error) {
+	if len(e) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan 实现sql.Scanner接口
+func (e *RecordExtra) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("无法解析记录扩展属性: %T", value)
+	}
+	if len(data) == 0 {
+		*e = nil
+		return nil
+	}
+	return json.Unmarshal(data, e)
+}
+
 // DNSProvider DNS服务商模型
 type DNSProvider struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
@@ -103,21 +146,38 @@ type CreateDNSRecordRequest struct {
 	Priority       int    `json:"priority"`        // MX和SRV记录的优先级
 	Weight         int    `json:"weight"`          // SRV记录的权重
 	Port           int    `json:"port"`            // SRV记录的端口
+	Line           string `json:"line"`            // 解析线路，为空时使用默认线路
 	Comment        string `json:"comment"`         // 记录备注
 	AllowPrivateIP bool   `json:"allow_private_ip"` // 是否允许私有IP
+
+	Extra map[string]string `json:"extra"` // 服务商特有的记录属性
 }
 
 // UpdateDNSRecordRequest DNS记录更新请求
 type UpdateDNSRecordRequest struct {
 	Subdomain      string `json:"subdomain"`
-	Type           string `json:"type" binding:"oneof=A AAAA CNAME TXT MX NS PTR SRV CAA"`
+	Type           string `json:"type" binding:"omitempty,oneof=A AAAA CNAME TXT MX NS PTR SRV CAA"`
 	Value          string `json:"value"`
 	TTL            int    `json:"ttl"`
 	Priority       int    `json:"priority"`
 	Weight         int    `json:"weight"`
 	Port           int    `json:"port"`
+	Line           string `json:"line"`
 	Comment        string `json:"comment"`
 	AllowPrivateIP bool   `json:"allow_private_ip"`
+
+	Extra map[string]string `json:"extra"` // 需要修改的服务商特有属性，未提供的键保留原值
+}
+
+// CreateDomainRequest 域名接入请求
+type CreateDomainRequest struct {
+	DomainName    string `json:"domain_name" binding:"required"`
+	DNSProviderID uint   `json:"dns_provider_id" binding:"required"`
+}
+
+// ImportDomainsRequest 从服务商账号导入全部域名请求
+type ImportDomainsRequest struct {
+	DNSProviderID uint `json:"dns_provider_id" binding:"required"`
 }
 
 // BatchDNSRecordRequest DNS记录批量操作请求
@@ -207,8 +267,8 @@ func validateSubdomain(subdomain string) error {
 		return errors.New("子域名长度不能超过63个字符")
 	}
 	
-	// 支持通配符子域名
-	if subdomain == "*" {
+	// 支持通配符子域名和根域名记录
+	if subdomain == "*" || subdomain == "@" {
 		return nil
 	}
 	
@@ -450,6 +510,11 @@ func validateCAARecord(value string) error {
 	return nil
 }
 
+// ValidateDomainName 验证域名格式
+func ValidateDomainName(domain string) error {
+	return validateDomainName(domain)
+}
+
 // validateTTL 验证TTL值
 func validateTTL(ttl int) error {
 	if ttl < 1 {
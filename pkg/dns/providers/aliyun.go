@@ -2,26 +2,94 @@ package providers
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha1"
-	"encoding/base64"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // AliyunProvider 阿里云DNS服务商
+// 请求使用V3签名（ACS3-HMAC-SHA256），支持以下凭据方式：
+//   - AccessKey: APIKey和APISecret
+//   - STS临时凭据: APIKey、APISecret为临时AccessKey，Token为SecurityToken
+//   - RAM角色: ExtraParams中配置role_arn，使用上述凭据调用AssumeRole获取临时凭据，过期前自动刷新
+//
+// RAM角色相关的ExtraParams：
+//   - role_arn: 要扮演的RAM角色ARN
+//   - role_session_name: 角色会话名称，默认为domain-max
+//   - role_duration_seconds: 临时凭据有效期（秒），默认为3600
+//   - sts_endpoint: STS服务地址，默认为https://sts.aliyuncs.com
 type AliyunProvider struct {
 	config     ProviderConfig
 	httpClient *http.Client
 	endpoint   string
 }
 
+// aliyunCredential 阿里云访问凭据
+type aliyunCredential struct {
+	AccessKeyID     string
+	AccessKeySecret string
+	SecurityToken   string
+	Expiration      time.Time
+}
+
+// aliyunRoleCache 扮演RAM角色获得的临时凭据缓存
+type aliyunRoleCache struct {
+	mu         sync.Mutex
+	credential aliyunCredential
+}
+
+const (
+	// aliyunSignatureAlgorithm V3签名算法
+	aliyunSignatureAlgorithm = "ACS3-HMAC-SHA256"
+	// aliyunRefreshBefore 临时凭据在过期前多久刷新
+	aliyunRefreshBefore = 5 * time.Minute
+)
+
+// aliyunRoleCredentials 按AccessKey和角色ARN共享的临时凭据，有效期内跨实例复用，避免每次创建实例都调用AssumeRole
+var aliyunRoleCredentials sync.Map
+
+// init 注册阿里云DNS服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "aliyun",
+		DisplayName: "阿里云DNS",
+		Description: "阿里云云解析DNS服务",
+		SortOrder:   1,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
+			SupportsBatch:        false,
+			SupportsLineTypes:    true,
+			MaxRecordsPerDomain:  10000,
+			MinTTL:               1,
+			MaxTTL:               604800,
+			RateLimit:            20,
+			RateBurst:            20,
+		},
+		ConfigSchema: accessKeySchema("AccessKey ID", "AccessKey Secret",
+			ConfigField{Key: "token", Label: "STS Token", Type: FieldText, Secret: true,
+				Help: "使用STS临时凭据时填写，AccessKey需要同时使用临时凭据"},
+			ConfigField{Key: "role_arn", Label: "RAM角色ARN", Type: FieldText, Placeholder: "acs:ram::123456789012:role/dns-admin",
+				Help: "填写后使用AccessKey扮演该角色，以角色的临时凭据调用接口"},
+			ConfigField{Key: "role_session_name", Label: "角色会话名称", Type: FieldText, Default: "domain-max"},
+			ConfigField{Key: "role_duration_seconds", Label: "角色凭据有效期（秒）", Type: FieldNumber, Default: "3600",
+				Min: intPtr(900), Max: intPtr(43200)},
+			ConfigField{Key: "sts_endpoint", Label: "STS接口地址", Type: FieldText, Placeholder: "https://sts.aliyuncs.com"},
+		),
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewAliyunProvider(config)
+		},
+	})
+}
+
 // NewAliyunProvider 创建阿里云DNS服务商实例
 func NewAliyunProvider(config ProviderConfig) (*AliyunProvider, error) {
 	if config.APIKey == "" || config.APISecret == "" {
@@ -55,6 +123,11 @@ func (p *AliyunProvider) ValidateConfig() error {
 	if p.config.APISecret == "" {
 		return fmt.Errorf("阿里云DNS API Secret不能为空")
 	}
+	if value := p.config.ExtraParams["role_duration_seconds"]; value != "" {
+		if seconds, err := strconv.Atoi(value); err != nil || seconds < 900 || seconds > 43200 {
+			return fmt.Errorf("角色凭据有效期需要在900-43200秒之间: %s", value)
+		}
+	}
 	return nil
 }
 
@@ -72,52 +145,74 @@ func (p *AliyunProvider) TestConnection(ctx context.Context) error {
 
 // ListRecords 获取域名记录列表
 func (p *AliyunProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	params := map[string]string{
-		"Action":     "DescribeDomainRecords",
-		"Version":    "2015-01-09",
-		"DomainName": domain,
-		"PageSize":   "500",
-	}
-	
-	response, err := p.makeRequest(ctx, params)
-	if err != nil {
-		return nil, err
-	}
-	
-	var result struct {
-		DomainRecords struct {
-			Record []struct {
-				RecordId string `json:"RecordId"`
-				RR       string `json:"RR"`
-				Type     string `json:"Type"`
-				Value    string `json:"Value"`
-				TTL      int    `json:"TTL"`
-				Priority int    `json:"Priority"`
-				Line     string `json:"Line"`
-				Status   string `json:"Status"`
-			} `json:"Record"`
-		} `json:"DomainRecords"`
-	}
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *AliyunProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	const pageSize = 500
+	var records []DNSRecord
 	
-	if err := json.Unmarshal(response, &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %v", err)
+	for page := 1; ; page++ {
+		params := map[string]string{
+			"Action":     "DescribeDomainRecords",
+			"Version":    "2015-01-09",
+			"DomainName": domain,
+			"PageNumber": fmt.Sprintf("%d", page),
+			"PageSize":   fmt.Sprintf("%d", pageSize),
+		}
+		// RRKeyWord为模糊匹配，结果需要再做精确过滤
+		if filter.Name != "" {
+			params["RRKeyWord"] = filter.Name
+		}
+		if filter.Type != "" {
+			params["TypeKeyWord"] = strings.ToUpper(filter.Type)
+		}
+		
+		response, err := p.makeRequest(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		
+		var result struct {
+			TotalCount    int `json:"TotalCount"`
+			DomainRecords struct {
+				Record []struct {
+					RecordId string `json:"RecordId"`
+					RR       string `json:"RR"`
+					Type     string `json:"Type"`
+					Value    string `json:"Value"`
+					TTL      int    `json:"TTL"`
+					Priority int    `json:"Priority"`
+					Line     string `json:"Line"`
+					Status   string `json:"Status"`
+				} `json:"Record"`
+			} `json:"DomainRecords"`
+		}
+		
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+		
+		for _, record := range result.DomainRecords.Record {
+			records = append(records, DNSRecord{
+				ID:       record.RecordId,
+				Name:     record.RR,
+				Type:     record.Type,
+				Value:    record.Value,
+				TTL:      record.TTL,
+				Priority: record.Priority,
+				Line:     record.Line,
+				Status:   record.Status,
+			})
+		}
+		
+		if len(result.DomainRecords.Record) < pageSize || page*pageSize >= result.TotalCount {
+			break
+		}
 	}
 	
-	var records []DNSRecord
-	for _, record := range result.DomainRecords.Record {
-		records = append(records, DNSRecord{
-			ID:       record.RecordId,
-			Name:     record.RR,
-			Type:     record.Type,
-			Value:    record.Value,
-			TTL:      record.TTL,
-			Priority: record.Priority,
-			Line:     record.Line,
-			Status:   record.Status,
-		})
-	}
-	
-	return records, nil
+	return filterRecords(records, filter), nil
 }
 
 // AddRecord 添加DNS记录
@@ -150,7 +245,7 @@ func (p *AliyunProvider) AddRecord(ctx context.Context, domain string, record DN
 	}
 	
 	if err := json.Unmarshal(response, &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %v", err)
+		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
 	
 	// 返回创建的记录，包含分配的ID
@@ -196,6 +291,24 @@ func (p *AliyunProvider) DeleteRecord(ctx context.Context, domain string, record
 	return err
 }
 
+// SetRecordStatus 启用或暂停DNS记录
+func (p *AliyunProvider) SetRecordStatus(ctx context.Context, domain string, recordID string, enabled bool) error {
+	status := "Disable"
+	if enabled {
+		status = "Enable"
+	}
+	
+	params := map[string]string{
+		"Action":   "SetDomainRecordStatus",
+		"Version":  "2015-01-09",
+		"RecordId": recordID,
+		"Status":   status,
+	}
+	
+	_, err := p.makeRequest(ctx, params)
+	return err
+}
+
 // GetRecord 获取单个记录详情
 func (p *AliyunProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
 	params := map[string]string{
@@ -222,7 +335,7 @@ func (p *AliyunProvider) GetRecord(ctx context.Context, domain string, recordID
 	}
 	
 	if err := json.Unmarshal(response, &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %v", err)
+		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
 	
 	return &DNSRecord{
@@ -237,7 +350,7 @@ func (p *AliyunProvider) GetRecord(ctx context.Context, domain string, recordID
 	}, nil
 }
 
-// BatchAddRecords 批量添加DNS记录
+// BatchAddRecords 逐条添加DNS记录，阿里云没有使用批量接口，因此不声明SupportsBatch
 func (p *AliyunProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
 	var results []DNSRecord
 	var errors []error
@@ -258,43 +371,112 @@ func (p *AliyunProvider) BatchAddRecords(ctx context.Context, domain string, rec
 	return results, nil
 }
 
-// makeRequest 发起API请求
+// ListZones 获取账号下托管的全部域名
+func (p *AliyunProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	const pageSize = 100
+	var zones []Zone
+	
+	for page := 1; ; page++ {
+		params := map[string]string{
+			"Action":     "DescribeDomains",
+			"Version":    "2015-01-09",
+			"PageNumber": fmt.Sprintf("%d", page),
+			"PageSize":   fmt.Sprintf("%d", pageSize),
+		}
+		
+		response, err := p.makeRequest(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		
+		var result struct {
+			TotalCount int `json:"TotalCount"`
+			Domains    struct {
+				Domain []struct {
+					DomainId    string `json:"DomainId"`
+					DomainName  string `json:"DomainName"`
+					RecordCount int    `json:"RecordCount"`
+				} `json:"Domain"`
+			} `json:"Domains"`
+		}
+		
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+		
+		for _, domain := range result.Domains.Domain {
+			zones = append(zones, Zone{
+				ID:          domain.DomainId,
+				Name:        domain.DomainName,
+				Status:      "active",
+				RecordCount: domain.RecordCount,
+			})
+		}
+		
+		if len(result.Domains.Domain) < pageSize || len(zones) >= result.TotalCount {
+			break
+		}
+	}
+	
+	return zones, nil
+}
+
+// makeRequest 发起API请求，params中的Action和Version作为公共请求头发送
 func (p *AliyunProvider) makeRequest(ctx context.Context, params map[string]string) ([]byte, error) {
-	// 添加公共参数
-	params["Format"] = "JSON"
-	params["AccessKeyId"] = p.config.APIKey
-	params["SignatureMethod"] = "HMAC-SHA1"
-	params["SignatureVersion"] = "1.0"
-	params["SignatureNonce"] = generateNonce()
-	params["Timestamp"] = time.Now().UTC().Format("2006-01-02T15:04:05Z")
-	
-	// 生成签名
-	signature := p.generateSignature(params)
-	params["Signature"] = signature
-	
-	// 构建请求URL
-	requestURL := p.buildRequestURL(params)
-	
-	// 发起请求
-	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	action := params["Action"]
+	version := params["Version"]
+	query := make(map[string]string, len(params))
+	for key, value := range params {
+		if key != "Action" && key != "Version" {
+			query[key] = value
+		}
+	}
+	
+	credential, err := p.credential(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	return p.call(ctx, p.endpoint, action, version, query, credential)
+}
+
+// call 使用V3签名调用阿里云RPC风格接口
+func (p *AliyunProvider) call(ctx context.Context, endpoint, action, version string, query map[string]string, credential aliyunCredential) ([]byte, error) {
+	requestURL, err := url.Parse(strings.TrimSuffix(endpoint, "/") + "/")
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %v", err)
+		return nil, fmt.Errorf("无效的API地址: %w", err)
 	}
+	requestURL.RawQuery = aliyunCanonicalQuery(query)
+	
+	req, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	
+	nonce, err := generateNonce()
+	if err != nil {
+		return nil, err
+	}
+	
+	req.Header.Set("x-acs-action", action)
+	req.Header.Set("x-acs-version", version)
+	req.Header.Set("x-acs-date", time.Now().UTC().Format("2006-01-02T15:04:05Z"))
+	req.Header.Set("x-acs-signature-nonce", nonce)
+	req.Header.Set("x-acs-content-sha256", sha256Hex(""))
+	if credential.SecurityToken != "" {
+		req.Header.Set("x-acs-security-token", credential.SecurityToken)
+	}
+	req.Header.Set("Authorization", aliyunAuthorization(req, query, credential))
 	
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("发起请求失败: %v", err)
+		return nil, fmt.Errorf("发起请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 	
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %v", err)
-	}
-	
-	// 检查HTTP状态码
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API请求失败，状态码: %d, 响应: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("读取响应失败: %w", err)
 	}
 	
 	// 检查API错误
@@ -305,53 +487,161 @@ func (p *AliyunProvider) makeRequest(ctx context.Context, params map[string]stri
 	}
 	
 	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Code != "" {
-		return nil, fmt.Errorf("阿里云DNS API错误: %s - %s (RequestId: %s)", 
-			errorResp.Code, errorResp.Message, errorResp.RequestId)
+		return nil, newHTTPError("阿里云DNS", resp, errorResp.Code, errorResp.Message, errorResp.RequestId)
+	}
+	
+	// 检查HTTP状态码
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError("阿里云DNS", resp, string(body))
 	}
 	
 	return body, nil
 }
 
-// generateSignature 生成API签名
-func (p *AliyunProvider) generateSignature(params map[string]string) string {
-	// 排序参数
-	var keys []string
-	for k := range params {
-		if k != "Signature" {
-			keys = append(keys, k)
+// credential 获取当前请求使用的凭据，配置了role_arn时返回扮演角色得到的临时凭据
+func (p *AliyunProvider) credential(ctx context.Context) (aliyunCredential, error) {
+	base := aliyunCredential{
+		AccessKeyID:     p.config.APIKey,
+		AccessKeySecret: p.config.APISecret,
+		SecurityToken:   p.config.Token,
+	}
+	
+	roleARN := p.config.ExtraParams["role_arn"]
+	if roleARN == "" {
+		return base, nil
+	}
+	
+	sessionName := p.config.ExtraParams["role_session_name"]
+	if sessionName == "" {
+		sessionName = "domain-max"
+	}
+	
+	entry, _ := aliyunRoleCredentials.LoadOrStore(base.AccessKeyID+"|"+roleARN+"|"+sessionName, &aliyunRoleCache{})
+	cache := entry.(*aliyunRoleCache)
+	
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	
+	if cache.credential.AccessKeyID != "" && time.Until(cache.credential.Expiration) > aliyunRefreshBefore {
+		return cache.credential, nil
+	}
+	
+	credential, err := p.assumeRole(ctx, base, roleARN, sessionName)
+	if err != nil {
+		return aliyunCredential{}, fmt.Errorf("扮演RAM角色失败: %w", err)
+	}
+	cache.credential = credential
+	return credential, nil
+}
+
+// assumeRole 调用STS AssumeRole获取RAM角色的临时凭据
+func (p *AliyunProvider) assumeRole(ctx context.Context, base aliyunCredential, roleARN, sessionName string) (aliyunCredential, error) {
+	endpoint := p.config.ExtraParams["sts_endpoint"]
+	if endpoint == "" {
+		endpoint = "https://sts.aliyuncs.com"
+	}
+	
+	duration := p.config.ExtraParams["role_duration_seconds"]
+	if duration == "" {
+		duration = "3600"
+	}
+	
+	query := map[string]string{
+		"RoleArn":         roleARN,
+		"RoleSessionName": sessionName,
+		"DurationSeconds": duration,
+	}
+	
+	response, err := p.call(ctx, endpoint, "AssumeRole", "2015-04-01", query, base)
+	if err != nil {
+		return aliyunCredential{}, err
+	}
+	
+	var result struct {
+		Credentials struct {
+			AccessKeyId     string `json:"AccessKeyId"`
+			AccessKeySecret string `json:"AccessKeySecret"`
+			SecurityToken   string `json:"SecurityToken"`
+			Expiration      string `json:"Expiration"`
+		} `json:"Credentials"`
+	}
+	
+	if err := json.Unmarshal(response, &result); err != nil {
+		return aliyunCredential{}, fmt.Errorf("解析响应失败: %w", err)
+	}
+	
+	expiration, err := time.Parse(time.RFC3339, result.Credentials.Expiration)
+	if err != nil || result.Credentials.AccessKeyId == "" {
+		return aliyunCredential{}, fmt.Errorf("STS返回的临时凭据无效")
+	}
+	
+	return aliyunCredential{
+		AccessKeyID:     result.Credentials.AccessKeyId,
+		AccessKeySecret: result.Credentials.AccessKeySecret,
+		SecurityToken:   result.Credentials.SecurityToken,
+		Expiration:      expiration,
+	}, nil
+}
+
+// aliyunAuthorization 生成V3签名的Authorization请求头
+func aliyunAuthorization(req *http.Request, query map[string]string, credential aliyunCredential) string {
+	// 参与签名的请求头：host和全部x-acs-*请求头
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for key, values := range req.Header {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "x-acs-") && len(values) > 0 {
+			headers[lower] = strings.TrimSpace(values[0])
 		}
 	}
-	sort.Strings(keys)
 	
-	// 构建规范化查询字符串
-	var parts []string
-	for _, key := range keys {
-		parts = append(parts, url.QueryEscape(key)+"="+url.QueryEscape(params[key]))
+	signedHeaders := make([]string, 0, len(headers))
+	for key := range headers {
+		signedHeaders = append(signedHeaders, key)
 	}
-	canonicalQueryString := strings.Join(parts, "&")
+	sort.Strings(signedHeaders)
 	
-	// 构建待签名字符串
-	stringToSign := "GET&" + url.QueryEscape("/") + "&" + url.QueryEscape(canonicalQueryString)
+	var canonicalHeaders strings.Builder
+	for _, key := range signedHeaders {
+		canonicalHeaders.WriteString(key + ":" + headers[key] + "\n")
+	}
+	
+	canonicalRequest := req.Method + "\n" +
+		"/\n" +
+		aliyunCanonicalQuery(query) + "\n" +
+		canonicalHeaders.String() + "\n" +
+		strings.Join(signedHeaders, ";") + "\n" +
+		req.Header.Get("x-acs-content-sha256")
 	
-	// 计算签名
-	key := p.config.APISecret + "&"
-	mac := hmac.New(sha1.New, []byte(key))
-	mac.Write([]byte(stringToSign))
-	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	stringToSign := aliyunSignatureAlgorithm + "\n" + sha256Hex(canonicalRequest)
+	signature := hex.EncodeToString(hmacSha256([]byte(credential.AccessKeySecret), stringToSign))
 	
-	return signature
+	return aliyunSignatureAlgorithm + " Credential=" + credential.AccessKeyID +
+		",SignedHeaders=" + strings.Join(signedHeaders, ";") +
+		",Signature=" + signature
 }
 
-// buildRequestURL 构建请求URL
-func (p *AliyunProvider) buildRequestURL(params map[string]string) string {
-	values := url.Values{}
-	for k, v := range params {
-		values.Set(k, v)
+// aliyunCanonicalQuery 按参数名排序并以RFC 3986规则编码查询参数
+func aliyunCanonicalQuery(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, uriEscape(key)+"="+uriEscape(query[key]))
 	}
-	return p.endpoint + "/?" + values.Encode()
+	return strings.Join(parts, "&")
 }
 
-// generateNonce 生成随机字符串
-func generateNonce() string {
-	return fmt.Sprintf("%d", time.Now().UnixNano())
-}
\ No newline at end of file
+// generateNonce 生成随机字符串，用于防止请求重放
+func generateNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
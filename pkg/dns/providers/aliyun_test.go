@@ -0,0 +1,260 @@
+package providers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const aliyunTestSecret = "aliyun-test-secret"
+
+// aliyunSTSFake 同时模拟STS和云解析接口，按AccessKey校验V3签名，临时凭据必须携带并签名安全令牌
+type aliyunSTSFake struct {
+	t          *testing.T
+	mu         sync.Mutex
+	accessKey  string
+	secrets    map[string]string // AccessKey ID对应的Secret
+	tokens     map[string]string // 临时AccessKey ID对应的安全令牌
+	expiresIn  time.Duration     // 下一次签发的临时凭据的有效期
+	assumed    int
+	dnsCallers []string // 调用云解析接口使用的AccessKey ID
+}
+
+// newAliyunSTSFake 创建模拟服务器，AccessKey包含测试名称，避免不同测试共用按AccessKey缓存的临时凭据，测试结束后删除缓存
+func newAliyunSTSFake(t *testing.T) (*aliyunSTSFake, ProviderConfig) {
+	t.Helper()
+	fake := &aliyunSTSFake{
+		t:         t,
+		accessKey: "LTAI-" + t.Name(),
+		tokens:    make(map[string]string),
+		expiresIn: time.Hour,
+	}
+	fake.secrets = map[string]string{fake.accessKey: aliyunTestSecret}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() {
+		aliyunRoleCredentials.Range(func(key, _ interface{}) bool {
+			if strings.HasPrefix(key.(string), fake.accessKey+"|") {
+				aliyunRoleCredentials.Delete(key)
+			}
+			return true
+		})
+	})
+
+	return fake, ProviderConfig{
+		APIKey:    fake.accessKey,
+		APISecret: aliyunTestSecret,
+		Endpoint:  server.URL,
+		ExtraParams: map[string]string{
+			"role_arn":     "acs:ram::1234567890:role/dns-admin",
+			"sts_endpoint": server.URL,
+		},
+	}
+}
+
+func (f *aliyunSTSFake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	accessKeyID, code := f.authenticate(r)
+	if code != "" {
+		writeAliyunTestError(w, http.StatusBadRequest, code)
+		return
+	}
+
+	switch action := r.Header.Get("x-acs-action"); action {
+	case "AssumeRole":
+		query := r.URL.Query()
+		if accessKeyID != f.accessKey || r.Header.Get("x-acs-version") != "2015-04-01" ||
+			query.Get("RoleArn") == "" || query.Get("RoleSessionName") != "domain-max" || query.Get("DurationSeconds") != "3600" {
+			writeAliyunTestError(w, http.StatusBadRequest, "InvalidParameter")
+			return
+		}
+		f.assumed++
+		id := fmt.Sprintf("STS.key-%d", f.assumed)
+		f.secrets[id] = fmt.Sprintf("sts-secret-%d", f.assumed)
+		f.tokens[id] = fmt.Sprintf("sts-token-%d", f.assumed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"RequestId": "sts-request",
+			"Credentials": map[string]string{
+				"AccessKeyId":     id,
+				"AccessKeySecret": f.secrets[id],
+				"SecurityToken":   f.tokens[id],
+				"Expiration":      time.Now().Add(f.expiresIn).UTC().Format(time.RFC3339),
+			},
+		})
+	case "DescribeDomains":
+		// 配置了角色时云解析接口只接受临时凭据
+		if _, ok := f.tokens[accessKeyID]; !ok {
+			writeAliyunTestError(w, http.StatusForbidden, "Forbidden.RAM")
+			return
+		}
+		f.dnsCallers = append(f.dnsCallers, accessKeyID)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"RequestId":  "dns-request",
+			"TotalCount": 0,
+			"Domains":    map[string]interface{}{"Domain": []interface{}{}},
+		})
+	default:
+		writeAliyunTestError(w, http.StatusNotFound, "InvalidAction.NotFound")
+	}
+}
+
+// authenticate 按ACS3-HMAC-SHA256规则重新计算签名，返回请求使用的AccessKey ID或错误码
+func (f *aliyunSTSFake) authenticate(r *http.Request) (string, string) {
+	const prefix = "ACS3-HMAC-SHA256 "
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, prefix) {
+		return "", "IncompleteSignature"
+	}
+	fields := make(map[string]string)
+	for _, part := range strings.Split(strings.TrimPrefix(authorization, prefix), ",") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			fields[key] = value
+		}
+	}
+
+	accessKeyID := fields["Credential"]
+	secret, ok := f.secrets[accessKeyID]
+	if !ok {
+		return "", "InvalidAccessKeyId.NotFound"
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	signed := make(map[string]bool, len(signedHeaders))
+	for _, name := range signedHeaders {
+		signed[name] = true
+	}
+	// 临时凭据必须携带与AccessKey对应的安全令牌，且令牌参与签名
+	if token, temporary := f.tokens[accessKeyID]; temporary {
+		if r.Header.Get("x-acs-security-token") != token || !signed["x-acs-security-token"] {
+			return "", "InvalidSecurityToken.Mismatch"
+		}
+	} else if r.Header.Get("x-acs-security-token") != "" {
+		return "", "InvalidSecurityToken.Malformed"
+	}
+	for _, required := range []string{"host", "x-acs-action", "x-acs-date", "x-acs-signature-nonce", "x-acs-content-sha256"} {
+		if !signed[required] {
+			return "", "IncompleteSignature"
+		}
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, aliyunTestEscape(key)+"="+aliyunTestEscape(query.Get(key)))
+	}
+
+	canonicalRequest := r.Method + "\n/\n" + strings.Join(pairs, "&") + "\n" +
+		canonicalHeaders.String() + "\n" + fields["SignedHeaders"] + "\n" + r.Header.Get("x-acs-content-sha256")
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("ACS3-HMAC-SHA256\n" + hex.EncodeToString(hashed[:])))
+	if fields["Signature"] != hex.EncodeToString(mac.Sum(nil)) {
+		return "", "SignatureDoesNotMatch"
+	}
+	return accessKeyID, ""
+}
+
+// aliyunTestEscape 按RFC 3986编码，空格编码为%20，波浪号不编码
+func aliyunTestEscape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(url.QueryEscape(s), "+", "%20"), "%7E", "~")
+}
+
+func writeAliyunTestError(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"Code": code, "Message": code, "RequestId": "error-request"})
+}
+
+func TestAliyunAssumeRole(t *testing.T) {
+	fake, config := newAliyunSTSFake(t)
+	ctx := context.Background()
+
+	provider, err := NewAliyunProvider(config)
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+
+	// 第一次签发的临时凭据在刷新窗口内，下一次请求需要重新扮演角色
+	fake.expiresIn = aliyunRefreshBefore - time.Minute
+	if err := provider.TestConnection(ctx); err != nil {
+		t.Fatalf("使用临时凭据调用失败: %v", err)
+	}
+	fake.expiresIn = time.Hour
+	if err := provider.TestConnection(ctx); err != nil {
+		t.Fatalf("刷新临时凭据后调用失败: %v", err)
+	}
+	if fake.assumed != 2 {
+		t.Fatalf("临时凭据临近过期时应刷新，实际扮演角色%d次", fake.assumed)
+	}
+
+	// 有效期充足的临时凭据跨实例复用
+	rebuilt, err := NewAliyunProvider(config)
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	if err := rebuilt.TestConnection(ctx); err != nil {
+		t.Fatalf("复用临时凭据调用失败: %v", err)
+	}
+	if fake.assumed != 2 {
+		t.Fatalf("有效期内的临时凭据应被复用，实际扮演角色%d次", fake.assumed)
+	}
+
+	want := []string{"STS.key-1", "STS.key-2", "STS.key-2"}
+	if strings.Join(fake.dnsCallers, ",") != strings.Join(want, ",") {
+		t.Fatalf("云解析接口使用的凭据不符合预期: %v", fake.dnsCallers)
+	}
+}
+
+func TestAliyunAssumeRoleErrors(t *testing.T) {
+	_, config := newAliyunSTSFake(t)
+	ctx := context.Background()
+
+	// 长期凭据签名错误时扮演角色失败，错误保留STS返回的错误码
+	config.APISecret = "wrong-secret"
+	provider, err := NewAliyunProvider(config)
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	err = provider.TestConnection(ctx)
+	if err == nil || !strings.Contains(err.Error(), "扮演RAM角色失败") || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Fatalf("签名错误时应返回扮演角色失败，实际为: %v", err)
+	}
+
+	// 不配置角色时直接使用长期凭据，不携带安全令牌
+	delete(config.ExtraParams, "role_arn")
+	config.APISecret = aliyunTestSecret
+	direct, err := NewAliyunProvider(config)
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	credential, err := direct.credential(ctx)
+	if err != nil || credential.AccessKeyID != config.APIKey || credential.SecurityToken != "" {
+		t.Fatalf("未配置角色时应使用长期凭据: %+v, %v", credential, err)
+	}
+}
@@ -0,0 +1,525 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BaiduProvider 百度智能云域名服务（BCD）解析服务商
+type BaiduProvider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	endpoint   string
+}
+
+// baiduExpirationSeconds 签名有效期（秒）
+const baiduExpirationSeconds = 1800
+
+// baiduViews 通用线路名称与百度云解析线路（view）的对应关系
+var baiduViews = map[string]string{
+	"default": "DEFAULT",
+	"telecom": "CT",
+	"unicom":  "CNC",
+	"mobile":  "CMNET",
+	"edu":     "EDU",
+	"search":  "SEARCH",
+	"默认":      "DEFAULT",
+	"电信":      "CT",
+	"联通":      "CNC",
+	"移动":      "CMNET",
+	"教育网":     "EDU",
+	"搜索引擎":    "SEARCH",
+}
+
+// init 注册百度云DNS服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "baidu",
+		DisplayName: "百度云DNS",
+		Description: "百度智能云DNS服务",
+		SortOrder:   5,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV"},
+			SupportsBatch:        false,
+			SupportsLineTypes:    true,
+			MaxRecordsPerDomain:  500,
+			MinTTL:               300,
+			MaxTTL:               86400,
+			RateLimit:            10,
+			RateBurst:            10,
+		},
+		ConfigSchema: accessKeySchema("Access Key", "Secret Key",
+			ConfigField{Key: "token", Label: "STS Token", Type: FieldText, Secret: true, Help: "使用STS临时凭据时填写"},
+		),
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewBaiduProvider(config)
+		},
+	})
+}
+
+// NewBaiduProvider 创建百度云DNS服务商实例
+func NewBaiduProvider(config ProviderConfig) (*BaiduProvider, error) {
+	if config.APIKey == "" || config.APISecret == "" {
+		return nil, fmt.Errorf("百度云DNS需要Access Key和Secret Key")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://bcd.baidubce.com"
+	}
+
+	return &BaiduProvider{
+		config:   config,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetName 获取服务商名称
+func (p *BaiduProvider) GetName() string {
+	return "baidu"
+}
+
+// ValidateConfig 验证API配置
+func (p *BaiduProvider) ValidateConfig() error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("百度云Access Key不能为空")
+	}
+	if p.config.APISecret == "" {
+		return fmt.Errorf("百度云Secret Key不能为空")
+	}
+	return nil
+}
+
+// TestConnection 测试连接
+func (p *BaiduProvider) TestConnection(ctx context.Context) error {
+	_, err := p.makeRequest(ctx, "/v1/domain/search", map[string]interface{}{
+		"pageNo":   1,
+		"pageSize": 1,
+	})
+	return err
+}
+
+// ListZones 获取账号下的全部域名
+func (p *BaiduProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	const pageSize = 100
+	var zones []Zone
+
+	for page := 1; ; page++ {
+		response, err := p.makeRequest(ctx, "/v1/domain/search", map[string]interface{}{
+			"pageNo":   page,
+			"pageSize": pageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			TotalCount int `json:"totalCount"`
+			Result     []struct {
+				Domain string `json:"domain"`
+				Status string `json:"status"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, domain := range result.Result {
+			zones = append(zones, Zone{
+				ID:     domain.Domain,
+				Name:   domain.Domain,
+				Status: strings.ToLower(domain.Status),
+			})
+		}
+
+		if len(result.Result) < pageSize || len(zones) >= result.TotalCount {
+			break
+		}
+	}
+
+	return zones, nil
+}
+
+// baiduRecord 百度云API返回的解析记录
+type baiduRecord struct {
+	RecordID int64  `json:"recordId"`
+	Domain   string `json:"domain"`
+	View     string `json:"view"`
+	RdType   string `json:"rdtype"`
+	TTL      int    `json:"ttl"`
+	Rdata    string `json:"rdata"`
+	ZoneName string `json:"zoneName"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+}
+
+// toDNSRecord 转换为通用DNS记录
+func (r baiduRecord) toDNSRecord() DNSRecord {
+	status := "active"
+	if r.Status != "" && r.Status != "RUNNING" {
+		status = strings.ToLower(r.Status)
+	}
+
+	name := r.Domain
+	if name == "" {
+		name = "@"
+	}
+
+	record := DNSRecord{
+		ID:       strconv.FormatInt(r.RecordID, 10),
+		Name:     name,
+		Type:     r.RdType,
+		Value:    r.Rdata,
+		TTL:      r.TTL,
+		Priority: r.Priority,
+		Line:     baiduLineName(r.View),
+		Status:   status,
+	}
+
+	// SRV记录的优先级、权重和端口包含在记录值中
+	if fields := strings.Fields(r.Rdata); r.RdType == "SRV" && len(fields) == 4 {
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Weight, _ = strconv.Atoi(fields[1])
+		record.Port, _ = strconv.Atoi(fields[2])
+		record.Value = fields[3]
+	}
+
+	return record
+}
+
+// ListRecords 获取域名记录列表
+func (p *BaiduProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	const pageSize = 100
+	var records []DNSRecord
+
+	for page := 1; ; page++ {
+		response, err := p.makeRequest(ctx, "/v1/domain/resolve/list", map[string]interface{}{
+			"domain":   domain,
+			"pageNo":   page,
+			"pageSize": pageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			TotalCount int           `json:"totalCount"`
+			Result     []baiduRecord `json:"result"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, record := range result.Result {
+			records = append(records, record.toDNSRecord())
+		}
+
+		if len(result.Result) < pageSize || len(records) >= result.TotalCount {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// AddRecord 添加DNS记录
+func (p *BaiduProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	response, err := p.makeRequest(ctx, "/v1/domain/resolve/add", baiduRecordBody(domain, record))
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		RecordID int64 `json:"recordId"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	created := record
+	created.ID = strconv.FormatInt(result.RecordID, 10)
+	created.Line = baiduLineName(baiduView(record.Line))
+	created.Status = "active"
+
+	// 部分接口版本不返回记录ID，需要查询后补全
+	if result.RecordID == 0 {
+		id, err := p.findRecordID(ctx, domain, record)
+		if err != nil {
+			return nil, err
+		}
+		created.ID = id
+	}
+
+	return &created, nil
+}
+
+// UpdateRecord 更新DNS记录
+func (p *BaiduProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	id, err := strconv.ParseInt(recordID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("无效的记录ID: %s", recordID)
+	}
+
+	data := baiduRecordBody(domain, record)
+	data["recordId"] = id
+
+	_, err = p.makeRequest(ctx, "/v1/domain/resolve/edit", data)
+	return err
+}
+
+// DeleteRecord 删除DNS记录
+func (p *BaiduProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	id, err := strconv.ParseInt(recordID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("无效的记录ID: %s", recordID)
+	}
+
+	_, err = p.makeRequest(ctx, "/v1/domain/resolve/delete", map[string]interface{}{
+		"zoneName": domain,
+		"recordId": id,
+	})
+	return err
+}
+
+// GetRecord 获取单个记录详情，百度云没有单条查询接口，通过记录列表查找
+func (p *BaiduProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	records, err := p.ListRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.ID == recordID {
+			return &record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrRecordNotFound, recordID)
+}
+
+// BatchAddRecords 批量添加DNS记录
+func (p *BaiduProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	var results []DNSRecord
+	var errors []error
+
+	for _, record := range records {
+		result, err := p.AddRecord(ctx, domain, record)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	if len(errors) > 0 {
+		return results, fmt.Errorf("批量添加记录时发生错误: %v", errors)
+	}
+
+	return results, nil
+}
+
+// findRecordID 根据记录内容查找记录ID
+func (p *BaiduProvider) findRecordID(ctx context.Context, domain string, record DNSRecord) (string, error) {
+	records, err := p.ListRecords(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+
+	view := baiduView(record.Line)
+	for _, existing := range records {
+		if strings.EqualFold(existing.Name, baiduHost(record.Name)) && existing.Type == strings.ToUpper(record.Type) &&
+			existing.Value == record.Value && baiduView(existing.Line) == view {
+			return existing.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("记录已创建但未能获取记录ID: %s %s", record.Name, record.Type)
+}
+
+// makeRequest 发起API请求，百度云解析接口均为POST请求
+func (p *BaiduProvider) makeRequest(ctx context.Context, path string, data interface{}) ([]byte, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求数据失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.endpoint+path, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if p.config.Token != "" {
+		req.Header.Set("x-bce-security-token", p.config.Token)
+	}
+	p.sign(req, time.Now().UTC())
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发起请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errorResp struct {
+			RequestID string `json:"requestId"`
+			Code      string `json:"code"`
+			Message   string `json:"message"`
+		}
+		if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Code != "" {
+			return nil, newHTTPError("百度云DNS", resp, errorResp.Code, errorResp.Message, errorResp.RequestID)
+		}
+		return nil, newStatusError("百度云DNS", resp, string(body))
+	}
+
+	// 部分写接口成功时不返回内容
+	if len(bytes.TrimSpace(body)) == 0 {
+		return []byte("{}"), nil
+	}
+	return body, nil
+}
+
+// sign 按bce-auth-v1规则为请求生成Authorization请求头
+func (p *BaiduProvider) sign(req *http.Request, now time.Time) {
+	timestamp := now.Format("2006-01-02T15:04:05Z")
+	req.Header.Set("x-bce-date", timestamp)
+
+	authPrefix := fmt.Sprintf("bce-auth-v1/%s/%s/%d", p.config.APIKey, timestamp, baiduExpirationSeconds)
+	signingKey := hex.EncodeToString(hmacSha256([]byte(p.config.APISecret), authPrefix))
+
+	// 参与签名的请求头：host、content-type及全部x-bce-前缀请求头
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for key, values := range req.Header {
+		lower := strings.ToLower(key)
+		if len(values) == 0 || (lower != "content-type" && !strings.HasPrefix(lower, "x-bce-")) {
+			continue
+		}
+		headers[lower] = strings.TrimSpace(values[0])
+	}
+
+	signedHeaders := make([]string, 0, len(headers))
+	for key := range headers {
+		signedHeaders = append(signedHeaders, key)
+	}
+	sort.Strings(signedHeaders)
+
+	canonicalHeaders := make([]string, 0, len(signedHeaders))
+	for _, key := range signedHeaders {
+		canonicalHeaders = append(canonicalHeaders, uriEscape(key)+":"+uriEscape(headers[key]))
+	}
+
+	canonicalRequest := req.Method + "\n" +
+		baiduCanonicalURI(req.URL.Path) + "\n" +
+		baiduCanonicalQuery(req) + "\n" +
+		strings.Join(canonicalHeaders, "\n")
+
+	signature := hex.EncodeToString(hmacSha256([]byte(signingKey), canonicalRequest))
+	req.Header.Set("Authorization", authPrefix+"/"+strings.Join(signedHeaders, ";")+"/"+signature)
+}
+
+// baiduCanonicalURI 规范化请求路径，"/"不编码
+func baiduCanonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// baiduCanonicalQuery 规范化查询字符串，忽略authorization参数
+func baiduCanonicalQuery(req *http.Request) string {
+	var parts []string
+	for key, values := range req.URL.Query() {
+		if strings.ToLower(key) == "authorization" {
+			continue
+		}
+		for _, value := range values {
+			parts = append(parts, uriEscape(key)+"="+uriEscape(value))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "&")
+}
+
+// baiduRecordBody 构建创建和更新记录的请求参数
+func baiduRecordBody(domain string, record DNSRecord) map[string]interface{} {
+	rdata := record.Value
+	if strings.ToUpper(record.Type) == "SRV" {
+		rdata = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Value)
+	}
+
+	data := map[string]interface{}{
+		"zoneName": domain,
+		"domain":   baiduHost(record.Name),
+		"rdType":   strings.ToUpper(record.Type),
+		"rdata":    rdata,
+		"ttl":      record.TTL,
+		"view":     baiduView(record.Line),
+	}
+	if strings.ToUpper(record.Type) == "MX" {
+		data["priority"] = record.Priority
+	}
+	return data
+}
+
+// baiduHost 将子域名转换为百度云的主机记录，根域名使用"@"
+func baiduHost(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+// baiduView 将线路名称转换为百度云的线路（view），未识别的线路原样传递
+func baiduView(line string) string {
+	if line == "" {
+		return "DEFAULT"
+	}
+	if view, ok := baiduViews[strings.ToLower(line)]; ok {
+		return view
+	}
+	return line
+}
+
+// baiduLineName 将百度云的线路（view）转换为通用线路名称
+func baiduLineName(view string) string {
+	switch strings.ToUpper(view) {
+	case "", "DEFAULT":
+		return "default"
+	case "CT":
+		return "telecom"
+	case "CNC":
+		return "unicom"
+	case "CMNET":
+		return "mobile"
+	case "EDU":
+		return "edu"
+	case "SEARCH":
+		return "search"
+	default:
+		return view
+	}
+}
@@ -0,0 +1,294 @@
+package providers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	baiduTestAccessKey = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	baiduTestSecretKey = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+// baiduFake 百度云DNS接口的内存实现，校验每个请求的bce-auth-v1签名
+type baiduFake struct {
+	mu         sync.Mutex
+	records    []baiduRecord
+	nextID     int64
+	omitID     bool // 为true时添加接口不返回记录ID
+	pageCalls  int
+	lastViewIn string
+}
+
+func newBaiduFake(t *testing.T) (*baiduFake, *BaiduProvider) {
+	t.Helper()
+	fake := &baiduFake{nextID: 1000}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	provider, err := NewBaiduProvider(ProviderConfig{
+		APIKey:    baiduTestAccessKey,
+		APISecret: baiduTestSecretKey,
+		Endpoint:  server.URL,
+	})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	return fake, provider
+}
+
+func (f *baiduFake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := verifyBaiduSignature(r); err != "" {
+		writeBaiduError(w, http.StatusForbidden, "AccessDenied", err)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&body)
+
+	switch r.URL.Path {
+	case "/v1/domain/resolve/list":
+		f.pageCalls++
+		pageNo, pageSize := int(body["pageNo"].(float64)), int(body["pageSize"].(float64))
+		start := (pageNo - 1) * pageSize
+		end := start + pageSize
+		if start > len(f.records) {
+			start = len(f.records)
+		}
+		if end > len(f.records) {
+			end = len(f.records)
+		}
+		writeBaiduJSON(w, map[string]interface{}{"totalCount": len(f.records), "result": f.records[start:end]})
+	case "/v1/domain/resolve/add":
+		if body["zoneName"] != "example.com" {
+			writeBaiduError(w, http.StatusBadRequest, "DomainNotExist", "zone not found")
+			return
+		}
+		f.nextID++
+		record := baiduRecord{
+			RecordID: f.nextID,
+			Domain:   body["domain"].(string),
+			View:     body["view"].(string),
+			RdType:   body["rdType"].(string),
+			TTL:      int(body["ttl"].(float64)),
+			Rdata:    body["rdata"].(string),
+			ZoneName: "example.com",
+			Status:   "RUNNING",
+		}
+		if priority, ok := body["priority"].(float64); ok {
+			record.Priority = int(priority)
+		}
+		f.records = append(f.records, record)
+		f.lastViewIn = record.View
+		if f.omitID {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		writeBaiduJSON(w, map[string]int64{"recordId": record.RecordID})
+	case "/v1/domain/resolve/edit":
+		for i := range f.records {
+			if f.records[i].RecordID == int64(body["recordId"].(float64)) {
+				f.records[i].Rdata = body["rdata"].(string)
+				f.records[i].TTL = int(body["ttl"].(float64))
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		writeBaiduError(w, http.StatusNotFound, "RecordNotFound", "record not found")
+	case "/v1/domain/resolve/delete":
+		for i := range f.records {
+			if f.records[i].RecordID == int64(body["recordId"].(float64)) {
+				f.records = append(f.records[:i], f.records[i+1:]...)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		writeBaiduError(w, http.StatusNotFound, "RecordNotFound", "record not found")
+	default:
+		writeBaiduError(w, http.StatusNotFound, "NoSuchAPI", "api not found")
+	}
+}
+
+// verifyBaiduSignature 按bce-auth-v1规则独立计算签名并与Authorization请求头比较，校验通过时返回空字符串
+func verifyBaiduSignature(r *http.Request) string {
+	parts := strings.Split(r.Header.Get("Authorization"), "/")
+	if len(parts) != 6 || parts[0] != "bce-auth-v1" {
+		return "malformed authorization"
+	}
+	if parts[1] != baiduTestAccessKey {
+		return "unknown access key"
+	}
+	if parts[2] != r.Header.Get("x-bce-date") {
+		return "timestamp mismatch"
+	}
+
+	signedHeaders := strings.Split(parts[4], ";")
+	if !containsHeader(signedHeaders, "host") {
+		return "host must be signed"
+	}
+	var canonicalHeaders []string
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders = append(canonicalHeaders, baiduTestEscape(name)+":"+baiduTestEscape(strings.TrimSpace(value)))
+	}
+	sort.Strings(canonicalHeaders)
+
+	var query []string
+	for key, values := range r.URL.Query() {
+		for _, value := range values {
+			query = append(query, baiduTestEscape(key)+"="+baiduTestEscape(value))
+		}
+	}
+	sort.Strings(query)
+
+	canonicalRequest := r.Method + "\n" + r.URL.EscapedPath() + "\n" + strings.Join(query, "&") + "\n" + strings.Join(canonicalHeaders, "\n")
+	signingKey := baiduTestHMAC(baiduTestSecretKey, strings.Join(parts[:4], "/"))
+	if baiduTestHMAC(signingKey, canonicalRequest) != parts[5] {
+		return "signature mismatch"
+	}
+	return ""
+}
+
+// baiduTestEscape 按RFC 3986编码，空格编码为%20
+func baiduTestEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func baiduTestHMAC(key, data string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func writeBaiduJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeBaiduError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"requestId": "req-test", "code": code, "message": message})
+}
+
+func TestBaiduSignatureVector(t *testing.T) {
+	// 签名密钥与百度智能云文档“生成认证字符串”示例一致，
+	// 示例请求只签名host、content-type和x-bce-date，签名结果由独立实现按同样的规范化请求计算
+	provider, err := NewBaiduProvider(ProviderConfig{APIKey: baiduTestAccessKey, APISecret: baiduTestSecretKey})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	req, _ := http.NewRequest("PUT", "http://bj.bcebos.com/v1/test/myfolder/readme.txt?partNumber=9&uploadId=a44cc9bab11cbd156984767aad637851", nil)
+	req.Header.Set("Content-Type", "text/plain")
+	provider.sign(req, time.Date(2015, 4, 27, 8, 23, 49, 0, time.UTC))
+
+	if got := baiduTestHMAC(baiduTestSecretKey, "bce-auth-v1/"+baiduTestAccessKey+"/2015-04-27T08:23:49Z/1800"); got != "1d5ce5f464064cbee060330d973218821825ac6952368a482a592e6615aef479" {
+		t.Fatalf("签名密钥与文档示例不一致: %s", got)
+	}
+	want := "bce-auth-v1/" + baiduTestAccessKey + "/2015-04-27T08:23:49Z/1800/content-type;host;x-bce-date/" +
+		"55aa9077e8c3cb42292603bbfdf2fd0240ebdc651a79aca5e64690bb33cb5fb9"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Fatalf("签名不一致:\n实际: %s\n期望: %s", got, want)
+	}
+	if req.Header.Get("x-bce-date") != "2015-04-27T08:23:49Z" {
+		t.Fatalf("x-bce-date不一致: %s", req.Header.Get("x-bce-date"))
+	}
+}
+
+func TestBaiduRecordRoundTrip(t *testing.T) {
+	fake, provider := newBaiduFake(t)
+	ctx := context.Background()
+
+	created, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 600, Line: "telecom"})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+	if fake.lastViewIn != "CT" || created.Line != "telecom" {
+		t.Fatalf("线路应映射为CT，实际请求为%s，返回%s", fake.lastViewIn, created.Line)
+	}
+
+	srv, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "_sip._tcp", Type: "SRV", Value: "sip.example.com", TTL: 600, Priority: 1, Weight: 5, Port: 5060})
+	if err != nil {
+		t.Fatalf("添加SRV记录失败: %v", err)
+	}
+	got, err := provider.GetRecord(ctx, "example.com", srv.ID)
+	if err != nil || got.Value != "sip.example.com" || got.Priority != 1 || got.Weight != 5 || got.Port != 5060 || got.Line != "default" {
+		t.Fatalf("SRV记录不一致: %+v, %v", got, err)
+	}
+
+	if err := provider.UpdateRecord(ctx, "example.com", created.ID, DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 900, Line: "telecom"}); err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", created.ID); err != nil || got.Value != "192.0.2.2" || got.TTL != 900 {
+		t.Fatalf("更新后的记录不一致: %+v, %v", got, err)
+	}
+
+	if err := provider.DeleteRecord(ctx, "example.com", created.ID); err != nil {
+		t.Fatalf("删除记录失败: %v", err)
+	}
+	if _, err := provider.GetRecord(ctx, "example.com", created.ID); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("删除后应返回ErrRecordNotFound，实际为: %v", err)
+	}
+	if err := provider.DeleteRecord(ctx, "example.com", created.ID); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("重复删除应返回ErrRecordNotFound，实际为: %v", err)
+	}
+
+	// 添加接口不返回记录ID时按记录内容查找
+	fake.omitID = true
+	found, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "mail", Type: "MX", Value: "mx.example.com", TTL: 600, Priority: 10})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+	if found.ID != "1003" {
+		t.Fatalf("应按记录内容查找到记录ID，实际为%s", found.ID)
+	}
+}
+
+func TestBaiduListRecordsPagination(t *testing.T) {
+	fake, provider := newBaiduFake(t)
+	for i := 0; i < 250; i++ {
+		fake.records = append(fake.records, baiduRecord{RecordID: int64(i + 1), Domain: "r", RdType: "TXT", Rdata: "v", TTL: 600, Status: "RUNNING"})
+	}
+
+	records, err := provider.ListRecords(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("获取记录列表失败: %v", err)
+	}
+	if len(records) != 250 || fake.pageCalls != 3 {
+		t.Fatalf("期望分3页获取250条记录，实际%d页%d条", fake.pageCalls, len(records))
+	}
+}
+
+func TestBaiduErrorKinds(t *testing.T) {
+	_, provider := newBaiduFake(t)
+	ctx := context.Background()
+
+	if _, err := provider.AddRecord(ctx, "missing.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 600}); !errors.Is(err, ErrZoneNotFound) {
+		t.Fatalf("未托管的域名应返回ErrZoneNotFound，实际为: %v", err)
+	}
+
+	provider.config.APISecret = "wrong-secret"
+	err := provider.TestConnection(ctx)
+	var providerErr *ProviderError
+	if !errors.Is(err, ErrAuthFailed) || !errors.As(err, &providerErr) || providerErr.RequestID != "req-test" {
+		t.Fatalf("签名错误应返回带请求ID的ErrAuthFailed，实际为: %v", err)
+	}
+}
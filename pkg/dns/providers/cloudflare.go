@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -17,6 +19,37 @@ type CloudflareProvider struct {
 	endpoint   string
 }
 
+// init 注册CloudFlare服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "cloudflare",
+		DisplayName: "CloudFlare",
+		Description: "CloudFlare DNS服务",
+		SortOrder:   3,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
+			SupportsBatch:        true,
+			SupportsLineTypes:    false,
+			MaxRecordsPerDomain:  20000,
+			MinTTL:               60,
+			MaxTTL:               604800,
+			RateLimit:            4,
+			RateBurst:            20,
+		},
+		ConfigSchema: []ConfigField{
+			{Key: "token", Label: "API Token", Type: FieldText, Secret: true, RequiredUnless: []string{"api_key"},
+				Help: "推荐使用，需要Zone.DNS编辑权限和Zone.Zone读取权限"},
+			{Key: "api_key", Label: "Global API Key", Type: FieldText, Secret: true, RequiredUnless: []string{"token"},
+				Help: "未填写API Token时使用，需要同时填写账号邮箱"},
+			{Key: "email", Label: "账号邮箱", Type: FieldText, RequiredWith: []string{"api_key"}, RequiredUnless: []string{"token"},
+				Placeholder: "user@example.com"},
+		},
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewCloudflareProvider(config)
+		},
+	})
+}
+
 // NewCloudflareProvider 创建CloudFlare DNS服务商实例
 func NewCloudflareProvider(config ProviderConfig) (*CloudflareProvider, error) {
 	if config.Token == "" && config.APIKey == "" {
@@ -57,83 +90,199 @@ func (p *CloudflareProvider) TestConnection(ctx context.Context) error {
 	return err
 }
 
-// ListRecords 获取域名记录列表
-func (p *CloudflareProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	// 首先获取域名的Zone ID
-	zoneID, err := p.getZoneID(ctx, domain)
-	if err != nil {
-		return nil, fmt.Errorf("获取Zone ID失败: %v", err)
+// cloudflareRecord CloudFlare API返回的DNS记录
+type cloudflareRecord struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name"`
+	Type     string      `json:"type"`
+	Content  string      `json:"content"`
+	TTL      int         `json:"ttl"`
+	Priority interface{} `json:"priority"`
+	Data     struct {
+		Priority int `json:"priority"`
+		Weight   int `json:"weight"`
+		Port     int `json:"port"`
+	} `json:"data"`
+	Proxied bool     `json:"proxied"`
+	Comment *string  `json:"comment"`
+	Tags    []string `json:"tags"`
+}
+
+const (
+	// cloudflareBatchLimit 单次批量请求的最大记录数
+	cloudflareBatchLimit = 200
+)
+
+// cloudflareErrorKinds CloudFlare错误码对应的错误分类
+var cloudflareErrorKinds = map[int]error{
+	6003:  ErrAuthFailed,
+	9103:  ErrAuthFailed,
+	9109:  ErrAuthFailed,
+	10000: ErrAuthFailed,
+	971:   ErrRateLimited,
+	10100: ErrRateLimited,
+	1001:  ErrZoneNotFound,
+	7003:  ErrZoneNotFound,
+	81044: ErrRecordNotFound,
+	81053: ErrRecordConflict,
+	81057: ErrRecordConflict,
+	81058: ErrRecordConflict,
+	81045: ErrQuotaExceeded,
+}
+
+// CloudflareRecordExtra CloudFlare记录的扩展属性，通过DNSRecord.Extra的proxied、comment和tags键传递，
+// 为nil的字段表示未指定，更新记录时保留服务商侧的原值
+type CloudflareRecordExtra struct {
+	Proxied *bool
+	Comment *string
+	Tags    []string
+}
+
+// GetCloudflareExtra 从DNSRecord.Extra中读取CloudFlare扩展属性
+func GetCloudflareExtra(record DNSRecord) CloudflareRecordExtra {
+	var extra CloudflareRecordExtra
+	if value, ok := record.Extra["proxied"]; ok {
+		proxied := value == "true"
+		extra.Proxied = &proxied
+	}
+	if value, ok := record.Extra["comment"]; ok {
+		extra.Comment = &value
+	}
+	if value, ok := record.Extra["tags"]; ok {
+		extra.Tags = []string{}
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				extra.Tags = append(extra.Tags, tag)
+			}
+		}
+	}
+	return extra
+}
+
+// SetCloudflareExtra 将CloudFlare扩展属性写入DNSRecord.Extra
+func SetCloudflareExtra(record *DNSRecord, extra CloudflareRecordExtra) {
+	if record.Extra == nil {
+		record.Extra = make(map[string]string)
+	}
+	if extra.Proxied != nil {
+		record.Extra["proxied"] = strconv.FormatBool(*extra.Proxied)
+	}
+	if extra.Comment != nil {
+		record.Extra["comment"] = *extra.Comment
+	}
+	if extra.Tags != nil {
+		record.Extra["tags"] = strings.Join(extra.Tags, ",")
+	}
+}
+
+// toDNSRecord 转换为通用DNS记录
+func (r cloudflareRecord) toDNSRecord(domain string) DNSRecord {
+	// 提取子域名（去掉主域名部分）
+	name := r.Name
+	if strings.HasSuffix(name, "."+domain) {
+		name = strings.TrimSuffix(name, "."+domain)
+	} else if name == domain {
+		name = "@"
 	}
 	
-	// 获取DNS记录
-	path := fmt.Sprintf("/zones/%s/dns_records", zoneID)
-	response, err := p.makeRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
+	priority := 0
+	if r.Priority != nil {
+		if p, ok := r.Priority.(float64); ok {
+			priority = int(p)
+		}
+	}
+	if priority == 0 && r.Data.Priority > 0 {
+		priority = r.Data.Priority
 	}
 	
-	var result struct {
-		Result []struct {
-			ID       string      `json:"id"`
-			Name     string      `json:"name"`
-			Type     string      `json:"type"`
-			Content  string      `json:"content"`
-			TTL      int         `json:"ttl"`
-			Priority interface{} `json:"priority"`
-			Data     struct {
-				Priority int `json:"priority"`
-				Weight   int `json:"weight"`
-				Port     int `json:"port"`
-			} `json:"data"`
-			Proxied bool `json:"proxied"`
-		} `json:"result"`
-		Success bool `json:"success"`
+	status := "active"
+	if r.Proxied {
+		status = "proxied"
 	}
 	
-	if err := json.Unmarshal(response, &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %v", err)
+	record := DNSRecord{
+		ID:       r.ID,
+		Name:     name,
+		Type:     r.Type,
+		Value:    r.Content,
+		TTL:      r.TTL,
+		Priority: priority,
+		Weight:   r.Data.Weight,
+		Port:     r.Data.Port,
+		Status:   status,
 	}
 	
-	if !result.Success {
-		return nil, fmt.Errorf("CloudFlare API返回失败")
+	comment := ""
+	if r.Comment != nil {
+		comment = *r.Comment
+	}
+	tags := r.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	SetCloudflareExtra(&record, CloudflareRecordExtra{
+		Proxied: &r.Proxied,
+		Comment: &comment,
+		Tags:    tags,
+	})
+	
+	return record
+}
+
+// ListRecords 获取域名记录列表
+func (p *CloudflareProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *CloudflareProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	// 首先获取域名的Zone ID
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取Zone ID失败: %w", err)
+	}
+	
+	query := url.Values{}
+	query.Set("per_page", "100")
+	if filter.Name != "" {
+		query.Set("name", p.fullRecordName(filter.Name, domain))
+	}
+	if filter.Type != "" {
+		query.Set("type", strings.ToUpper(filter.Type))
 	}
 	
 	var records []DNSRecord
-	for _, record := range result.Result {
-		// 提取子域名（去掉主域名部分）
-		name := record.Name
-		if strings.HasSuffix(name, "."+domain) {
-			name = strings.TrimSuffix(name, "."+domain)
-		} else if name == domain {
-			name = "@"
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		path := fmt.Sprintf("/zones/%s/dns_records?%s", zoneID, query.Encode())
+		response, err := p.makeRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
 		}
 		
-		priority := 0
-		if record.Priority != nil {
-			if p, ok := record.Priority.(float64); ok {
-				priority = int(p)
-			}
+		var result struct {
+			Result     []cloudflareRecord `json:"result"`
+			ResultInfo struct {
+				TotalPages int `json:"total_pages"`
+			} `json:"result_info"`
+			Success bool `json:"success"`
 		}
-		if priority == 0 && record.Data.Priority > 0 {
-			priority = record.Data.Priority
+		
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+		
+		if !result.Success {
+			return nil, fmt.Errorf("CloudFlare API返回失败")
 		}
 		
-		status := "active"
-		if record.Proxied {
-			status = "proxied"
+		for _, record := range result.Result {
+			records = append(records, record.toDNSRecord(domain))
 		}
 		
-		records = append(records, DNSRecord{
-			ID:       record.ID,
-			Name:     name,
-			Type:     record.Type,
-			Value:    record.Content,
-			TTL:      record.TTL,
-			Priority: priority,
-			Weight:   record.Data.Weight,
-			Port:     record.Data.Port,
-			Status:   status,
-		})
+		if page >= result.ResultInfo.TotalPages || len(result.Result) == 0 {
+			break
+		}
 	}
 	
 	return records, nil
@@ -144,61 +293,22 @@ func (p *CloudflareProvider) AddRecord(ctx context.Context, domain string, recor
 	// 获取Zone ID
 	zoneID, err := p.getZoneID(ctx, domain)
 	if err != nil {
-		return nil, fmt.Errorf("获取Zone ID失败: %v", err)
-	}
-	
-	// 构建完整的记录名称
-	recordName := record.Name
-	if recordName == "@" {
-		recordName = domain
-	} else {
-		recordName = record.Name + "." + domain
-	}
-	
-	// 构建请求体
-	data := map[string]interface{}{
-		"type":    record.Type,
-		"name":    recordName,
-		"content": record.Value,
-		"ttl":     record.TTL,
-	}
-	
-	// 设置优先级（MX和SRV记录）
-	if record.Priority > 0 && (record.Type == "MX" || record.Type == "SRV") {
-		data["priority"] = record.Priority
-	}
-	
-	// SRV记录需要特殊处理
-	if record.Type == "SRV" {
-		data["data"] = map[string]interface{}{
-			"priority": record.Priority,
-			"weight":   record.Weight,
-			"port":     record.Port,
-			"target":   record.Value,
-		}
-		// SRV记录的content字段格式为：priority weight port target
-		data["content"] = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Value)
+		return nil, fmt.Errorf("获取Zone ID失败: %w", err)
 	}
 	
 	path := fmt.Sprintf("/zones/%s/dns_records", zoneID)
-	response, err := p.makeRequest(ctx, "POST", path, data)
+	response, err := p.makeRequest(ctx, "POST", path, p.recordPayload(record, domain))
 	if err != nil {
 		return nil, err
 	}
 	
 	var result struct {
-		Result struct {
-			ID      string `json:"id"`
-			Name    string `json:"name"`
-			Type    string `json:"type"`
-			Content string `json:"content"`
-			TTL     int    `json:"ttl"`
-		} `json:"result"`
-		Success bool `json:"success"`
+		Result  cloudflareRecord `json:"result"`
+		Success bool             `json:"success"`
 	}
 	
 	if err := json.Unmarshal(response, &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %v", err)
+		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
 	
 	if !result.Success {
@@ -206,9 +316,7 @@ func (p *CloudflareProvider) AddRecord(ctx context.Context, domain string, recor
 	}
 	
 	// 返回创建的记录
-	createdRecord := record
-	createdRecord.ID = result.Result.ID
-	
+	createdRecord := cloudflareCreatedRecord(record, result.Result, domain)
 	return &createdRecord, nil
 }
 
@@ -217,43 +325,12 @@ func (p *CloudflareProvider) UpdateRecord(ctx context.Context, domain string, re
 	// 获取Zone ID
 	zoneID, err := p.getZoneID(ctx, domain)
 	if err != nil {
-		return fmt.Errorf("获取Zone ID失败: %v", err)
-	}
-	
-	// 构建完整的记录名称
-	recordName := record.Name
-	if recordName == "@" {
-		recordName = domain
-	} else {
-		recordName = record.Name + "." + domain
-	}
-	
-	// 构建请求体
-	data := map[string]interface{}{
-		"type":    record.Type,
-		"name":    recordName,
-		"content": record.Value,
-		"ttl":     record.TTL,
-	}
-	
-	// 设置优先级（MX和SRV记录）
-	if record.Priority > 0 && (record.Type == "MX" || record.Type == "SRV") {
-		data["priority"] = record.Priority
-	}
-	
-	// SRV记录需要特殊处理
-	if record.Type == "SRV" {
-		data["data"] = map[string]interface{}{
-			"priority": record.Priority,
-			"weight":   record.Weight,
-			"port":     record.Port,
-			"target":   record.Value,
-		}
-		data["content"] = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Value)
+		return fmt.Errorf("获取Zone ID失败: %w", err)
 	}
 	
+	// 使用PATCH只修改提交的字段，未指定的proxied、comment和tags保留原值
 	path := fmt.Sprintf("/zones/%s/dns_records/%s", zoneID, recordID)
-	_, err = p.makeRequest(ctx, "PUT", path, data)
+	_, err = p.makeRequest(ctx, "PATCH", path, p.recordPayload(record, domain))
 	return err
 }
 
@@ -262,7 +339,7 @@ func (p *CloudflareProvider) DeleteRecord(ctx context.Context, domain string, re
 	// 获取Zone ID
 	zoneID, err := p.getZoneID(ctx, domain)
 	if err != nil {
-		return fmt.Errorf("获取Zone ID失败: %v", err)
+		return fmt.Errorf("获取Zone ID失败: %w", err)
 	}
 	
 	path := fmt.Sprintf("/zones/%s/dns_records/%s", zoneID, recordID)
@@ -275,7 +352,7 @@ func (p *CloudflareProvider) GetRecord(ctx context.Context, domain string, recor
 	// 获取Zone ID
 	zoneID, err := p.getZoneID(ctx, domain)
 	if err != nil {
-		return nil, fmt.Errorf("获取Zone ID失败: %v", err)
+		return nil, fmt.Errorf("获取Zone ID失败: %w", err)
 	}
 	
 	path := fmt.Sprintf("/zones/%s/dns_records/%s", zoneID, recordID)
@@ -285,94 +362,194 @@ func (p *CloudflareProvider) GetRecord(ctx context.Context, domain string, recor
 	}
 	
 	var result struct {
-		Result struct {
-			ID       string      `json:"id"`
-			Name     string      `json:"name"`
-			Type     string      `json:"type"`
-			Content  string      `json:"content"`
-			TTL      int         `json:"ttl"`
-			Priority interface{} `json:"priority"`
-			Data     struct {
-				Priority int `json:"priority"`
-				Weight   int `json:"weight"`
-				Port     int `json:"port"`
-			} `json:"data"`
-			Proxied bool `json:"proxied"`
-		} `json:"result"`
-		Success bool `json:"success"`
+		Result  cloudflareRecord `json:"result"`
+		Success bool             `json:"success"`
 	}
 	
 	if err := json.Unmarshal(response, &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %v", err)
+		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
 	
 	if !result.Success {
 		return nil, fmt.Errorf("CloudFlare API返回失败")
 	}
 	
-	record := result.Result
+	record := result.Result.toDNSRecord(domain)
+	return &record, nil
+}
+
+// BatchAddRecords 批量添加DNS记录
+// 使用批量接口提交，每个批量请求由CloudFlare原子执行，超过单次上限的记录分多次提交
+func (p *CloudflareProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
 	
-	// 提取子域名
-	name := record.Name
-	if strings.HasSuffix(name, "."+domain) {
-		name = strings.TrimSuffix(name, "."+domain)
-	} else if name == domain {
-		name = "@"
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取Zone ID失败: %w", err)
 	}
 	
-	priority := 0
-	if record.Priority != nil {
-		if p, ok := record.Priority.(float64); ok {
-			priority = int(p)
+	var results []DNSRecord
+	for start := 0; start < len(records); start += cloudflareBatchLimit {
+		end := start + cloudflareBatchLimit
+		if end > len(records) {
+			end = len(records)
+		}
+		
+		posts := make([]map[string]interface{}, 0, end-start)
+		for _, record := range records[start:end] {
+			posts = append(posts, p.recordPayload(record, domain))
+		}
+		
+		path := fmt.Sprintf("/zones/%s/dns_records/batch", zoneID)
+		response, err := p.makeRequest(ctx, "POST", path, map[string]interface{}{"posts": posts})
+		if err != nil {
+			return results, fmt.Errorf("批量添加记录时发生错误: %w", err)
+		}
+		
+		var result struct {
+			Result struct {
+				Posts []cloudflareRecord `json:"posts"`
+			} `json:"result"`
+			Success bool `json:"success"`
+		}
+		
+		if err := json.Unmarshal(response, &result); err != nil {
+			return results, fmt.Errorf("解析响应失败: %w", err)
+		}
+		
+		if !result.Success || len(result.Result.Posts) != end-start {
+			return results, fmt.Errorf("CloudFlare API返回失败")
+		}
+		
+		// 批量接口按提交顺序返回创建的记录
+		for i, created := range result.Result.Posts {
+			results = append(results, cloudflareCreatedRecord(records[start+i], created, domain))
 		}
-	}
-	if priority == 0 && record.Data.Priority > 0 {
-		priority = record.Data.Priority
 	}
 	
-	status := "active"
-	if record.Proxied {
-		status = "proxied"
+	return results, nil
+}
+
+// VerifyZone 校验域名已托管在CloudFlare账号下
+func (p *CloudflareProvider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	return p.getZoneID(ctx, domain)
+}
+
+// ListZones 获取账号下托管的全部域名
+func (p *CloudflareProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	var zones []Zone
+	
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/zones?page=%d&per_page=50", page)
+		response, err := p.makeRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+		
+		var result struct {
+			Result []struct {
+				ID     string `json:"id"`
+				Name   string `json:"name"`
+				Status string `json:"status"`
+			} `json:"result"`
+			ResultInfo struct {
+				TotalPages int `json:"total_pages"`
+			} `json:"result_info"`
+			Success bool `json:"success"`
+		}
+		
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+		
+		if !result.Success {
+			return nil, fmt.Errorf("CloudFlare API返回失败")
+		}
+		
+		// CloudFlare的Zone列表不包含记录数量
+		for _, zone := range result.Result {
+			zones = append(zones, Zone{
+				ID:     zone.ID,
+				Name:   zone.Name,
+				Status: zone.Status,
+			})
+		}
+		
+		if page >= result.ResultInfo.TotalPages || len(result.Result) == 0 {
+			break
+		}
 	}
 	
-	return &DNSRecord{
-		ID:       record.ID,
-		Name:     name,
-		Type:     record.Type,
-		Value:    record.Content,
-		TTL:      record.TTL,
-		Priority: priority,
-		Weight:   record.Data.Weight,
-		Port:     record.Data.Port,
-		Status:   status,
-	}, nil
+	return zones, nil
 }
 
-// BatchAddRecords 批量添加DNS记录
-func (p *CloudflareProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
-	// CloudFlare不支持批量操作，逐个添加
-	var results []DNSRecord
-	var errors []error
+// recordPayload 构建创建或更新记录的请求体
+func (p *CloudflareProvider) recordPayload(record DNSRecord, domain string) map[string]interface{} {
+	data := map[string]interface{}{
+		"type":    record.Type,
+		"name":    p.fullRecordName(record.Name, domain),
+		"content": record.Value,
+		"ttl":     record.TTL,
+	}
 	
-	for _, record := range records {
-		result, err := p.AddRecord(ctx, domain, record)
-		if err != nil {
-			errors = append(errors, err)
-			continue
+	// 设置优先级（MX和SRV记录）
+	if record.Priority > 0 && (record.Type == "MX" || record.Type == "SRV") {
+		data["priority"] = record.Priority
+	}
+	
+	// SRV记录需要特殊处理
+	if record.Type == "SRV" {
+		data["data"] = map[string]interface{}{
+			"priority": record.Priority,
+			"weight":   record.Weight,
+			"port":     record.Port,
+			"target":   record.Value,
 		}
-		results = append(results, *result)
+		// SRV记录的content字段格式为：priority weight port target
+		data["content"] = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Value)
 	}
 	
-	if len(errors) > 0 {
-		return results, fmt.Errorf("批量添加记录时发生错误: %v", errors)
+	// 只提交指定了的扩展属性
+	extra := GetCloudflareExtra(record)
+	if extra.Proxied != nil {
+		data["proxied"] = *extra.Proxied
+	}
+	if extra.Comment != nil {
+		data["comment"] = *extra.Comment
+	}
+	if extra.Tags != nil {
+		data["tags"] = extra.Tags
 	}
 	
-	return results, nil
+	return data
 }
 
-// getZoneID 获取域名的Zone ID
+// cloudflareCreatedRecord 以提交的记录为基础，补充服务商返回的记录ID和扩展属性
+func cloudflareCreatedRecord(record DNSRecord, created cloudflareRecord, domain string) DNSRecord {
+	returned := created.toDNSRecord(domain)
+	record.ID = created.ID
+	record.Extra = returned.Extra
+	return record
+}
+
+// fullRecordName 将子域名转换为完整的记录名称
+func (p *CloudflareProvider) fullRecordName(name, domain string) string {
+	if name == "@" || name == "" {
+		return domain
+	}
+	return name + "." + domain
+}
+
+// getZoneID 获取域名的Zone ID，结果按凭据缓存
 func (p *CloudflareProvider) getZoneID(ctx context.Context, domain string) (string, error) {
-	path := "/zones?name=" + domain
+	return cachedZoneID(ctx, "cloudflare", p.config, domain, p.lookupZoneID)
+}
+
+// lookupZoneID 查询域名的Zone ID
+func (p *CloudflareProvider) lookupZoneID(ctx context.Context, domain string) (string, error) {
+	path := "/zones?" + url.Values{"name": {domain}}.Encode()
 	response, err := p.makeRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return "", err
@@ -387,7 +564,7 @@ func (p *CloudflareProvider) getZoneID(ctx context.Context, domain string) (stri
 	}
 	
 	if err := json.Unmarshal(response, &result); err != nil {
-		return "", fmt.Errorf("解析响应失败: %v", err)
+		return "", fmt.Errorf("解析响应失败: %w", err)
 	}
 	
 	if !result.Success {
@@ -400,7 +577,7 @@ func (p *CloudflareProvider) getZoneID(ctx context.Context, domain string) (stri
 		}
 	}
 	
-	return "", fmt.Errorf("域名不存在: %s", domain)
+	return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
 }
 
 // makeRequest 发起API请求
@@ -410,7 +587,7 @@ func (p *CloudflareProvider) makeRequest(ctx context.Context, method, path strin
 	if data != nil {
 		jsonData, err := json.Marshal(data)
 		if err != nil {
-			return nil, fmt.Errorf("序列化请求数据失败: %v", err)
+			return nil, fmt.Errorf("序列化请求数据失败: %w", err)
 		}
 		body = strings.NewReader(string(jsonData))
 	}
@@ -418,7 +595,7 @@ func (p *CloudflareProvider) makeRequest(ctx context.Context, method, path strin
 	// 创建请求
 	req, err := http.NewRequestWithContext(ctx, method, p.endpoint+path, body)
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %v", err)
+		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
 	
 	// 设置请求头
@@ -441,21 +618,16 @@ func (p *CloudflareProvider) makeRequest(ctx context.Context, method, path strin
 	// 发起请求
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("发起请求失败: %v", err)
+		return nil, fmt.Errorf("发起请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 	
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %v", err)
+		return nil, fmt.Errorf("读取响应失败: %w", err)
 	}
 	
-	// 检查HTTP状态码
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API请求失败，状态码: %d, 响应: %s", resp.StatusCode, string(responseBody))
-	}
-	
-	// 检查API错误
+	// 检查API错误，错误响应的HTTP状态码也不是2xx，需要先解析错误码
 	var errorResp struct {
 		Success bool `json:"success"`
 		Errors  []struct {
@@ -467,9 +639,19 @@ func (p *CloudflareProvider) makeRequest(ctx context.Context, method, path strin
 	if err := json.Unmarshal(responseBody, &errorResp); err == nil && !errorResp.Success && len(errorResp.Errors) > 0 {
 		var errorMsgs []string
 		for _, apiErr := range errorResp.Errors {
-			errorMsgs = append(errorMsgs, fmt.Sprintf("[%d] %s", apiErr.Code, apiErr.Message))
+			errorMsgs = append(errorMsgs, apiErr.Message)
+		}
+		code := errorResp.Errors[0].Code
+		apiErr := newHTTPError("CloudFlare", resp, strconv.Itoa(code), strings.Join(errorMsgs, "; "), resp.Header.Get("Cf-Ray"))
+		if kind, ok := cloudflareErrorKinds[code]; ok {
+			apiErr.Kind = kind
 		}
-		return nil, fmt.Errorf("CloudFlare API错误: %s", strings.Join(errorMsgs, "; "))
+		return nil, apiErr
+	}
+	
+	// 检查HTTP状态码
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newStatusError("CloudFlare", resp, string(responseBody))
 	}
 	
 	return responseBody, nil
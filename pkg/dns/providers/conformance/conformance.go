@@ -0,0 +1,484 @@
+// Package conformance 对DNS服务商适配器执行一致性检查，验证各适配器对DNSProvider接口的行为约定一致
+//
+// 检查项包括记录的增删改查、筛选、批量添加、分页，以及冲突、不存在、限流和认证失败时返回的错误分类。
+// 检查会在指定域名下创建并删除测试记录，通常配合emulator包的模拟器使用。
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"domain-max/pkg/dns/providers"
+	"domain-max/pkg/dns/providers/emulator"
+)
+
+// errSkipped 检查项不适用于当前服务商
+var errSkipped = errors.New("跳过")
+
+// FaultInjector 可以注入错误的服务商，emulator包的模拟器实现了该接口
+type FaultInjector interface {
+	InjectFault(kind string)
+}
+
+// Suite 一致性检查配置
+type Suite struct {
+	Provider providers.DNSProvider
+	Domain   string
+
+	// Prefix 测试记录的子域名前缀，为空时根据当前时间生成，避免与已有记录冲突
+	Prefix string
+	// Seed 绕过适配器直接写入指定数量的记录，用于检查分页，为nil时跳过分页检查
+	Seed func(count int) error
+	// SeedCount 分页检查写入的记录数量，应大于适配器单页的记录数
+	SeedCount int
+	// Faults 向服务商注入错误，为nil时跳过限流和认证失败检查
+	Faults FaultInjector
+}
+
+// Result 单个检查项的结果
+type Result struct {
+	Name     string
+	Err      error
+	Skipped  bool
+	Duration time.Duration
+}
+
+// Passed 检查项是否通过，跳过的检查项视为通过
+func (r Result) Passed() bool {
+	return r.Err == nil
+}
+
+// Passed 全部检查项是否通过
+func Passed(results []Result) bool {
+	for _, result := range results {
+		if !result.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// step 检查项
+type step struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// state 检查过程中创建的记录
+type state struct {
+	record  providers.DNSRecord
+	batch   []providers.DNSRecord
+	deleted string
+}
+
+// Run 按顺序执行全部检查项，前面的检查项失败时依赖它的检查项会被跳过
+func (s Suite) Run(ctx context.Context) []Result {
+	if s.Prefix == "" {
+		s.Prefix = fmt.Sprintf("conformance-%d", time.Now().UnixNano()%1000000)
+	}
+
+	st := &state{}
+	steps := []step{
+		{"测试连接", s.testConnection},
+		{"域名列表", s.listZones},
+		{"校验域名", s.verifyZone},
+		{"添加记录", func(ctx context.Context) error { return s.addRecord(ctx, st) }},
+		{"获取记录", func(ctx context.Context) error { return s.getRecord(ctx, st) }},
+		{"记录列表", func(ctx context.Context) error { return s.listRecords(ctx, st) }},
+		{"筛选记录", func(ctx context.Context) error { return s.filterRecords(ctx, st) }},
+		{"重复添加返回冲突", func(ctx context.Context) error { return s.duplicateRecord(ctx, st) }},
+		{"更新记录", func(ctx context.Context) error { return s.updateRecord(ctx, st) }},
+		{"暂停和启用记录", func(ctx context.Context) error { return s.recordStatus(ctx, st) }},
+		{"批量添加", func(ctx context.Context) error { return s.batchAddRecords(ctx, st) }},
+		{"删除记录", func(ctx context.Context) error { return s.deleteRecords(ctx, st) }},
+		{"记录不存在", func(ctx context.Context) error { return s.recordNotFound(ctx, st) }},
+		{"域名不存在", s.zoneNotFound},
+		{"分页", s.pagination},
+		{"限流后重试", s.rateLimited},
+		{"认证失败", s.authFailed},
+	}
+
+	results := make([]Result, 0, len(steps))
+	for _, step := range steps {
+		start := time.Now()
+		err := step.run(ctx)
+		result := Result{Name: step.name, Duration: time.Since(start)}
+		if errors.Is(err, errSkipped) {
+			result.Skipped = true
+		} else {
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// testConnection 测试连接
+func (s Suite) testConnection(ctx context.Context) error {
+	return s.Provider.TestConnection(ctx)
+}
+
+// listZones 域名列表中包含测试域名
+func (s Suite) listZones(ctx context.Context) error {
+	zones, err := s.Provider.ListZones(ctx)
+	if err != nil {
+		return err
+	}
+	for _, zone := range zones {
+		if strings.EqualFold(zone.Name, s.Domain) {
+			if zone.ID == "" {
+				return fmt.Errorf("域名%s的ID为空", s.Domain)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("域名列表中没有%s", s.Domain)
+}
+
+// verifyZone 校验已托管的域名成功，未托管的域名返回ErrZoneNotFound
+// 没有域名ID概念的服务商返回的ID可以为空
+func (s Suite) verifyZone(ctx context.Context) error {
+	verifier, ok := s.Provider.(providers.ZoneVerifier)
+	if !ok {
+		return errSkipped
+	}
+	if _, err := verifier.VerifyZone(ctx, s.Domain); err != nil {
+		return err
+	}
+	_, err := verifier.VerifyZone(ctx, s.Prefix+"-missing.invalid")
+	if err == nil {
+		return errors.New("校验未托管的域名没有返回错误")
+	}
+	return expectKind(err, providers.ErrZoneNotFound)
+}
+
+// addRecord 添加A记录并返回记录ID
+func (s Suite) addRecord(ctx context.Context, st *state) error {
+	record := providers.DNSRecord{
+		Name:  s.Prefix + "-a",
+		Type:  "A",
+		Value: "192.0.2.10",
+		TTL:   600,
+	}
+	created, err := s.Provider.AddRecord(ctx, s.Domain, record)
+	if err != nil {
+		return err
+	}
+	if created == nil || created.ID == "" {
+		return errors.New("添加记录后没有返回记录ID")
+	}
+	record.ID = created.ID
+	st.record = record
+	return nil
+}
+
+// getRecord 获取的记录与添加的记录一致
+func (s Suite) getRecord(ctx context.Context, st *state) error {
+	if st.record.ID == "" {
+		return errSkipped
+	}
+	record, err := s.Provider.GetRecord(ctx, s.Domain, st.record.ID)
+	if err != nil {
+		return err
+	}
+	return compareRecord(st.record, *record)
+}
+
+// listRecords 记录列表中包含添加的记录
+func (s Suite) listRecords(ctx context.Context, st *state) error {
+	if st.record.ID == "" {
+		return errSkipped
+	}
+	records, err := s.Provider.ListRecords(ctx, s.Domain)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if record.ID == st.record.ID {
+			return compareRecord(st.record, record)
+		}
+	}
+	return fmt.Errorf("记录列表中没有记录%s", st.record.ID)
+}
+
+// filterRecords 按子域名和类型筛选只返回添加的记录
+func (s Suite) filterRecords(ctx context.Context, st *state) error {
+	if st.record.ID == "" {
+		return errSkipped
+	}
+	records, err := providers.ListRecordsWithFilter(ctx, s.Provider, s.Domain, providers.RecordFilter{
+		Name: st.record.Name,
+		Type: st.record.Type,
+	})
+	if err != nil {
+		return err
+	}
+	if len(records) != 1 || records[0].ID != st.record.ID {
+		return fmt.Errorf("筛选结果应只包含记录%s，实际返回%d条记录", st.record.ID, len(records))
+	}
+	return nil
+}
+
+// duplicateRecord 重复添加相同的记录返回ErrRecordConflict
+func (s Suite) duplicateRecord(ctx context.Context, st *state) error {
+	if st.record.ID == "" {
+		return errSkipped
+	}
+	duplicate := st.record
+	duplicate.ID = ""
+	created, err := s.Provider.AddRecord(ctx, s.Domain, duplicate)
+	if err == nil {
+		// 服务商接受了重复记录，清理后报告失败
+		if created != nil && created.ID != "" {
+			s.Provider.DeleteRecord(ctx, s.Domain, created.ID)
+		}
+		return errors.New("重复添加记录没有返回错误")
+	}
+	return expectKind(err, providers.ErrRecordConflict)
+}
+
+// updateRecord 更新记录值后获取到新的值，服务商通过删除重建实现更新时记录ID可能变化
+func (s Suite) updateRecord(ctx context.Context, st *state) error {
+	if st.record.ID == "" {
+		return errSkipped
+	}
+	record := st.record
+	record.Value = "192.0.2.20"
+	updated, err := providers.UpdateRecordWithResult(ctx, s.Provider, s.Domain, st.record.ID, record)
+	if err != nil {
+		return err
+	}
+	if updated.ID != "" {
+		record.ID = updated.ID
+	}
+	st.record = record
+
+	current, err := s.Provider.GetRecord(ctx, s.Domain, record.ID)
+	if err != nil {
+		return err
+	}
+	return compareRecord(record, *current)
+}
+
+// recordStatus 暂停记录后修改记录不会重新启用，再次启用后记录恢复解析，服务商不支持暂停时跳过
+func (s Suite) recordStatus(ctx context.Context, st *state) error {
+	setter, ok := s.Provider.(providers.RecordStatusSetter)
+	if st.record.ID == "" || !ok || !providers.SupportsRecordStatus(s.Provider) {
+		return errSkipped
+	}
+
+	if err := setter.SetRecordStatus(ctx, s.Domain, st.record.ID, false); err != nil {
+		return err
+	}
+	if err := s.expectStatus(ctx, st.record.ID, false); err != nil {
+		return fmt.Errorf("暂停后: %w", err)
+	}
+
+	record := st.record
+	record.TTL = 1200
+	record.Status = "inactive"
+	updated, err := providers.UpdateRecordWithResult(ctx, s.Provider, s.Domain, st.record.ID, record)
+	if err != nil {
+		return err
+	}
+	if updated.ID != "" {
+		record.ID = updated.ID
+	}
+	record.Status = ""
+	st.record = record
+	if err := s.expectStatus(ctx, record.ID, false); err != nil {
+		return fmt.Errorf("修改暂停的记录后: %w", err)
+	}
+
+	if err := setter.SetRecordStatus(ctx, s.Domain, record.ID, true); err != nil {
+		return err
+	}
+	if err := s.expectStatus(ctx, record.ID, true); err != nil {
+		return fmt.Errorf("启用后: %w", err)
+	}
+	return nil
+}
+
+// expectStatus 检查记录的启用状态
+func (s Suite) expectStatus(ctx context.Context, recordID string, enabled bool) error {
+	record, err := s.Provider.GetRecord(ctx, s.Domain, recordID)
+	if err != nil {
+		return err
+	}
+	if providers.RecordEnabled(*record) != enabled {
+		return fmt.Errorf("记录状态不符: %q", record.Status)
+	}
+	return nil
+}
+
+// batchAddRecords 批量添加MX和TXT记录，按提交顺序返回记录ID
+func (s Suite) batchAddRecords(ctx context.Context, st *state) error {
+	records := []providers.DNSRecord{
+		{Name: s.Prefix + "-mx", Type: "MX", Value: "mail.example.com", TTL: 600, Priority: 10},
+		{Name: s.Prefix + "-txt", Type: "TXT", Value: "conformance", TTL: 600},
+	}
+	created, err := s.Provider.BatchAddRecords(ctx, s.Domain, records)
+	for _, record := range created {
+		if record.ID != "" {
+			st.batch = append(st.batch, record)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(created) != len(records) {
+		return fmt.Errorf("批量添加%d条记录，返回%d条", len(records), len(created))
+	}
+
+	for i, record := range created {
+		if record.ID == "" {
+			return fmt.Errorf("第%d条记录没有返回记录ID", i+1)
+		}
+		current, err := s.Provider.GetRecord(ctx, s.Domain, record.ID)
+		if err != nil {
+			return err
+		}
+		expected := records[i]
+		expected.ID = record.ID
+		if err := compareRecord(expected, *current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteRecords 删除检查过程中创建的全部记录，删除后无法再获取
+func (s Suite) deleteRecords(ctx context.Context, st *state) error {
+	if st.record.ID == "" && len(st.batch) == 0 {
+		return errSkipped
+	}
+
+	records := st.batch
+	if st.record.ID != "" {
+		records = append([]providers.DNSRecord{st.record}, records...)
+	}
+	for _, record := range records {
+		if err := s.Provider.DeleteRecord(ctx, s.Domain, record.ID); err != nil {
+			return fmt.Errorf("删除记录%s失败: %w", record.ID, err)
+		}
+	}
+	st.deleted = records[0].ID
+	st.record = providers.DNSRecord{}
+	st.batch = nil
+
+	_, err := s.Provider.GetRecord(ctx, s.Domain, st.deleted)
+	if err == nil {
+		return fmt.Errorf("记录%s删除后仍然可以获取", st.deleted)
+	}
+	return expectKind(err, providers.ErrRecordNotFound)
+}
+
+// recordNotFound 删除不存在的记录返回ErrRecordNotFound
+func (s Suite) recordNotFound(ctx context.Context, st *state) error {
+	if st.deleted == "" {
+		return errSkipped
+	}
+	err := s.Provider.DeleteRecord(ctx, s.Domain, st.deleted)
+	if err == nil {
+		return errors.New("删除不存在的记录没有返回错误")
+	}
+	return expectKind(err, providers.ErrRecordNotFound)
+}
+
+// zoneNotFound 获取未托管域名的记录返回ErrZoneNotFound
+func (s Suite) zoneNotFound(ctx context.Context) error {
+	_, err := s.Provider.ListRecords(ctx, s.Prefix+"-missing.invalid")
+	if err == nil {
+		return errors.New("获取未托管域名的记录没有返回错误")
+	}
+	return expectKind(err, providers.ErrZoneNotFound)
+}
+
+// pagination 写入超过单页数量的记录后，记录列表返回全部记录且没有重复
+func (s Suite) pagination(ctx context.Context) error {
+	if s.Seed == nil || s.SeedCount <= 0 {
+		return errSkipped
+	}
+	before, err := s.Provider.ListRecords(ctx, s.Domain)
+	if err != nil {
+		return err
+	}
+	if err := s.Seed(s.SeedCount); err != nil {
+		return fmt.Errorf("写入记录失败: %w", err)
+	}
+	after, err := s.Provider.ListRecords(ctx, s.Domain)
+	if err != nil {
+		return err
+	}
+
+	if len(after) != len(before)+s.SeedCount {
+		return fmt.Errorf("写入%d条记录后记录数应为%d，实际为%d", s.SeedCount, len(before)+s.SeedCount, len(after))
+	}
+	seen := make(map[string]bool, len(after))
+	for _, record := range after {
+		if seen[record.ID] {
+			return fmt.Errorf("记录%s在列表中重复出现", record.ID)
+		}
+		seen[record.ID] = true
+	}
+	return nil
+}
+
+// rateLimited 服务商返回限流错误后，经过重试的服务商实例最终请求成功
+func (s Suite) rateLimited(ctx context.Context) error {
+	if s.Faults == nil {
+		return errSkipped
+	}
+	s.Faults.InjectFault(emulator.FaultRateLimit)
+	if err := s.Provider.TestConnection(ctx); err != nil {
+		return fmt.Errorf("限流后重试仍然失败: %w", err)
+	}
+	return nil
+}
+
+// authFailed 服务商返回认证失败时返回ErrAuthFailed且不重试
+func (s Suite) authFailed(ctx context.Context) error {
+	if s.Faults == nil {
+		return errSkipped
+	}
+	s.Faults.InjectFault(emulator.FaultAuth)
+	err := s.Provider.TestConnection(ctx)
+	if err == nil {
+		return errors.New("认证失败没有返回错误")
+	}
+	return expectKind(err, providers.ErrAuthFailed)
+}
+
+// compareRecord 比较记录的名称、类型、值、TTL和MX优先级，记录值末尾的"."不影响比较
+func compareRecord(expected, actual providers.DNSRecord) error {
+	if actual.ID != expected.ID {
+		return fmt.Errorf("记录ID应为%s，实际为%s", expected.ID, actual.ID)
+	}
+	if !strings.EqualFold(actual.Name, expected.Name) {
+		return fmt.Errorf("记录%s的子域名应为%s，实际为%s", expected.ID, expected.Name, actual.Name)
+	}
+	if !strings.EqualFold(actual.Type, expected.Type) {
+		return fmt.Errorf("记录%s的类型应为%s，实际为%s", expected.ID, expected.Type, actual.Type)
+	}
+	if strings.TrimSuffix(actual.Value, ".") != strings.TrimSuffix(expected.Value, ".") {
+		return fmt.Errorf("记录%s的值应为%s，实际为%s", expected.ID, expected.Value, actual.Value)
+	}
+	if actual.TTL != expected.TTL {
+		return fmt.Errorf("记录%s的TTL应为%d，实际为%d", expected.ID, expected.TTL, actual.TTL)
+	}
+	if expected.Type == "MX" && actual.Priority != expected.Priority {
+		return fmt.Errorf("记录%s的优先级应为%d，实际为%d", expected.ID, expected.Priority, actual.Priority)
+	}
+	return nil
+}
+
+// expectKind 检查错误分类
+func expectKind(err, kind error) error {
+	if !errors.Is(err, kind) {
+		return fmt.Errorf("错误应为%q，实际为: %w", kind, err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package providers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"domain-max/pkg/dns/providers"
+	"domain-max/pkg/dns/providers/conformance"
+	"domain-max/pkg/dns/providers/emulator"
+)
+
+// conformanceDomain 模拟器中托管的测试域名
+const conformanceDomain = "example.com"
+
+// conformanceTarget 一致性检查的目标服务商
+type conformanceTarget struct {
+	config    map[string]string
+	seed      func(count int) error
+	seedCount int
+	faults    conformance.FaultInjector
+	close     func()
+}
+
+// conformanceTargets 有模拟器的服务商，分页检查写入的记录数超过适配器单页的记录数
+var conformanceTargets = map[string]func(t *testing.T) conformanceTarget{
+	"cloudflare": func(t *testing.T) conformanceTarget {
+		e := emulator.NewCloudflare()
+		e.AddZone(conformanceDomain)
+		return conformanceTarget{
+			config:    e.Config(),
+			seed:      func(count int) error { return e.SeedRecords(conformanceDomain, count) },
+			seedCount: 250,
+			faults:    e,
+			close:     e.Close,
+		}
+	},
+	"aliyun": func(t *testing.T) conformanceTarget {
+		e := emulator.NewAliyun()
+		e.AddZone(conformanceDomain)
+		return conformanceTarget{
+			config:    e.Config(),
+			seed:      func(count int) error { return e.SeedRecords(conformanceDomain, count) },
+			seedCount: 1200,
+			faults:    e,
+			close:     e.Close,
+		}
+	},
+	"dnspod": func(t *testing.T) conformanceTarget {
+		e := emulator.NewDNSPod()
+		e.AddZone(conformanceDomain)
+		return conformanceTarget{
+			config:    e.Config(),
+			seed:      func(count int) error { return e.SeedRecords(conformanceDomain, count) },
+			seedCount: 3500,
+			faults:    e,
+			close:     e.Close,
+		}
+	},
+	"mock": func(t *testing.T) conformanceTarget {
+		// 模拟DNS服务商没有HTTP接口，同一账号的实例共享数据，通过另一个实例写入记录和注入错误
+		config := map[string]string{"api_key": "conformance-" + t.Name(), "zones": conformanceDomain}
+		mock, err := providers.NewMockProvider(providers.ProviderConfig{APIKey: config["api_key"], ExtraParams: config})
+		if err != nil {
+			t.Fatalf("创建模拟服务商失败: %v", err)
+		}
+		return conformanceTarget{
+			config: config,
+			seed: func(count int) error {
+				records := make([]providers.DNSRecord, count)
+				for i := range records {
+					records[i] = providers.DNSRecord{Name: fmt.Sprintf("seed-%d", i), Type: "TXT", Value: "seed", TTL: 600}
+				}
+				_, err := mock.BatchAddRecords(context.Background(), conformanceDomain, records)
+				return err
+			},
+			seedCount: 100,
+			faults:    mock,
+			close:     func() {},
+		}
+	},
+}
+
+// conformanceExempt 没有模拟器、不执行一致性检查的服务商及原因
+var conformanceExempt = map[string]string{
+	"huawei":     "没有模拟器，签名、记录ID和记录集状态由huawei_test.go中的模拟服务器覆盖",
+	"volcengine": "没有模拟器，签名、线路、分页和增删改查由volcengine_test.go中的模拟服务器覆盖",
+	"route53":    "没有模拟器，签名、名称转义和记录集变更由route53_test.go中的模拟服务器覆盖",
+	"rfc2136":    "不使用HTTP接口，UPDATE、AXFR和TSIG由rfc2136_test.go中的进程内DNS服务器覆盖",
+	"powerdns":   "没有模拟器，记录暂停和记录集变更由powerdns_test.go中的模拟服务器覆盖",
+	"dnsla":      "没有模拟器，批量接口和分页由dnsla_test.go中的模拟服务器覆盖",
+	"baidu":      "没有模拟器，bce-auth-v1签名、线路和分页由baidu_test.go中的模拟服务器覆盖",
+	"namesilo":   "没有模拟器，返回码、记录ID变化和限流由namesilo_test.go中的模拟服务器覆盖",
+	"west":       "没有模拟器，GBK编码和持久记录ID由west_test.go中的模拟服务器覆盖",
+}
+
+// TestConformance 对有模拟器的服务商执行一致性检查，分页、限流和认证失败检查不能被跳过
+func TestConformance(t *testing.T) {
+	// 缩短重试等待时间，限流检查只需要等待一次重试
+	factory := providers.NewProviderFactory()
+	factory.SetRetryConfig(providers.RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  10 * time.Millisecond,
+		MaxDelay:      2 * time.Second,
+		BackoffFactor: 2.0,
+	})
+
+	for providerType, newTarget := range conformanceTargets {
+		t.Run(providerType, func(t *testing.T) {
+			target := newTarget(t)
+			defer target.close()
+			// 模拟器不限制请求频率，关闭客户端限流以加快检查
+			target.config["rate_limit"] = "0"
+
+			provider, err := factory.CreateProvider(providerType, target.config)
+			if err != nil {
+				t.Fatalf("创建服务商失败: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			results := conformance.Suite{
+				Provider:  provider,
+				Domain:    conformanceDomain,
+				Seed:      target.seed,
+				SeedCount: target.seedCount,
+				Faults:    target.faults,
+			}.Run(ctx)
+
+			for _, result := range results {
+				switch {
+				case result.Err != nil:
+					t.Errorf("%s: %v", result.Name, result.Err)
+				case result.Skipped && (result.Name == "分页" || result.Name == "限流后重试" || result.Name == "认证失败"):
+					t.Errorf("%s: 检查被跳过", result.Name)
+				case result.Skipped:
+					t.Logf("%s: 跳过", result.Name)
+				}
+			}
+		})
+	}
+}
+
+// TestConformanceCoverage 每个注册的服务商都需要执行一致性检查或说明不执行的原因
+func TestConformanceCoverage(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, definition := range providers.Definitions() {
+		registered[definition.Type] = true
+		_, hasTarget := conformanceTargets[definition.Type]
+		_, exempt := conformanceExempt[definition.Type]
+		if hasTarget == exempt {
+			t.Errorf("服务商%s需要添加模拟器或在conformanceExempt中说明原因（只能选择一种）", definition.Type)
+		}
+	}
+	for providerType := range conformanceExempt {
+		if !registered[providerType] {
+			t.Errorf("conformanceExempt中的服务商%s没有注册", providerType)
+		}
+	}
+}
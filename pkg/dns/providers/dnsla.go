@@ -0,0 +1,581 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNSLAProvider DNS.LA服务商
+type DNSLAProvider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	endpoint   string
+}
+
+// dnslaTypes 记录类型与DNS.LA数字类型代码的对应关系
+var dnslaTypes = map[string]int{
+	"A":     1,
+	"NS":    2,
+	"CNAME": 5,
+	"MX":    15,
+	"TXT":   16,
+	"AAAA":  28,
+	"SRV":   33,
+	"CAA":   257,
+}
+
+// init 注册DNSLA服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "dnsla",
+		DisplayName: "DNSLA",
+		Description: "DNSLA专业DNS解析服务",
+		SortOrder:   8,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
+			SupportsBatch:        true,
+			SupportsLineTypes:    true,
+			MaxRecordsPerDomain:  10000,
+			MinTTL:               1,
+			MaxTTL:               86400,
+			RateLimit:            10,
+			RateBurst:            10,
+		},
+		ConfigSchema: accessKeySchema("APIID", "APISecret"),
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewDNSLAProvider(config)
+		},
+	})
+}
+
+// NewDNSLAProvider 创建DNS.LA服务商实例，APIKey为APIID，APISecret为API密钥
+func NewDNSLAProvider(config ProviderConfig) (*DNSLAProvider, error) {
+	if config.APIKey == "" || config.APISecret == "" {
+		return nil, fmt.Errorf("DNS.LA需要APIID和APISecret")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://api.dns.la"
+	}
+
+	return &DNSLAProvider{
+		config:   config,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetName 获取服务商名称
+func (p *DNSLAProvider) GetName() string {
+	return "dnsla"
+}
+
+// ValidateConfig 验证API配置
+func (p *DNSLAProvider) ValidateConfig() error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("DNS.LA APIID不能为空")
+	}
+	if p.config.APISecret == "" {
+		return fmt.Errorf("DNS.LA APISecret不能为空")
+	}
+	return nil
+}
+
+// TestConnection 测试连接
+func (p *DNSLAProvider) TestConnection(ctx context.Context) error {
+	_, err := p.makeRequest(ctx, "GET", "/api/domainList", url.Values{"pageIndex": {"1"}, "pageSize": {"1"}}, nil)
+	return err
+}
+
+// dnslaDomain DNS.LA API返回的域名
+type dnslaDomain struct {
+	ID     string `json:"id"`
+	Domain string `json:"domain"`
+}
+
+// ListZones 获取账号下的全部域名
+func (p *DNSLAProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	const pageSize = 100
+	var zones []Zone
+
+	for page := 1; ; page++ {
+		query := url.Values{
+			"pageIndex": {strconv.Itoa(page)},
+			"pageSize":  {strconv.Itoa(pageSize)},
+		}
+		response, err := p.makeRequest(ctx, "GET", "/api/domainList", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Total   int           `json:"total"`
+			Results []dnslaDomain `json:"results"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, domain := range result.Results {
+			zones = append(zones, Zone{
+				ID:     domain.ID,
+				Name:   strings.TrimSuffix(domain.Domain, "."),
+				Status: "active",
+			})
+		}
+
+		if len(result.Results) < pageSize || len(zones) >= result.Total {
+			break
+		}
+	}
+
+	return zones, nil
+}
+
+// VerifyZone 校验域名已添加到DNS.LA账号下
+func (p *DNSLAProvider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	return p.getDomainID(ctx, domain)
+}
+
+// ListLines 获取域名可用的解析线路，线路分组展开后以分组名称作为Group
+func (p *DNSLAProvider) ListLines(ctx context.Context, domain string) ([]Line, error) {
+	response, err := p.makeRequest(ctx, "GET", "/api/availableLine", url.Values{"domain": {domain}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []dnslaLine
+	if err := json.Unmarshal(response, &nodes); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	var lines []Line
+	var walk func(nodes []dnslaLine, group string)
+	walk = func(nodes []dnslaLine, group string) {
+		for _, node := range nodes {
+			lines = append(lines, Line{
+				ID:    node.ID,
+				Code:  node.Code,
+				Name:  node.Name,
+				Group: group,
+			})
+			walk(node.Children, node.Name)
+		}
+	}
+	walk(nodes, "")
+
+	return lines, nil
+}
+
+// dnslaLine DNS.LA API返回的线路节点
+type dnslaLine struct {
+	ID       string      `json:"id"`
+	Code     string      `json:"code"`
+	Name     string      `json:"value"`
+	Children []dnslaLine `json:"children"`
+}
+
+// dnslaRecord DNS.LA API返回的记录
+type dnslaRecord struct {
+	ID         string `json:"id"`
+	DomainID   string `json:"domainId"`
+	Host       string `json:"host"`
+	Type       int    `json:"type"`
+	Data       string `json:"data"`
+	TTL        int    `json:"ttl"`
+	LineID     string `json:"lineId"`
+	LineCode   string `json:"lineCode"`
+	Preference int    `json:"preference"`
+	Weight     int    `json:"weight"`
+	Disable    bool   `json:"disable"`
+}
+
+// toDNSRecord 转换为通用DNS记录
+func (r dnslaRecord) toDNSRecord() DNSRecord {
+	status := "active"
+	if r.Disable {
+		status = "disabled"
+	}
+
+	name := r.Host
+	if name == "" {
+		name = "@"
+	}
+
+	record := DNSRecord{
+		ID:       r.ID,
+		Name:     name,
+		Type:     dnslaTypeName(r.Type),
+		Value:    r.Data,
+		TTL:      r.TTL,
+		Priority: r.Preference,
+		Line:     r.LineCode,
+		Status:   status,
+	}
+
+	// SRV记录的优先级、权重和端口包含在记录值中
+	if fields := strings.Fields(r.Data); record.Type == "SRV" && len(fields) == 4 {
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Weight, _ = strconv.Atoi(fields[1])
+		record.Port, _ = strconv.Atoi(fields[2])
+		record.Value = fields[3]
+	}
+
+	return record
+}
+
+// ListRecords 获取域名记录列表
+func (p *DNSLAProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *DNSLAProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	items, err := p.listRecords(ctx, domain, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]DNSRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, item.toDNSRecord())
+	}
+	return filterRecords(records, filter), nil
+}
+
+// AddRecord 添加DNS记录
+func (p *DNSLAProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	domainID, err := p.getDomainID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	data, err := p.recordBody(ctx, domain, record)
+	if err != nil {
+		return nil, err
+	}
+	data["domainId"] = domainID
+
+	response, err := p.makeRequest(ctx, "POST", "/api/record", nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	created := record
+	created.ID = result.ID
+	created.Status = "active"
+	return &created, nil
+}
+
+// UpdateRecord 更新DNS记录
+func (p *DNSLAProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	data, err := p.recordBody(ctx, domain, record)
+	if err != nil {
+		return err
+	}
+	data["id"] = recordID
+
+	_, err = p.makeRequest(ctx, "PUT", "/api/record", nil, data)
+	return err
+}
+
+// DeleteRecord 删除DNS记录
+func (p *DNSLAProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	_, err := p.makeRequest(ctx, "DELETE", "/api/record", url.Values{"id": {recordID}}, nil)
+	return err
+}
+
+// GetRecord 获取单个记录详情
+func (p *DNSLAProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	response, err := p.makeRequest(ctx, "GET", "/api/record", url.Values{"id": {recordID}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result dnslaRecord
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	record := result.toDNSRecord()
+	return &record, nil
+}
+
+// BatchAddRecords 通过批量接口一次添加多条DNS记录
+func (p *DNSLAProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	domainID, err := p.getDomainID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	items := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		item, err := p.recordBody(ctx, domain, record)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if _, err := p.makeRequest(ctx, "POST", "/api/recordBatch", nil, map[string]interface{}{
+		"domainId": domainID,
+		"records":  items,
+	}); err != nil {
+		return nil, err
+	}
+
+	// 批量接口不返回记录ID，需要读取记录列表按内容匹配
+	existing, err := p.listRecords(ctx, domain, RecordFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("批量添加成功但读取记录ID失败: %w", err)
+	}
+
+	results := make([]DNSRecord, 0, len(records))
+	used := make(map[string]bool)
+	for i, record := range records {
+		created := record
+		created.Status = "active"
+		for _, candidate := range existing {
+			if !used[candidate.ID] && dnslaSameRecord(candidate, items[i]) {
+				created.ID = candidate.ID
+				used[candidate.ID] = true
+				break
+			}
+		}
+		if created.ID == "" {
+			return results, fmt.Errorf("批量添加成功但未找到记录: %s %s %s", record.Name, record.Type, record.Value)
+		}
+		results = append(results, created)
+	}
+
+	return results, nil
+}
+
+// listRecords 分页获取原生记录
+func (p *DNSLAProvider) listRecords(ctx context.Context, domain string, filter RecordFilter) ([]dnslaRecord, error) {
+	domainID, err := p.getDomainID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	const pageSize = 100
+	var records []dnslaRecord
+
+	for page := 1; ; page++ {
+		query := url.Values{
+			"domainId":  {domainID},
+			"pageIndex": {strconv.Itoa(page)},
+			"pageSize":  {strconv.Itoa(pageSize)},
+		}
+		if filter.Name != "" {
+			query.Set("host", dnslaHost(filter.Name))
+		}
+		if code, ok := dnslaTypes[strings.ToUpper(filter.Type)]; ok {
+			query.Set("type", strconv.Itoa(code))
+		}
+
+		response, err := p.makeRequest(ctx, "GET", "/api/recordList", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Total   int           `json:"total"`
+			Results []dnslaRecord `json:"results"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		records = append(records, result.Results...)
+
+		if len(result.Results) < pageSize || len(records) >= result.Total {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// recordBody 构建创建和更新记录的请求参数
+func (p *DNSLAProvider) recordBody(ctx context.Context, domain string, record DNSRecord) (map[string]interface{}, error) {
+	recordType := strings.ToUpper(record.Type)
+	code, ok := dnslaTypes[recordType]
+	if !ok {
+		return nil, fmt.Errorf("DNS.LA不支持的记录类型: %s", record.Type)
+	}
+
+	value := record.Value
+	if recordType == "SRV" {
+		value = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Value)
+	}
+
+	lineID, err := p.resolveLineID(ctx, domain, record.Line)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"type":   code,
+		"host":   dnslaHost(record.Name),
+		"data":   value,
+		"ttl":    record.TTL,
+		"lineId": lineID,
+	}
+	if recordType == "MX" {
+		data["preference"] = record.Priority
+	}
+	return data, nil
+}
+
+// resolveLineID 将线路代码、名称或ID转换为线路ID，默认线路为空字符串
+func (p *DNSLAProvider) resolveLineID(ctx context.Context, domain, line string) (string, error) {
+	if line == "" || line == "default" || line == "默认" {
+		return "", nil
+	}
+
+	lines, err := p.ListLines(ctx, domain)
+	if err != nil {
+		return "", fmt.Errorf("获取解析线路失败: %w", err)
+	}
+
+	for _, candidate := range lines {
+		if candidate.ID == line || strings.EqualFold(candidate.Code, line) || candidate.Name == line {
+			return candidate.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("不支持的解析线路: %s", line)
+}
+
+// getDomainID 获取域名ID，结果按凭据缓存
+func (p *DNSLAProvider) getDomainID(ctx context.Context, domain string) (string, error) {
+	return cachedZoneID(ctx, "dnsla", p.config, domain, p.lookupDomainID)
+}
+
+// lookupDomainID 查询域名ID
+func (p *DNSLAProvider) lookupDomainID(ctx context.Context, domain string) (string, error) {
+	response, err := p.makeRequest(ctx, "GET", "/api/domain", url.Values{"domain": {domain}}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result dnslaDomain
+	if err := json.Unmarshal(response, &result); err != nil {
+		return "", fmt.Errorf("解析响应失败: %w", err)
+	}
+	if result.ID == "" {
+		return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
+	}
+
+	return result.ID, nil
+}
+
+// makeRequest 发起API请求
+func (p *DNSLAProvider) makeRequest(ctx context.Context, method, path string, query url.Values, data interface{}) ([]byte, error) {
+	var body io.Reader
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("序列化请求数据失败: %w", err)
+		}
+		body = bytes.NewReader(jsonData)
+	}
+
+	requestURL := p.endpoint + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.SetBasicAuth(p.config.APIKey, p.config.APISecret)
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发起请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result struct {
+		Code int             `json:"code"`
+		Msg  string          `json:"msg"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, newStatusError("DNS.LA", resp, string(respBody))
+	}
+
+	if result.Code != 200 {
+		// DNS.LA的业务错误码沿用HTTP状态码的含义
+		apiErr := newHTTPError("DNS.LA", resp, strconv.Itoa(result.Code), result.Msg, "")
+		if apiErr.Kind == nil {
+			apiErr.Kind = classifyStatusCode(result.Code)
+		}
+		return nil, apiErr
+	}
+
+	if len(result.Data) == 0 || string(result.Data) == "null" {
+		return []byte("{}"), nil
+	}
+	return result.Data, nil
+}
+
+// dnslaSameRecord 判断服务商返回的记录是否与批量添加的请求参数一致
+func dnslaSameRecord(candidate dnslaRecord, item map[string]interface{}) bool {
+	return strings.EqualFold(dnslaHost(candidate.Host), fmt.Sprint(item["host"])) &&
+		candidate.Type == item["type"] &&
+		candidate.Data == item["data"] &&
+		candidate.LineID == item["lineId"]
+}
+
+// dnslaHost 将子域名转换为DNS.LA的主机记录，根域名使用"@"
+func dnslaHost(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+// dnslaTypeName 将DNS.LA数字类型代码转换为记录类型
+func dnslaTypeName(code int) string {
+	for name, value := range dnslaTypes {
+		if value == code {
+			return name
+		}
+	}
+	return strconv.Itoa(code)
+}
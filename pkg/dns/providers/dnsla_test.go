@@ -0,0 +1,240 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	dnslaTestAPIID     = "dnsla-test-id"
+	dnslaTestAPISecret = "dnsla-test-secret"
+)
+
+// dnslaFake DNS.LA开放接口的内存实现，批量添加接口不返回记录ID
+type dnslaFake struct {
+	t          *testing.T
+	mu         sync.Mutex
+	records    []dnslaRecord
+	nextID     int
+	listPages  int // 记录列表接口被调用的次数
+	batchCalls int
+	dropBatch  bool // 为true时批量接口返回成功但不保存记录
+}
+
+func newDNSLAFake(t *testing.T) (*dnslaFake, *DNSLAProvider) {
+	t.Helper()
+	fake := &dnslaFake{t: t}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSLAProvider(ProviderConfig{APIKey: dnslaTestAPIID, APISecret: dnslaTestAPISecret, Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	return fake, provider
+}
+
+func (f *dnslaFake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if id, secret, ok := r.BasicAuth(); !ok || id != dnslaTestAPIID || secret != dnslaTestAPISecret {
+		writeDNSLAResponse(w, 401, "认证失败", nil)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	query := r.URL.Query()
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/api/domain":
+		if query.Get("domain") != "example.com" {
+			writeDNSLAResponse(w, 200, "", nil)
+			return
+		}
+		writeDNSLAResponse(w, 200, "", dnslaDomain{ID: "domain-1", Domain: "example.com."})
+	case r.Method == "GET" && r.URL.Path == "/api/availableLine":
+		writeDNSLAResponse(w, 200, "", []dnslaLine{
+			{ID: "", Code: "default", Name: "默认"},
+			{ID: "isp", Code: "isp", Name: "运营商", Children: []dnslaLine{{ID: "line-telecom", Code: "telecom", Name: "电信"}}},
+		})
+	case r.Method == "GET" && r.URL.Path == "/api/recordList":
+		f.listPages++
+		var matched []dnslaRecord
+		for _, record := range f.records {
+			if host := query.Get("host"); host != "" && record.Host != host {
+				continue
+			}
+			if recordType := query.Get("type"); recordType != "" && strconv.Itoa(record.Type) != recordType {
+				continue
+			}
+			matched = append(matched, record)
+		}
+		page, _ := strconv.Atoi(query.Get("pageIndex"))
+		size, _ := strconv.Atoi(query.Get("pageSize"))
+		start, end := (page-1)*size, page*size
+		if start > len(matched) {
+			start = len(matched)
+		}
+		if end > len(matched) {
+			end = len(matched)
+		}
+		writeDNSLAResponse(w, 200, "", map[string]interface{}{"total": len(matched), "results": matched[start:end]})
+	case r.Method == "POST" && r.URL.Path == "/api/recordBatch":
+		f.batchCalls++
+		var body struct {
+			DomainID string        `json:"domainId"`
+			Records  []dnslaRecord `json:"records"`
+		}
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil || body.DomainID != "domain-1" {
+			writeDNSLAResponse(w, 400, "参数错误", nil)
+			return
+		}
+		for _, record := range body.Records {
+			if !f.dropBatch {
+				f.add(record)
+			}
+		}
+		writeDNSLAResponse(w, 200, "", nil)
+	case r.Method == "POST" && r.URL.Path == "/api/record":
+		var record dnslaRecord
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &record)
+		for _, existing := range f.records {
+			if existing.Host == record.Host && existing.Type == record.Type && existing.Data == record.Data && existing.LineID == record.LineID {
+				writeDNSLAResponse(w, 409, "记录已存在", nil)
+				return
+			}
+		}
+		writeDNSLAResponse(w, 200, "", map[string]string{"id": f.add(record)})
+	case r.Method == "GET" && r.URL.Path == "/api/record":
+		for _, record := range f.records {
+			if record.ID == query.Get("id") {
+				writeDNSLAResponse(w, 200, "", record)
+				return
+			}
+		}
+		writeDNSLAResponse(w, 404, "记录不存在", nil)
+	default:
+		writeDNSLAResponse(w, 404, "接口不存在", nil)
+	}
+}
+
+// add 保存记录并分配记录ID
+func (f *dnslaFake) add(record dnslaRecord) string {
+	f.nextID++
+	record.ID = fmt.Sprintf("record-%d", f.nextID)
+	record.DomainID = "domain-1"
+	f.records = append(f.records, record)
+	return record.ID
+}
+
+// writeDNSLAResponse 按DNS.LA的格式返回响应，业务错误码放在响应体中，HTTP状态码始终为200
+func writeDNSLAResponse(w http.ResponseWriter, code int, msg string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"code": code, "msg": msg, "data": data})
+}
+
+func TestDNSLABatchAddRecords(t *testing.T) {
+	fake, provider := newDNSLAFake(t)
+	ctx := context.Background()
+
+	// 已有的同名记录不能被匹配为新添加的记录
+	existingID := fake.add(dnslaRecord{Host: "batch", Type: 16, Data: "192.0.2.1", TTL: 600})
+
+	// 超过一页的记录数量，读取记录ID需要分页
+	var records []DNSRecord
+	for i := 0; i < 150; i++ {
+		records = append(records, DNSRecord{Name: "batch", Type: "A", Value: fmt.Sprintf("192.0.2.%d", i%250+1), TTL: 600})
+	}
+	records = append(records, DNSRecord{Name: "batch", Type: "A", Value: "192.0.2.1", TTL: 600, Line: "telecom"})
+
+	created, err := provider.BatchAddRecords(ctx, "example.com", records)
+	if err != nil {
+		t.Fatalf("批量添加记录失败: %v", err)
+	}
+	if fake.batchCalls != 1 {
+		t.Fatalf("应只调用一次批量接口，实际为%d次", fake.batchCalls)
+	}
+	if fake.listPages != 2 {
+		t.Fatalf("读取记录ID应分页获取2页，实际为%d页", fake.listPages)
+	}
+	if len(created) != len(records) {
+		t.Fatalf("返回的记录数量不一致: %d", len(created))
+	}
+
+	seen := map[string]bool{existingID: true}
+	for i, record := range created {
+		if record.ID == "" || seen[record.ID] {
+			t.Fatalf("第%d条记录的ID缺失或重复: %q", i, record.ID)
+		}
+		seen[record.ID] = true
+	}
+
+	// 线路按代码转换为线路ID，匹配时区分线路
+	got, err := provider.GetRecord(ctx, "example.com", created[len(created)-1].ID)
+	if err != nil || got.Value != "192.0.2.1" {
+		t.Fatalf("获取记录失败: %+v, %v", got, err)
+	}
+	if fake.records[len(fake.records)-1].LineID != "line-telecom" {
+		t.Fatalf("线路应转换为线路ID，实际为%q", fake.records[len(fake.records)-1].LineID)
+	}
+
+	listed, err := provider.ListRecordsFiltered(ctx, "example.com", RecordFilter{Name: "batch", Type: "A"})
+	if err != nil || len(listed) != len(records) {
+		t.Fatalf("分页获取的记录数量不一致: %d, %v", len(listed), err)
+	}
+}
+
+func TestDNSLABatchAddRecordsMissing(t *testing.T) {
+	fake, provider := newDNSLAFake(t)
+	ctx := context.Background()
+
+	if created, err := provider.BatchAddRecords(ctx, "example.com", nil); err != nil || created != nil || fake.batchCalls != 0 {
+		t.Fatalf("空的批量添加不应调用接口: %v", err)
+	}
+
+	// 服务商未保存批量添加的记录时返回错误，而不是返回没有ID的记录
+	fake.dropBatch = true
+	_, err := provider.BatchAddRecords(ctx, "example.com", []DNSRecord{{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 600}})
+	if err == nil || !strings.Contains(err.Error(), "未找到记录") {
+		t.Fatalf("读取不到批量添加的记录时应返回错误，实际为: %v", err)
+	}
+}
+
+func TestDNSLAErrors(t *testing.T) {
+	_, provider := newDNSLAFake(t)
+	ctx := context.Background()
+
+	if _, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 600}); err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+
+	// 响应体中的业务错误码按HTTP状态码的含义分类
+	_, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 600})
+	var providerErr *ProviderError
+	if !errors.Is(err, ErrRecordConflict) || !errors.As(err, &providerErr) || providerErr.Message != "记录已存在" {
+		t.Fatalf("重复添加应返回ErrRecordConflict，实际为: %v", err)
+	}
+
+	if _, err := provider.VerifyZone(ctx, "missing.example"); !errors.Is(err, ErrZoneNotFound) {
+		t.Fatalf("不存在的域名应返回ErrZoneNotFound，实际为: %v", err)
+	}
+
+	if _, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 600, Line: "unknown"}); err == nil {
+		t.Fatalf("不支持的线路应返回错误")
+	}
+
+	provider.config.APISecret = "wrong-secret"
+	if err := provider.TestConnection(ctx); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("认证失败应返回ErrAuthFailed，实际为: %v", err)
+	}
+}
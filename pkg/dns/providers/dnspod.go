@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,6 +22,30 @@ type DNSPodProvider struct {
 	endpoint   string
 }
 
+// init 注册腾讯云DNSPod服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "dnspod",
+		DisplayName: "腾讯云DNSPod",
+		Description: "腾讯云DNSPod服务",
+		SortOrder:   2,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV"},
+			SupportsBatch:        false,
+			SupportsLineTypes:    true,
+			MaxRecordsPerDomain:  10000,
+			MinTTL:               1,
+			MaxTTL:               604800,
+			RateLimit:            20,
+			RateBurst:            20,
+		},
+		ConfigSchema: accessKeySchema("SecretId", "SecretKey"),
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewDNSPodProvider(config)
+		},
+	})
+}
+
 // NewDNSPodProvider 创建腾讯云DNSPod服务商实例
 func NewDNSPodProvider(config ProviderConfig) (*DNSPodProvider, error) {
 	if config.APIKey == "" || config.APISecret == "" {
@@ -68,54 +93,82 @@ func (p *DNSPodProvider) TestConnection(ctx context.Context) error {
 
 // ListRecords 获取域名记录列表
 func (p *DNSPodProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *DNSPodProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
 	// 首先获取域名ID
 	domainID, err := p.getDomainID(ctx, domain)
 	if err != nil {
-		return nil, fmt.Errorf("获取域名ID失败: %v", err)
-	}
-	
-	params := map[string]interface{}{
-		"Domain":   domain,
-		"DomainId": domainID,
-		"Limit":    3000,
-	}
-	
-	response, err := p.makeRequest(ctx, "DescribeRecordList", params)
-	if err != nil {
-		return nil, err
-	}
-	
-	var result struct {
-		Response struct {
-			RecordList []struct {
-				RecordId int    `json:"RecordId"`
-				Name     string `json:"Name"`
-				Type     string `json:"Type"`
-				Value    string `json:"Value"`
-				TTL      int    `json:"TTL"`
-				MX       int    `json:"MX"`
-				Line     string `json:"Line"`
-				Status   string `json:"Status"`
-			} `json:"RecordList"`
-		} `json:"Response"`
-	}
-	
-	if err := json.Unmarshal(response, &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %v", err)
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
 	}
 	
+	const pageSize = 3000
 	var records []DNSRecord
-	for _, record := range result.Response.RecordList {
-		records = append(records, DNSRecord{
-			ID:       strconv.Itoa(record.RecordId),
-			Name:     record.Name,
-			Type:     record.Type,
-			Value:    record.Value,
-			TTL:      record.TTL,
-			Priority: record.MX,
-			Line:     record.Line,
-			Status:   record.Status,
-		})
+	
+	for offset := 0; ; offset += pageSize {
+		params := map[string]interface{}{
+			"Domain":   domain,
+			"DomainId": domainID,
+			"Offset":   offset,
+			"Limit":    pageSize,
+		}
+		if filter.Name != "" {
+			params["Subdomain"] = filter.Name
+		}
+		if filter.Type != "" {
+			params["RecordType"] = strings.ToUpper(filter.Type)
+		}
+		
+		response, err := p.makeRequest(ctx, "DescribeRecordList", params)
+		if err != nil {
+			// 没有匹配的记录时DNSPod返回错误码而不是空列表
+			var apiErr *ProviderError
+			if errors.As(err, &apiErr) && apiErr.Code == "ResourceNotFound.NoDataOfRecord" {
+				break
+			}
+			return nil, err
+		}
+		
+		var result struct {
+			Response struct {
+				RecordCountInfo struct {
+					TotalCount int `json:"TotalCount"`
+				} `json:"RecordCountInfo"`
+				RecordList []struct {
+					RecordId int    `json:"RecordId"`
+					Name     string `json:"Name"`
+					Type     string `json:"Type"`
+					Value    string `json:"Value"`
+					TTL      int    `json:"TTL"`
+					MX       int    `json:"MX"`
+					Line     string `json:"Line"`
+					Status   string `json:"Status"`
+				} `json:"RecordList"`
+			} `json:"Response"`
+		}
+		
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+		
+		for _, record := range result.Response.RecordList {
+			records = append(records, DNSRecord{
+				ID:       strconv.Itoa(record.RecordId),
+				Name:     record.Name,
+				Type:     record.Type,
+				Value:    record.Value,
+				TTL:      record.TTL,
+				Priority: record.MX,
+				Line:     record.Line,
+				Status:   record.Status,
+			})
+		}
+		
+		if len(result.Response.RecordList) < pageSize || len(records) >= result.Response.RecordCountInfo.TotalCount {
+			break
+		}
 	}
 	
 	return records, nil
@@ -126,7 +179,7 @@ func (p *DNSPodProvider) AddRecord(ctx context.Context, domain string, record DN
 	// 获取域名ID
 	domainID, err := p.getDomainID(ctx, domain)
 	if err != nil {
-		return nil, fmt.Errorf("获取域名ID失败: %v", err)
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
 	}
 	
 	params := map[string]interface{}{
@@ -142,9 +195,8 @@ func (p *DNSPodProvider) AddRecord(ctx context.Context, domain string, record DN
 		params["MX"] = record.Priority
 	}
 	
-	if record.Line != "" {
-		params["RecordLine"] = record.Line
-	}
+	// RecordLine为必填参数，未指定线路时使用默认线路
+	params["RecordLine"] = dnspodRecordLine(record.Line)
 	
 	response, err := p.makeRequest(ctx, "CreateRecord", params)
 	if err != nil {
@@ -158,7 +210,7 @@ func (p *DNSPodProvider) AddRecord(ctx context.Context, domain string, record DN
 	}
 	
 	if err := json.Unmarshal(response, &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %v", err)
+		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
 	
 	// 返回创建的记录
@@ -173,7 +225,7 @@ func (p *DNSPodProvider) UpdateRecord(ctx context.Context, domain string, record
 	// 获取域名ID
 	domainID, err := p.getDomainID(ctx, domain)
 	if err != nil {
-		return fmt.Errorf("获取域名ID失败: %v", err)
+		return fmt.Errorf("获取域名ID失败: %w", err)
 	}
 	
 	recordIDInt, err := strconv.Atoi(recordID)
@@ -195,8 +247,12 @@ func (p *DNSPodProvider) UpdateRecord(ctx context.Context, domain string, record
 		params["MX"] = record.Priority
 	}
 	
-	if record.Line != "" {
-		params["RecordLine"] = record.Line
+	// RecordLine为必填参数，未指定线路时使用默认线路
+	params["RecordLine"] = dnspodRecordLine(record.Line)
+	
+	// 未指定Status时ModifyRecord会启用记录，暂停的记录需要保持暂停状态
+	if !RecordEnabled(record) {
+		params["Status"] = "DISABLE"
 	}
 	
 	_, err = p.makeRequest(ctx, "ModifyRecord", params)
@@ -208,7 +264,7 @@ func (p *DNSPodProvider) DeleteRecord(ctx context.Context, domain string, record
 	// 获取域名ID
 	domainID, err := p.getDomainID(ctx, domain)
 	if err != nil {
-		return fmt.Errorf("获取域名ID失败: %v", err)
+		return fmt.Errorf("获取域名ID失败: %w", err)
 	}
 	
 	recordIDInt, err := strconv.Atoi(recordID)
@@ -226,6 +282,35 @@ func (p *DNSPodProvider) DeleteRecord(ctx context.Context, domain string, record
 	return err
 }
 
+// SetRecordStatus 启用或暂停DNS记录
+func (p *DNSPodProvider) SetRecordStatus(ctx context.Context, domain string, recordID string, enabled bool) error {
+	// 获取域名ID
+	domainID, err := p.getDomainID(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("获取域名ID失败: %w", err)
+	}
+	
+	recordIDInt, err := strconv.Atoi(recordID)
+	if err != nil {
+		return fmt.Errorf("无效的记录ID: %s", recordID)
+	}
+	
+	status := "DISABLE"
+	if enabled {
+		status = "ENABLE"
+	}
+	
+	params := map[string]interface{}{
+		"Domain":   domain,
+		"DomainId": domainID,
+		"RecordId": recordIDInt,
+		"Status":   status,
+	}
+	
+	_, err = p.makeRequest(ctx, "ModifyRecordStatus", params)
+	return err
+}
+
 // GetRecord 获取单个记录详情
 func (p *DNSPodProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
 	// DNSPod API没有单独的获取记录接口，需要通过列表接口获取
@@ -240,10 +325,10 @@ func (p *DNSPodProvider) GetRecord(ctx context.Context, domain string, recordID
 		}
 	}
 	
-	return nil, fmt.Errorf("记录不存在: %s", recordID)
+	return nil, fmt.Errorf("%w: %s", ErrRecordNotFound, recordID)
 }
 
-// BatchAddRecords 批量添加DNS记录
+// BatchAddRecords 逐条添加DNS记录，DNSPod没有使用批量接口，因此不声明SupportsBatch
 func (p *DNSPodProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
 	var results []DNSRecord
 	var errors []error
@@ -264,37 +349,93 @@ func (p *DNSPodProvider) BatchAddRecords(ctx context.Context, domain string, rec
 	return results, nil
 }
 
-// getDomainID 获取域名ID
-func (p *DNSPodProvider) getDomainID(ctx context.Context, domain string) (int, error) {
-	params := map[string]interface{}{
-		"Limit": 3000,
+// VerifyZone 校验域名已托管在DNSPod账号下
+func (p *DNSPodProvider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	domainID, err := p.getDomainID(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(domainID), nil
+}
+
+// ListZones 获取账号下托管的全部域名
+func (p *DNSPodProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	const pageSize = 3000
+	var zones []Zone
+	
+	for offset := 0; ; offset += pageSize {
+		params := map[string]interface{}{
+			"Offset": offset,
+			"Limit":  pageSize,
+		}
+		
+		response, err := p.makeRequest(ctx, "DescribeDomainList", params)
+		if err != nil {
+			return nil, err
+		}
+		
+		var result struct {
+			Response struct {
+				DomainCountInfo struct {
+					AllTotal int `json:"AllTotal"`
+				} `json:"DomainCountInfo"`
+				DomainList []struct {
+					DomainId    int    `json:"DomainId"`
+					Name        string `json:"Name"`
+					Status      string `json:"Status"`
+					RecordCount int    `json:"RecordCount"`
+				} `json:"DomainList"`
+			} `json:"Response"`
+		}
+		
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+		
+		for _, domainInfo := range result.Response.DomainList {
+			status := strings.ToLower(domainInfo.Status)
+			if domainInfo.Status == "ENABLE" {
+				status = "active"
+			}
+			zones = append(zones, Zone{
+				ID:          strconv.Itoa(domainInfo.DomainId),
+				Name:        domainInfo.Name,
+				Status:      status,
+				RecordCount: domainInfo.RecordCount,
+			})
+		}
+		
+		if len(result.Response.DomainList) < pageSize || len(zones) >= result.Response.DomainCountInfo.AllTotal {
+			break
+		}
 	}
 	
-	response, err := p.makeRequest(ctx, "DescribeDomainList", params)
+	return zones, nil
+}
+
+// getDomainID 获取域名ID，结果按凭据缓存
+func (p *DNSPodProvider) getDomainID(ctx context.Context, domain string) (int, error) {
+	domainID, err := cachedZoneID(ctx, "dnspod", p.config, domain, p.lookupDomainID)
 	if err != nil {
 		return 0, err
 	}
-	
-	var result struct {
-		Response struct {
-			DomainList []struct {
-				DomainId int    `json:"DomainId"`
-				Name     string `json:"Name"`
-			} `json:"DomainList"`
-		} `json:"Response"`
-	}
-	
-	if err := json.Unmarshal(response, &result); err != nil {
-		return 0, fmt.Errorf("解析响应失败: %v", err)
+	return strconv.Atoi(domainID)
+}
+
+// lookupDomainID 查询域名ID，需要读取账号下的全部域名
+func (p *DNSPodProvider) lookupDomainID(ctx context.Context, domain string) (string, error) {
+	zones, err := p.ListZones(ctx)
+	if err != nil {
+		return "", err
 	}
 	
-	for _, domainInfo := range result.Response.DomainList {
-		if domainInfo.Name == domain {
-			return domainInfo.DomainId, nil
+	for _, zone := range zones {
+		if zone.Name == domain {
+			return zone.ID, nil
 		}
 	}
 	
-	return 0, fmt.Errorf("域名不存在: %s", domain)
+	return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
 }
 
 // makeRequest 发起API请求
@@ -307,19 +448,18 @@ func (p *DNSPodProvider) makeRequest(ctx context.Context, action string, params
 	
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
-		return nil, fmt.Errorf("序列化请求体失败: %v", err)
+		return nil, fmt.Errorf("序列化请求体失败: %w", err)
 	}
 	
 	// 创建请求
 	req, err := http.NewRequestWithContext(ctx, "POST", p.endpoint, strings.NewReader(string(jsonBody)))
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %v", err)
+		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
 	
 	// 设置请求头
 	timestamp := time.Now().Unix()
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Set("Host", "dnspod.tencentcloudapi.com")
 	req.Header.Set("X-TC-Action", action)
 	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(timestamp, 10))
 	req.Header.Set("X-TC-Version", "2021-03-23")
@@ -332,18 +472,18 @@ func (p *DNSPodProvider) makeRequest(ctx context.Context, action string, params
 	// 发起请求
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("发起请求失败: %v", err)
+		return nil, fmt.Errorf("发起请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 	
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %v", err)
+		return nil, fmt.Errorf("读取响应失败: %w", err)
 	}
 	
 	// 检查HTTP状态码
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API请求失败，状态码: %d, 响应: %s", resp.StatusCode, string(body))
+		return nil, newStatusError("腾讯云DNSPod", resp, string(body))
 	}
 	
 	// 检查API错误
@@ -358,7 +498,7 @@ func (p *DNSPodProvider) makeRequest(ctx context.Context, action string, params
 	}
 	
 	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Response.Error.Code != "" {
-		return nil, fmt.Errorf("腾讯云DNSPod API错误: %s - %s (RequestId: %s)", 
+		return nil, newHTTPError("腾讯云DNSPod", resp,
 			errorResp.Response.Error.Code, errorResp.Response.Error.Message, errorResp.Response.RequestId)
 	}
 	
@@ -372,7 +512,7 @@ func (p *DNSPodProvider) generateSignature(req *http.Request, payload string, ti
 	canonicalURI := "/"
 	canonicalQueryString := ""
 	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\n", 
-		req.Header.Get("Content-Type"), req.Header.Get("Host"))
+		req.Header.Get("Content-Type"), req.URL.Host)
 	signedHeaders := "content-type;host"
 	hashedRequestPayload := sha256Hex(payload)
 	
@@ -410,6 +550,14 @@ func (p *DNSPodProvider) generateSignature(req *http.Request, payload string, ti
 	return authorization
 }
 
+// dnspodRecordLine 获取记录线路，未指定时为默认线路
+func dnspodRecordLine(line string) string {
+	if line == "" {
+		return "默认"
+	}
+	return line
+}
+
 // sha256Hex 计算SHA256哈希值并返回十六进制字符串
 func sha256Hex(s string) string {
 	b := sha256.Sum256([]byte(s))
@@ -0,0 +1,519 @@
+package emulator
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	aliyunVersion            = "2015-01-09"
+	aliyunSignatureAlgorithm = "ACS3-HMAC-SHA256"
+	// aliyunMaxClockSkew 请求时间与服务端时间允许的最大偏差
+	aliyunMaxClockSkew = 15 * time.Minute
+)
+
+// Aliyun 阿里云云解析（Alidns 2015-01-09）API模拟器
+// 校验V3签名（ACS3-HMAC-SHA256）和签名随机串，支持域名和记录的查询、添加、修改和删除
+type Aliyun struct {
+	*store
+	server *httptest.Server
+
+	AccessKeyID     string
+	AccessKeySecret string
+}
+
+// NewAliyun 启动阿里云云解析API模拟器，凭据随机生成
+func NewAliyun() *Aliyun {
+	e := &Aliyun{
+		store:           newStore(),
+		AccessKeyID:     "LTAI" + randomHex(10),
+		AccessKeySecret: randomHex(15),
+	}
+	e.server = httptest.NewServer(http.HandlerFunc(e.serveHTTP))
+	return e
+}
+
+// URL 模拟器的API地址，作为适配器的Endpoint
+func (e *Aliyun) URL() string {
+	return e.server.URL
+}
+
+// Config 创建阿里云适配器使用的配置
+func (e *Aliyun) Config() map[string]string {
+	return map[string]string{
+		"api_key":    e.AccessKeyID,
+		"api_secret": e.AccessKeySecret,
+		"endpoint":   e.URL(),
+	}
+}
+
+// Close 关闭模拟器
+func (e *Aliyun) Close() {
+	e.server.Close()
+}
+
+// aliyunError 阿里云错误响应
+type aliyunError struct {
+	status  int
+	code    string
+	message string
+}
+
+// aliyunStoreErrors 存储层错误对应的阿里云错误
+var aliyunStoreErrors = map[error]aliyunError{
+	errZoneNotFound:   {http.StatusBadRequest, "InvalidDomainName.NoExist", "The specified domain name does not exist. Refresh the page and try again."},
+	errRecordNotFound: {http.StatusBadRequest, "DomainRecordNotBelongToUser", "The DNS record does not belong to you."},
+	errDuplicate:      {http.StatusBadRequest, "DomainRecordDuplicate", "The DNS record already exists."},
+	errCNAMEConflict:  {http.StatusBadRequest, "DomainRecordConflict", "The DNS record conflicts with other records."},
+}
+
+// aliyunFaults 注入错误对应的阿里云错误
+var aliyunFaults = map[string]aliyunError{
+	FaultRateLimit: {http.StatusServiceUnavailable, "Throttling.User", "Request was denied due to user flow control."},
+	FaultAuth:      {http.StatusNotFound, "InvalidAccessKeyId.NotFound", "Specified access key is not found."},
+	FaultServer:    {http.StatusInternalServerError, "InternalError", "The request processing has failed due to some unknown error."},
+}
+
+// serveHTTP 处理阿里云RPC风格请求
+func (e *Aliyun) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := strings.ToUpper(randomHex(16))
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		e.writeError(w, requestID, aliyunError{http.StatusBadRequest, "InvalidParameter", "failed to read request body"})
+		return
+	}
+
+	if apiErr := e.authenticate(r, body); apiErr != nil {
+		e.writeError(w, requestID, *apiErr)
+		return
+	}
+	if kind := e.takeFault(); kind != "" {
+		e.writeError(w, requestID, aliyunFaults[kind])
+		return
+	}
+
+	params := r.URL.Query()
+	var result map[string]interface{}
+	var apiErr *aliyunError
+	switch action := r.Header.Get("x-acs-action"); action {
+	case "DescribeDomains":
+		result, apiErr = e.describeDomains(params)
+	case "DescribeDomainRecords":
+		result, apiErr = e.describeDomainRecords(params)
+	case "DescribeDomainRecordInfo":
+		result, apiErr = e.describeDomainRecordInfo(params)
+	case "AddDomainRecord":
+		result, apiErr = e.addDomainRecord(params)
+	case "UpdateDomainRecord":
+		result, apiErr = e.updateDomainRecord(params)
+	case "DeleteDomainRecord":
+		result, apiErr = e.deleteDomainRecord(params)
+	case "SetDomainRecordStatus":
+		result, apiErr = e.setDomainRecordStatus(params)
+	default:
+		apiErr = &aliyunError{http.StatusNotFound, "InvalidAction.NotFound", "Specified api is not found, please check your url and method: " + action}
+	}
+	if apiErr != nil {
+		e.writeError(w, requestID, *apiErr)
+		return
+	}
+
+	result["RequestId"] = requestID
+	writeJSON(w, http.StatusOK, result)
+}
+
+// authenticate 校验V3签名，与阿里云一样按签名头列表重新计算签名
+func (e *Aliyun) authenticate(r *http.Request, body []byte) *aliyunError {
+	if r.Method != http.MethodPost && r.Method != http.MethodGet {
+		return &aliyunError{http.StatusBadRequest, "UnsupportedHTTPMethod", "The specified HTTP method is not supported."}
+	}
+	if version := r.Header.Get("x-acs-version"); version != aliyunVersion {
+		return &aliyunError{http.StatusBadRequest, "InvalidVersion", "Specified parameter Version is not valid."}
+	}
+
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, aliyunSignatureAlgorithm+" ") {
+		return &aliyunError{http.StatusBadRequest, "IncompleteSignature", "The request signature does not conform to Aliyun standards."}
+	}
+	fields := parseAuthorizationFields(strings.TrimPrefix(authorization, aliyunSignatureAlgorithm+" "))
+	if fields["Credential"] != e.AccessKeyID {
+		return &aliyunError{http.StatusNotFound, "InvalidAccessKeyId.NotFound", "Specified access key is not found."}
+	}
+
+	date, err := time.Parse("2006-01-02T15:04:05Z", r.Header.Get("x-acs-date"))
+	if err != nil || time.Since(date).Abs() > aliyunMaxClockSkew {
+		return &aliyunError{http.StatusBadRequest, "InvalidTimeStamp.Expired", "Specified time stamp or date value is expired."}
+	}
+	if sha256Hex(body) != r.Header.Get("x-acs-content-sha256") {
+		return &aliyunError{http.StatusBadRequest, "SignatureDoesNotMatch", "Specified content hash is not matched with request body."}
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	if !containsString(signedHeaders, "host") || !containsString(signedHeaders, "x-acs-date") {
+		return &aliyunError{http.StatusBadRequest, "IncompleteSignature", "The request signature does not conform to Aliyun standards."}
+	}
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := r.Method + "\n" +
+		"/\n" +
+		canonicalQuery(r.URL.Query()) + "\n" +
+		canonicalHeaders.String() + "\n" +
+		fields["SignedHeaders"] + "\n" +
+		r.Header.Get("x-acs-content-sha256")
+	stringToSign := aliyunSignatureAlgorithm + "\n" + sha256Hex([]byte(canonicalRequest))
+	expected := hex.EncodeToString(hmacSHA256([]byte(e.AccessKeySecret), stringToSign))
+	if fields["Signature"] != expected {
+		return &aliyunError{http.StatusBadRequest, "SignatureDoesNotMatch", "Specified signature is not matched with our calculation."}
+	}
+
+	if !e.useNonce(r.Header.Get("x-acs-signature-nonce")) {
+		return &aliyunError{http.StatusBadRequest, "SignatureNonceUsed", "Specified signature nonce was used already."}
+	}
+	return nil
+}
+
+// describeDomains 分页查询域名列表
+func (e *Aliyun) describeDomains(params url.Values) (map[string]interface{}, *aliyunError) {
+	pageNumber, pageSize, apiErr := aliyunPage(params, 20, 100)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	domains := make([]map[string]interface{}, 0)
+	start, end := pageBounds(len(e.zones), (pageNumber-1)*pageSize, pageSize)
+	for _, z := range e.zones[start:end] {
+		domains = append(domains, map[string]interface{}{
+			"DomainId":    z.id,
+			"DomainName":  z.name,
+			"RecordCount": len(z.records),
+			"VersionCode": "mianfei",
+		})
+	}
+
+	return map[string]interface{}{
+		"TotalCount": len(e.zones),
+		"PageNumber": pageNumber,
+		"PageSize":   pageSize,
+		"Domains":    map[string]interface{}{"Domain": domains},
+	}, nil
+}
+
+// describeDomainRecords 分页查询域名记录，RRKeyWord为模糊匹配，TypeKeyWord为精确匹配
+func (e *Aliyun) describeDomainRecords(params url.Values) (map[string]interface{}, *aliyunError) {
+	if apiErr := aliyunRequire(params, "DomainName"); apiErr != nil {
+		return nil, apiErr
+	}
+	pageNumber, pageSize, apiErr := aliyunPage(params, 20, 500)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z := e.zoneByName(normalizeDomain(params.Get("DomainName")))
+	if z == nil {
+		return nil, aliyunStoreError(errZoneNotFound)
+	}
+
+	keyword := strings.ToLower(params.Get("RRKeyWord"))
+	recordType := strings.ToUpper(params.Get("TypeKeyWord"))
+	var matched []Record
+	for _, record := range z.records {
+		if keyword != "" && !strings.Contains(record.Name, keyword) {
+			continue
+		}
+		if recordType != "" && record.Type != recordType {
+			continue
+		}
+		matched = append(matched, record)
+	}
+
+	records := make([]map[string]interface{}, 0)
+	start, end := pageBounds(len(matched), (pageNumber-1)*pageSize, pageSize)
+	for _, record := range matched[start:end] {
+		records = append(records, aliyunRecord(z, record))
+	}
+
+	return map[string]interface{}{
+		"TotalCount":    len(matched),
+		"PageNumber":    pageNumber,
+		"PageSize":      pageSize,
+		"DomainRecords": map[string]interface{}{"Record": records},
+	}, nil
+}
+
+// describeDomainRecordInfo 查询单条记录
+func (e *Aliyun) describeDomainRecordInfo(params url.Values) (map[string]interface{}, *aliyunError) {
+	if apiErr := aliyunRequire(params, "RecordId"); apiErr != nil {
+		return nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z, index := e.findRecord(params.Get("RecordId"))
+	if z == nil {
+		return nil, aliyunStoreError(errRecordNotFound)
+	}
+	return aliyunRecord(z, z.records[index]), nil
+}
+
+// addDomainRecord 添加记录
+func (e *Aliyun) addDomainRecord(params url.Values) (map[string]interface{}, *aliyunError) {
+	if apiErr := aliyunRequire(params, "DomainName", "RR", "Type", "Value"); apiErr != nil {
+		return nil, apiErr
+	}
+	record, apiErr := aliyunRecordParams(params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z := e.zoneByName(normalizeDomain(params.Get("DomainName")))
+	if z == nil {
+		return nil, aliyunStoreError(errZoneNotFound)
+	}
+	created, err := e.create(z, record)
+	if err != nil {
+		return nil, aliyunStoreError(err)
+	}
+	return map[string]interface{}{"RecordId": created.ID}, nil
+}
+
+// updateDomainRecord 修改记录，与阿里云一样在记录内容没有变化时返回DomainRecordDuplicate
+func (e *Aliyun) updateDomainRecord(params url.Values) (map[string]interface{}, *aliyunError) {
+	if apiErr := aliyunRequire(params, "RecordId", "RR", "Type", "Value"); apiErr != nil {
+		return nil, apiErr
+	}
+	record, apiErr := aliyunRecordParams(params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	record.ID = params.Get("RecordId")
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z, index := e.findRecord(record.ID)
+	if z == nil {
+		return nil, aliyunStoreError(errRecordNotFound)
+	}
+	existing := z.records[index]
+	if existing.Name == normalizeName(record.Name) && existing.Type == strings.ToUpper(record.Type) &&
+		existing.Value == record.Value && existing.TTL == record.TTL && existing.Priority == record.Priority &&
+		existing.Line == record.Line {
+		return nil, aliyunStoreError(errDuplicate)
+	}
+	record.Disabled = existing.Disabled
+	if err := z.update(record); err != nil {
+		return nil, aliyunStoreError(err)
+	}
+	return map[string]interface{}{"RecordId": record.ID}, nil
+}
+
+// deleteDomainRecord 删除记录
+func (e *Aliyun) deleteDomainRecord(params url.Values) (map[string]interface{}, *aliyunError) {
+	if apiErr := aliyunRequire(params, "RecordId"); apiErr != nil {
+		return nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	recordID := params.Get("RecordId")
+	z, _ := e.findRecord(recordID)
+	if z == nil {
+		return nil, aliyunStoreError(errRecordNotFound)
+	}
+	if err := z.remove(recordID); err != nil {
+		return nil, aliyunStoreError(err)
+	}
+	return map[string]interface{}{"RecordId": recordID}, nil
+}
+
+// setDomainRecordStatus 暂停或启用记录，Status取值为Enable或Disable
+func (e *Aliyun) setDomainRecordStatus(params url.Values) (map[string]interface{}, *aliyunError) {
+	if apiErr := aliyunRequire(params, "RecordId", "Status"); apiErr != nil {
+		return nil, apiErr
+	}
+	status := strings.ToUpper(params.Get("Status"))
+	if status != "ENABLE" && status != "DISABLE" {
+		return nil, &aliyunError{http.StatusBadRequest, "InvalidStatus", "The specified status is invalid."}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	recordID := params.Get("RecordId")
+	z, index := e.findRecord(recordID)
+	if z == nil {
+		return nil, aliyunStoreError(errRecordNotFound)
+	}
+	z.records[index].Disabled = status == "DISABLE"
+	return map[string]interface{}{"RecordId": recordID, "Status": params.Get("Status")}, nil
+}
+
+// findRecord 在全部域名中查找记录，阿里云按记录ID操作时不需要指定域名，调用方需要持有锁
+func (e *Aliyun) findRecord(recordID string) (*zone, int) {
+	for _, z := range e.zones {
+		if index := z.find(recordID); index >= 0 {
+			return z, index
+		}
+	}
+	return nil, -1
+}
+
+// writeError 输出阿里云格式的错误响应
+func (e *Aliyun) writeError(w http.ResponseWriter, requestID string, apiErr aliyunError) {
+	writeJSON(w, apiErr.status, map[string]interface{}{
+		"RequestId": requestID,
+		"HostId":    "alidns.aliyuncs.com",
+		"Code":      apiErr.code,
+		"Message":   apiErr.message,
+	})
+}
+
+// aliyunStoreError 转换存储层错误
+func aliyunStoreError(err error) *aliyunError {
+	for storeErr, apiErr := range aliyunStoreErrors {
+		if errors.Is(err, storeErr) {
+			return &apiErr
+		}
+	}
+	return &aliyunError{http.StatusInternalServerError, "InternalError", err.Error()}
+}
+
+// aliyunRecord 转换为阿里云返回的记录格式
+func aliyunRecord(z *zone, record Record) map[string]interface{} {
+	result := map[string]interface{}{
+		"DomainName": z.name,
+		"RecordId":   record.ID,
+		"RR":         record.Name,
+		"Type":       record.Type,
+		"Value":      record.Value,
+		"TTL":        record.TTL,
+		"Line":       record.Line,
+		"Status":     recordStatus(record),
+		"Locked":     false,
+	}
+	if record.Type == "MX" || record.Type == "SRV" {
+		result["Priority"] = record.Priority
+	}
+	return result
+}
+
+// aliyunRecordParams 解析添加和修改记录的参数
+func aliyunRecordParams(params url.Values) (Record, *aliyunError) {
+	record := Record{
+		Name:  params.Get("RR"),
+		Type:  strings.ToUpper(params.Get("Type")),
+		Value: params.Get("Value"),
+		TTL:   600,
+		Line:  params.Get("Line"),
+	}
+	if record.Line == "" {
+		record.Line = "default"
+	}
+	if value := params.Get("TTL"); value != "" {
+		ttl, err := strconv.Atoi(value)
+		if err != nil || ttl < 1 || ttl > 86400 {
+			return Record{}, &aliyunError{http.StatusBadRequest, "InvalidTTL", "The specified TTL is invalid."}
+		}
+		record.TTL = ttl
+	}
+	if record.Type == "MX" {
+		record.Priority = 10
+		if value := params.Get("Priority"); value != "" {
+			priority, err := strconv.Atoi(value)
+			if err != nil || priority < 1 || priority > 50 {
+				return Record{}, &aliyunError{http.StatusBadRequest, "InvalidPriority", "The specified Priority is invalid."}
+			}
+			record.Priority = priority
+		}
+	}
+	return record, nil
+}
+
+// aliyunRequire 检查必填参数
+func aliyunRequire(params url.Values, names ...string) *aliyunError {
+	for _, name := range names {
+		if params.Get(name) == "" {
+			return &aliyunError{http.StatusBadRequest, "Missing" + name, name + " is mandatory for this action."}
+		}
+	}
+	return nil
+}
+
+// aliyunPage 解析PageNumber和PageSize参数
+func aliyunPage(params url.Values, defaultSize, maxSize int) (int, int, *aliyunError) {
+	pageNumber, pageSize := 1, defaultSize
+	if value := params.Get("PageNumber"); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 {
+			return 0, 0, &aliyunError{http.StatusBadRequest, "InvalidPageNumber", "The specified PageNumber is invalid."}
+		}
+		pageNumber = number
+	}
+	if value := params.Get("PageSize"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 || size > maxSize {
+			return 0, 0, &aliyunError{http.StatusBadRequest, "InvalidPageSize", fmt.Sprintf("The specified PageSize is invalid, the maximum is %d.", maxSize)}
+		}
+		pageSize = size
+	}
+	return pageNumber, pageSize, nil
+}
+
+// canonicalQuery 按参数名排序并以RFC 3986规则编码查询参数
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, percentEncode(key)+"="+percentEncode(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// percentEncode 按RFC 3986编码，只保留字母、数字和-_.~
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
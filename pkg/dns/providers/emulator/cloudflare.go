@@ -0,0 +1,584 @@
+package emulator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cloudflarePathPrefix CloudFlare API的路径前缀
+const cloudflarePathPrefix = "/client/v4"
+
+// Cloudflare CloudFlare v4 API模拟器
+// 支持API Token和Global API Key两种认证方式，错误响应的HTTP状态码和errors中的错误码与CloudFlare一致
+type Cloudflare struct {
+	*store
+	server *httptest.Server
+
+	Token  string
+	APIKey string
+	Email  string
+}
+
+// NewCloudflare 启动CloudFlare v4 API模拟器，凭据随机生成
+func NewCloudflare() *Cloudflare {
+	e := &Cloudflare{
+		store:  newStore(),
+		Token:  randomHex(20),
+		APIKey: randomHex(18),
+		Email:  "emulator@example.com",
+	}
+	e.server = httptest.NewServer(http.HandlerFunc(e.serveHTTP))
+	return e
+}
+
+// URL 模拟器的API地址，作为适配器的Endpoint
+func (e *Cloudflare) URL() string {
+	return e.server.URL + cloudflarePathPrefix
+}
+
+// Config 创建CloudFlare适配器使用的配置，使用API Token认证
+func (e *Cloudflare) Config() map[string]string {
+	return map[string]string{
+		"token":    e.Token,
+		"endpoint": e.URL(),
+	}
+}
+
+// Close 关闭模拟器
+func (e *Cloudflare) Close() {
+	e.server.Close()
+}
+
+// cloudflareError CloudFlare错误响应
+type cloudflareError struct {
+	status  int
+	code    int
+	message string
+}
+
+// cloudflareStoreErrors 存储层错误对应的CloudFlare错误
+var cloudflareStoreErrors = map[error]cloudflareError{
+	errZoneNotFound:   {http.StatusNotFound, 7003, "Could not route to /zones, perhaps your object identifier is invalid?"},
+	errRecordNotFound: {http.StatusNotFound, 81044, "Record does not exist."},
+	errDuplicate:      {http.StatusBadRequest, 81058, "An identical record already exists."},
+	errCNAMEConflict:  {http.StatusBadRequest, 81053, "An A, AAAA, or CNAME record with that host already exists."},
+}
+
+// cloudflareFaults 注入错误对应的CloudFlare错误
+var cloudflareFaults = map[string]cloudflareError{
+	FaultRateLimit: {http.StatusTooManyRequests, 971, "Please wait and consider throttling your request speed"},
+	FaultAuth:      {http.StatusForbidden, 10000, "Authentication error"},
+	FaultServer:    {http.StatusInternalServerError, 10001, "Internal server error"},
+}
+
+// cloudflareRecord CloudFlare记录的请求和响应格式
+type cloudflareRecord struct {
+	ID         string                 `json:"id"`
+	ZoneID     string                 `json:"zone_id"`
+	ZoneName   string                 `json:"zone_name"`
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	Content    string                 `json:"content"`
+	Proxiable  bool                   `json:"proxiable"`
+	Proxied    bool                   `json:"proxied"`
+	TTL        int                    `json:"ttl"`
+	Priority   *int                   `json:"priority,omitempty"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+	Comment    *string                `json:"comment"`
+	Tags       []string               `json:"tags"`
+	CreatedOn  string                 `json:"created_on"`
+	ModifiedOn string                 `json:"modified_on"`
+}
+
+// cloudflareRecordInput 创建和修改记录的请求体，指针字段为nil表示未提交
+type cloudflareRecordInput struct {
+	ID       string   `json:"id"`
+	Name     *string  `json:"name"`
+	Type     *string  `json:"type"`
+	Content  *string  `json:"content"`
+	TTL      *int     `json:"ttl"`
+	Priority *int     `json:"priority"`
+	Proxied  *bool    `json:"proxied"`
+	Comment  *string  `json:"comment"`
+	Tags     []string `json:"tags"`
+	Data     *struct {
+		Priority int    `json:"priority"`
+		Weight   int    `json:"weight"`
+		Port     int    `json:"port"`
+		Target   string `json:"target"`
+	} `json:"data"`
+}
+
+// serveHTTP 处理CloudFlare v4请求
+func (e *Cloudflare) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cf-Ray", randomHex(8)+"-EMU")
+
+	if apiErr := e.authenticate(r); apiErr != nil {
+		e.writeError(w, *apiErr)
+		return
+	}
+	if kind := e.takeFault(); kind != "" {
+		if kind == FaultRateLimit {
+			w.Header().Set("Retry-After", "1")
+		}
+		e.writeError(w, cloudflareFaults[kind])
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, cloudflarePathPrefix), "/")
+	segments := strings.Split(path, "/")
+
+	var result interface{}
+	var resultInfo map[string]int
+	var apiErr *cloudflareError
+	switch {
+	case path == "user" && r.Method == http.MethodGet:
+		result = map[string]interface{}{"id": "emulator-user", "email": e.Email}
+	case path == "zones" && r.Method == http.MethodGet:
+		result, resultInfo, apiErr = e.listZones(r)
+	case len(segments) == 3 && segments[0] == "zones" && segments[2] == "dns_records":
+		switch r.Method {
+		case http.MethodGet:
+			result, resultInfo, apiErr = e.listRecords(r, segments[1])
+		case http.MethodPost:
+			result, apiErr = e.createRecord(r, segments[1])
+		default:
+			apiErr = &cloudflareError{http.StatusMethodNotAllowed, 10405, "Method not allowed for this endpoint"}
+		}
+	case len(segments) == 4 && segments[0] == "zones" && segments[2] == "dns_records" && segments[3] == "batch":
+		if r.Method != http.MethodPost {
+			apiErr = &cloudflareError{http.StatusMethodNotAllowed, 10405, "Method not allowed for this endpoint"}
+			break
+		}
+		result, apiErr = e.batchRecords(r, segments[1])
+	case len(segments) == 4 && segments[0] == "zones" && segments[2] == "dns_records":
+		switch r.Method {
+		case http.MethodGet:
+			result, apiErr = e.getRecord(segments[1], segments[3])
+		case http.MethodPatch, http.MethodPut:
+			result, apiErr = e.updateRecord(r, segments[1], segments[3])
+		case http.MethodDelete:
+			result, apiErr = e.deleteRecord(segments[1], segments[3])
+		default:
+			apiErr = &cloudflareError{http.StatusMethodNotAllowed, 10405, "Method not allowed for this endpoint"}
+		}
+	default:
+		apiErr = &cloudflareError{http.StatusNotFound, 7000, "No route for that URI"}
+	}
+	if apiErr != nil {
+		e.writeError(w, *apiErr)
+		return
+	}
+
+	response := map[string]interface{}{
+		"success":  true,
+		"errors":   []interface{}{},
+		"messages": []interface{}{},
+		"result":   result,
+	}
+	if resultInfo != nil {
+		response["result_info"] = resultInfo
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// authenticate 校验API Token或Global API Key
+func (e *Cloudflare) authenticate(r *http.Request) *cloudflareError {
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		if authorization != "Bearer "+e.Token {
+			return &cloudflareError{http.StatusForbidden, 10000, "Authentication error"}
+		}
+		return nil
+	}
+
+	email, key := r.Header.Get("X-Auth-Email"), r.Header.Get("X-Auth-Key")
+	if email == "" || key == "" {
+		return &cloudflareError{http.StatusBadRequest, 9106, "Missing X-Auth-Key, X-Auth-Email or Authorization headers"}
+	}
+	if email != e.Email || key != e.APIKey {
+		return &cloudflareError{http.StatusForbidden, 9103, "Unknown X-Auth-Key or X-Auth-Email"}
+	}
+	return nil
+}
+
+// listZones 分页查询域名，支持按name精确筛选
+func (e *Cloudflare) listZones(r *http.Request) (interface{}, map[string]int, *cloudflareError) {
+	page, perPage, apiErr := cloudflarePage(r, 20, 50)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	name := normalizeDomain(r.URL.Query().Get("name"))
+	var matched []*zone
+	for _, z := range e.zones {
+		if name == "" || z.name == name {
+			matched = append(matched, z)
+		}
+	}
+
+	zones := make([]map[string]interface{}, 0)
+	start, end := pageBounds(len(matched), (page-1)*perPage, perPage)
+	for _, z := range matched[start:end] {
+		zones = append(zones, map[string]interface{}{
+			"id":     z.id,
+			"name":   z.name,
+			"status": "active",
+			"paused": false,
+			"type":   "full",
+		})
+	}
+	return zones, cloudflareResultInfo(page, perPage, len(zones), len(matched)), nil
+}
+
+// listRecords 分页查询记录，name和type为精确匹配
+func (e *Cloudflare) listRecords(r *http.Request, zoneID string) (interface{}, map[string]int, *cloudflareError) {
+	page, perPage, apiErr := cloudflarePage(r, 100, 5000)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z := e.zoneByID(zoneID)
+	if z == nil {
+		return nil, nil, cloudflareStoreError(errZoneNotFound)
+	}
+
+	query := r.URL.Query()
+	name := normalizeDomain(query.Get("name"))
+	recordType := strings.ToUpper(query.Get("type"))
+	var matched []Record
+	for _, record := range z.records {
+		if name != "" && cloudflareFQDN(record.Name, z.name) != name {
+			continue
+		}
+		if recordType != "" && record.Type != recordType {
+			continue
+		}
+		matched = append(matched, record)
+	}
+
+	records := make([]cloudflareRecord, 0)
+	start, end := pageBounds(len(matched), (page-1)*perPage, perPage)
+	for _, record := range matched[start:end] {
+		records = append(records, toCloudflareRecord(z, record))
+	}
+	return records, cloudflareResultInfo(page, perPage, len(records), len(matched)), nil
+}
+
+// getRecord 查询单条记录
+func (e *Cloudflare) getRecord(zoneID, recordID string) (interface{}, *cloudflareError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z := e.zoneByID(zoneID)
+	if z == nil {
+		return nil, cloudflareStoreError(errZoneNotFound)
+	}
+	index := z.find(recordID)
+	if index < 0 {
+		return nil, cloudflareStoreError(errRecordNotFound)
+	}
+	return toCloudflareRecord(z, z.records[index]), nil
+}
+
+// createRecord 创建记录
+func (e *Cloudflare) createRecord(r *http.Request, zoneID string) (interface{}, *cloudflareError) {
+	var input cloudflareRecordInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return nil, &cloudflareError{http.StatusBadRequest, 9207, "Request body is invalid."}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z := e.zoneByID(zoneID)
+	if z == nil {
+		return nil, cloudflareStoreError(errZoneNotFound)
+	}
+	record, apiErr := applyCloudflareInput(z, Record{TTL: 1}, input, true)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	created, err := e.create(z, record)
+	if err != nil {
+		return nil, cloudflareStoreError(err)
+	}
+	return toCloudflareRecord(z, created), nil
+}
+
+// updateRecord 修改记录，PATCH只修改提交的字段，PUT覆盖全部字段
+func (e *Cloudflare) updateRecord(r *http.Request, zoneID, recordID string) (interface{}, *cloudflareError) {
+	var input cloudflareRecordInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return nil, &cloudflareError{http.StatusBadRequest, 9207, "Request body is invalid."}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z := e.zoneByID(zoneID)
+	if z == nil {
+		return nil, cloudflareStoreError(errZoneNotFound)
+	}
+	index := z.find(recordID)
+	if index < 0 {
+		return nil, cloudflareStoreError(errRecordNotFound)
+	}
+
+	base := z.records[index]
+	if r.Method == http.MethodPut {
+		base = Record{ID: recordID, TTL: 1}
+	}
+	record, apiErr := applyCloudflareInput(z, base, input, r.Method == http.MethodPut)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if err := z.update(record); err != nil {
+		return nil, cloudflareStoreError(err)
+	}
+	return toCloudflareRecord(z, record), nil
+}
+
+// deleteRecord 删除记录
+func (e *Cloudflare) deleteRecord(zoneID, recordID string) (interface{}, *cloudflareError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z := e.zoneByID(zoneID)
+	if z == nil {
+		return nil, cloudflareStoreError(errZoneNotFound)
+	}
+	if err := z.remove(recordID); err != nil {
+		return nil, cloudflareStoreError(err)
+	}
+	return map[string]string{"id": recordID}, nil
+}
+
+// batchRecords 批量操作记录，与CloudFlare一样按deletes、posts的顺序执行，任一操作失败时全部回滚
+func (e *Cloudflare) batchRecords(r *http.Request, zoneID string) (interface{}, *cloudflareError) {
+	var input struct {
+		Deletes []cloudflareRecordInput `json:"deletes"`
+		Patches []cloudflareRecordInput `json:"patches"`
+		Puts    []cloudflareRecordInput `json:"puts"`
+		Posts   []cloudflareRecordInput `json:"posts"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return nil, &cloudflareError{http.StatusBadRequest, 9207, "Request body is invalid."}
+	}
+	if len(input.Patches) > 0 || len(input.Puts) > 0 {
+		return nil, &cloudflareError{http.StatusBadRequest, 1004, "The emulator only supports deletes and posts in batch requests."}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z := e.zoneByID(zoneID)
+	if z == nil {
+		return nil, cloudflareStoreError(errZoneNotFound)
+	}
+
+	snapshot := make([]Record, len(z.records))
+	copy(snapshot, z.records)
+	rollback := func(apiErr *cloudflareError) (interface{}, *cloudflareError) {
+		z.records = snapshot
+		return nil, apiErr
+	}
+
+	deleted := make([]cloudflareRecord, 0, len(input.Deletes))
+	for _, item := range input.Deletes {
+		index := z.find(item.ID)
+		if index < 0 {
+			return rollback(cloudflareStoreError(errRecordNotFound))
+		}
+		deleted = append(deleted, toCloudflareRecord(z, z.records[index]))
+		z.remove(item.ID)
+	}
+
+	posted := make([]cloudflareRecord, 0, len(input.Posts))
+	for _, item := range input.Posts {
+		record, apiErr := applyCloudflareInput(z, Record{TTL: 1}, item, true)
+		if apiErr != nil {
+			return rollback(apiErr)
+		}
+		created, err := e.create(z, record)
+		if err != nil {
+			return rollback(cloudflareStoreError(err))
+		}
+		posted = append(posted, toCloudflareRecord(z, created))
+	}
+
+	return map[string]interface{}{
+		"deletes": deleted,
+		"patches": []cloudflareRecord{},
+		"puts":    []cloudflareRecord{},
+		"posts":   posted,
+	}, nil
+}
+
+// writeError 输出CloudFlare格式的错误响应
+func (e *Cloudflare) writeError(w http.ResponseWriter, apiErr cloudflareError) {
+	writeJSON(w, apiErr.status, map[string]interface{}{
+		"success":  false,
+		"errors":   []map[string]interface{}{{"code": apiErr.code, "message": apiErr.message}},
+		"messages": []interface{}{},
+		"result":   nil,
+	})
+}
+
+// cloudflareStoreError 转换存储层错误
+func cloudflareStoreError(err error) *cloudflareError {
+	for storeErr, apiErr := range cloudflareStoreErrors {
+		if errors.Is(err, storeErr) {
+			return &apiErr
+		}
+	}
+	return &cloudflareError{http.StatusInternalServerError, 10001, err.Error()}
+}
+
+// applyCloudflareInput 将请求体中提交的字段应用到记录上，requireAll为true时name、type和content必填
+func applyCloudflareInput(z *zone, record Record, input cloudflareRecordInput, requireAll bool) (Record, *cloudflareError) {
+	if requireAll && (input.Name == nil || input.Type == nil || (input.Content == nil && input.Data == nil)) {
+		return Record{}, &cloudflareError{http.StatusBadRequest, 9000, "DNS record requires name, type and content."}
+	}
+
+	if input.Name != nil {
+		name := normalizeDomain(*input.Name)
+		switch {
+		case name == "@" || name == z.name:
+			record.Name = "@"
+		case strings.HasSuffix(name, "."+z.name):
+			record.Name = strings.TrimSuffix(name, "."+z.name)
+		default:
+			// CloudFlare接受相对名称，自动补全为完整域名
+			record.Name = name
+		}
+	}
+	if input.Type != nil {
+		record.Type = strings.ToUpper(*input.Type)
+	}
+	if input.Content != nil {
+		record.Value = *input.Content
+	}
+	if input.TTL != nil {
+		if *input.TTL != 1 && (*input.TTL < 60 || *input.TTL > 86400) {
+			return Record{}, &cloudflareError{http.StatusBadRequest, 9021, "TTL must be between 60 and 86400 seconds, or 1 for Automatic."}
+		}
+		record.TTL = *input.TTL
+	}
+	if input.Priority != nil {
+		record.Priority = *input.Priority
+	}
+	if input.Data != nil && record.Type == "SRV" {
+		record.Priority = input.Data.Priority
+		record.Weight = input.Data.Weight
+		record.Port = input.Data.Port
+		record.Value = fmt.Sprintf("%d %d %d %s", input.Data.Priority, input.Data.Weight, input.Data.Port, input.Data.Target)
+	}
+	if input.Proxied != nil {
+		if *input.Proxied && record.Type != "A" && record.Type != "AAAA" && record.Type != "CNAME" {
+			return Record{}, &cloudflareError{http.StatusBadRequest, 9004, "This record type cannot be proxied."}
+		}
+		record.Proxied = *input.Proxied
+	}
+	if input.Comment != nil {
+		record.Comment = *input.Comment
+	}
+	if input.Tags != nil {
+		record.Tags = append([]string(nil), input.Tags...)
+	}
+
+	if record.Type == "MX" && input.Priority == nil && requireAll {
+		return Record{}, &cloudflareError{http.StatusBadRequest, 9100, "MX records require a priority."}
+	}
+	return record, nil
+}
+
+// toCloudflareRecord 转换为CloudFlare返回的记录格式
+func toCloudflareRecord(z *zone, record Record) cloudflareRecord {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result := cloudflareRecord{
+		ID:         record.ID,
+		ZoneID:     z.id,
+		ZoneName:   z.name,
+		Name:       cloudflareFQDN(record.Name, z.name),
+		Type:       record.Type,
+		Content:    record.Value,
+		Proxiable:  record.Type == "A" || record.Type == "AAAA" || record.Type == "CNAME",
+		Proxied:    record.Proxied,
+		TTL:        record.TTL,
+		Tags:       record.Tags,
+		CreatedOn:  now,
+		ModifiedOn: now,
+	}
+	if result.Tags == nil {
+		result.Tags = []string{}
+	}
+	if record.Comment != "" {
+		comment := record.Comment
+		result.Comment = &comment
+	}
+	if record.Type == "MX" || record.Type == "SRV" {
+		priority := record.Priority
+		result.Priority = &priority
+	}
+	if record.Type == "SRV" {
+		result.Data = map[string]interface{}{
+			"priority": record.Priority,
+			"weight":   record.Weight,
+			"port":     record.Port,
+		}
+	}
+	return result
+}
+
+// cloudflareFQDN 将子域名转换为CloudFlare返回的完整域名
+func cloudflareFQDN(name, domain string) string {
+	if name == "@" || name == "" {
+		return domain
+	}
+	return name + "." + domain
+}
+
+// cloudflarePage 解析page和per_page参数
+func cloudflarePage(r *http.Request, defaultPerPage, maxPerPage int) (int, int, *cloudflareError) {
+	query := r.URL.Query()
+	page, perPage := 1, defaultPerPage
+	if value := query.Get("page"); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 {
+			return 0, 0, &cloudflareError{http.StatusBadRequest, 1004, "page must be a positive integer"}
+		}
+		page = number
+	}
+	if value := query.Get("per_page"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 || size > maxPerPage {
+			return 0, 0, &cloudflareError{http.StatusBadRequest, 1004, fmt.Sprintf("per_page must be between 1 and %d", maxPerPage)}
+		}
+		perPage = size
+	}
+	return page, perPage, nil
+}
+
+// cloudflareResultInfo 构建分页信息
+func cloudflareResultInfo(page, perPage, count, total int) map[string]int {
+	return map[string]int{
+		"page":        page,
+		"per_page":    perPage,
+		"count":       count,
+		"total_count": total,
+		"total_pages": int(math.Ceil(float64(total) / float64(perPage))),
+	}
+}
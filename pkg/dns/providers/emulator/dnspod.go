@@ -0,0 +1,554 @@
+package emulator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dnspodVersion            = "2021-03-23"
+	dnspodService            = "dnspod"
+	dnspodSignatureAlgorithm = "TC3-HMAC-SHA256"
+	// dnspodMaxClockSkew 请求时间戳与服务端时间允许的最大偏差
+	dnspodMaxClockSkew = 5 * time.Minute
+)
+
+// DNSPod 腾讯云DNSPod（2021-03-23）API模拟器
+// 校验TC3-HMAC-SHA256签名，与腾讯云一样业务错误也返回HTTP 200，错误信息在Response.Error中
+type DNSPod struct {
+	*store
+	server *httptest.Server
+
+	SecretID  string
+	SecretKey string
+}
+
+// NewDNSPod 启动腾讯云DNSPod API模拟器，凭据随机生成
+func NewDNSPod() *DNSPod {
+	e := &DNSPod{
+		store:     newStore(),
+		SecretID:  "AKID" + randomHex(16),
+		SecretKey: randomHex(16),
+	}
+	e.server = httptest.NewServer(http.HandlerFunc(e.serveHTTP))
+	return e
+}
+
+// URL 模拟器的API地址，作为适配器的Endpoint
+func (e *DNSPod) URL() string {
+	return e.server.URL
+}
+
+// Config 创建DNSPod适配器使用的配置
+func (e *DNSPod) Config() map[string]string {
+	return map[string]string{
+		"api_key":    e.SecretID,
+		"api_secret": e.SecretKey,
+		"endpoint":   e.URL(),
+	}
+}
+
+// Close 关闭模拟器
+func (e *DNSPod) Close() {
+	e.server.Close()
+}
+
+// dnspodError 腾讯云错误码和错误信息
+type dnspodError struct {
+	code    string
+	message string
+}
+
+// dnspodStoreErrors 存储层错误对应的DNSPod错误
+var dnspodStoreErrors = map[error]dnspodError{
+	errZoneNotFound:   {"ResourceNotFound.NoDataOfDomain", "域名不存在。"},
+	errRecordNotFound: {"InvalidParameter.RecordIdInvalid", "记录编号错误。"},
+	errDuplicate:      {"InvalidParameter.DomainRecordExist", "记录已经存在，无需再次添加。"},
+	errCNAMEConflict:  {"InvalidParameter.RecordConflict", "CNAME记录与同名的其他记录冲突。"},
+}
+
+// dnspodFaults 注入错误对应的DNSPod错误
+var dnspodFaults = map[string]dnspodError{
+	FaultRateLimit: {"RequestLimitExceeded", "请求的次数超过了频率限制。"},
+	FaultAuth:      {"AuthFailure.SecretIdNotFound", "密钥不存在。"},
+	FaultServer:    {"InternalError", "内部错误。"},
+}
+
+// dnspodRecord DNSPod记录列表中的记录格式
+type dnspodRecord struct {
+	RecordId      int    `json:"RecordId"`
+	Value         string `json:"Value"`
+	Status        string `json:"Status"`
+	UpdatedOn     string `json:"UpdatedOn"`
+	Name          string `json:"Name"`
+	Line          string `json:"Line"`
+	LineId        string `json:"LineId"`
+	Type          string `json:"Type"`
+	MonitorStatus string `json:"MonitorStatus"`
+	Remark        string `json:"Remark"`
+	TTL           int    `json:"TTL"`
+	MX            int    `json:"MX"`
+}
+
+// dnspodRequest DNSPod接口的请求参数，只包含模拟器使用的字段
+type dnspodRequest struct {
+	Domain     string `json:"Domain"`
+	DomainId   int    `json:"DomainId"`
+	RecordId   int    `json:"RecordId"`
+	SubDomain  string `json:"SubDomain"`
+	Subdomain  string `json:"Subdomain"`
+	RecordType string `json:"RecordType"`
+	RecordLine string `json:"RecordLine"`
+	Value      string `json:"Value"`
+	TTL        int    `json:"TTL"`
+	MX         int    `json:"MX"`
+	Status     string `json:"Status"`
+	Offset     int    `json:"Offset"`
+	Limit      int    `json:"Limit"`
+}
+
+// serveHTTP 处理腾讯云API 3.0请求
+func (e *DNSPod) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := dnspodRequestID()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		e.writeError(w, requestID, dnspodError{"InvalidParameter", "读取请求体失败。"})
+		return
+	}
+
+	if apiErr := e.authenticate(r, body); apiErr != nil {
+		e.writeError(w, requestID, *apiErr)
+		return
+	}
+	if kind := e.takeFault(); kind != "" {
+		e.writeError(w, requestID, dnspodFaults[kind])
+		return
+	}
+
+	var req dnspodRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		e.writeError(w, requestID, dnspodError{"InvalidParameter", "请求体不是有效的JSON。"})
+		return
+	}
+
+	var result map[string]interface{}
+	var apiErr *dnspodError
+	switch action := r.Header.Get("X-TC-Action"); action {
+	case "DescribeDomainList":
+		result, apiErr = e.describeDomainList(req)
+	case "DescribeRecordList":
+		result, apiErr = e.describeRecordList(req)
+	case "DescribeRecord":
+		result, apiErr = e.describeRecord(req)
+	case "CreateRecord":
+		result, apiErr = e.createRecord(req)
+	case "ModifyRecord":
+		result, apiErr = e.modifyRecord(req)
+	case "DeleteRecord":
+		result, apiErr = e.deleteRecord(req)
+	case "ModifyRecordStatus":
+		result, apiErr = e.modifyRecordStatus(req)
+	default:
+		apiErr = &dnspodError{"InvalidAction", "接口`" + action + "`不存在。"}
+	}
+	if apiErr != nil {
+		e.writeError(w, requestID, *apiErr)
+		return
+	}
+
+	result["RequestId"] = requestID
+	writeJSON(w, http.StatusOK, map[string]interface{}{"Response": result})
+}
+
+// authenticate 校验TC3-HMAC-SHA256签名
+func (e *DNSPod) authenticate(r *http.Request, body []byte) *dnspodError {
+	if r.Method != http.MethodPost {
+		return &dnspodError{"UnsupportedOperation", "仅支持POST请求。"}
+	}
+	if version := r.Header.Get("X-TC-Version"); version != dnspodVersion {
+		return &dnspodError{"InvalidParameterValue", "不支持的接口版本: " + version}
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get("X-TC-Timestamp"), 10, 64)
+	if err != nil {
+		return &dnspodError{"MissingParameter", "缺少X-TC-Timestamp请求头。"}
+	}
+	if time.Since(time.Unix(timestamp, 0)).Abs() > dnspodMaxClockSkew {
+		return &dnspodError{"AuthFailure.SignatureExpire", "签名过期。"}
+	}
+
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, dnspodSignatureAlgorithm+" ") {
+		return &dnspodError{"AuthFailure.InvalidAuthorization", "Authorization请求头格式不正确。"}
+	}
+	fields := parseAuthorizationFields(strings.TrimPrefix(authorization, dnspodSignatureAlgorithm+" "))
+
+	// Credential格式为SecretId/Date/Service/tc3_request
+	credential := strings.Split(fields["Credential"], "/")
+	date := time.Unix(timestamp, 0).UTC().Format("2006-01-02")
+	if len(credential) != 4 || credential[2] != dnspodService || credential[3] != "tc3_request" {
+		return &dnspodError{"AuthFailure.InvalidAuthorization", "Authorization请求头格式不正确。"}
+	}
+	if credential[0] != e.SecretID {
+		return &dnspodError{"AuthFailure.SecretIdNotFound", "密钥不存在。"}
+	}
+	if credential[1] != date {
+		return &dnspodError{"AuthFailure.SignatureFailure", "凭证日期与请求时间戳不一致。"}
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	if !containsString(signedHeaders, "content-type") || !containsString(signedHeaders, "host") {
+		return &dnspodError{"AuthFailure.InvalidAuthorization", "签名必须包含content-type和host请求头。"}
+	}
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.ToLower(strings.TrimSpace(value)) + "\n")
+	}
+
+	canonicalRequest := r.Method + "\n" +
+		"/\n" +
+		r.URL.RawQuery + "\n" +
+		canonicalHeaders.String() + "\n" +
+		fields["SignedHeaders"] + "\n" +
+		sha256Hex(body)
+	credentialScope := date + "/" + dnspodService + "/tc3_request"
+	stringToSign := dnspodSignatureAlgorithm + "\n" +
+		strconv.FormatInt(timestamp, 10) + "\n" +
+		credentialScope + "\n" +
+		sha256Hex([]byte(canonicalRequest))
+
+	secretDate := hmacSHA256([]byte("TC3"+e.SecretKey), date)
+	secretService := hmacSHA256(secretDate, dnspodService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	if fields["Signature"] != hex.EncodeToString(hmacSHA256(secretSigning, stringToSign)) {
+		return &dnspodError{"AuthFailure.SignatureFailure", "请求签名验证失败，请检查您的签名计算是否正确。"}
+	}
+	return nil
+}
+
+// describeDomainList 分页查询域名列表
+func (e *DNSPod) describeDomainList(req dnspodRequest) (map[string]interface{}, *dnspodError) {
+	limit, apiErr := dnspodLimit(req, 20, 3000)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	domains := make([]map[string]interface{}, 0)
+	start, end := pageBounds(len(e.zones), req.Offset, limit)
+	for _, z := range e.zones[start:end] {
+		id, _ := strconv.Atoi(z.id)
+		domains = append(domains, map[string]interface{}{
+			"DomainId":    id,
+			"Name":        z.name,
+			"Status":      "ENABLE",
+			"TTL":         600,
+			"RecordCount": len(z.records),
+			"Grade":       "DP_FREE",
+			"DNSStatus":   "",
+		})
+	}
+
+	return map[string]interface{}{
+		"DomainCountInfo": map[string]interface{}{
+			"AllTotal":    len(e.zones),
+			"DomainTotal": len(e.zones),
+		},
+		"DomainList": domains,
+	}, nil
+}
+
+// describeRecordList 分页查询域名记录，与DNSPod一样没有匹配的记录时返回ResourceNotFound.NoDataOfRecord
+func (e *DNSPod) describeRecordList(req dnspodRequest) (map[string]interface{}, *dnspodError) {
+	limit, apiErr := dnspodLimit(req, 100, 3000)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z, apiErr := e.zone(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	subdomain := strings.ToLower(req.Subdomain)
+	recordType := strings.ToUpper(req.RecordType)
+	var matched []Record
+	for _, record := range z.records {
+		if subdomain != "" && record.Name != subdomain {
+			continue
+		}
+		if recordType != "" && record.Type != recordType {
+			continue
+		}
+		matched = append(matched, record)
+	}
+	if len(matched) == 0 {
+		return nil, &dnspodError{"ResourceNotFound.NoDataOfRecord", "记录列表为空。"}
+	}
+
+	records := make([]dnspodRecord, 0)
+	start, end := pageBounds(len(matched), req.Offset, limit)
+	for _, record := range matched[start:end] {
+		records = append(records, toDNSPodRecord(record))
+	}
+
+	return map[string]interface{}{
+		"RecordCountInfo": map[string]interface{}{
+			"SubdomainCount": len(matched),
+			"ListCount":      len(records),
+			"TotalCount":     len(matched),
+		},
+		"RecordList": records,
+	}, nil
+}
+
+// describeRecord 查询单条记录
+func (e *DNSPod) describeRecord(req dnspodRequest) (map[string]interface{}, *dnspodError) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z, apiErr := e.zone(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	index := z.find(strconv.Itoa(req.RecordId))
+	if index < 0 {
+		return nil, dnspodStoreError(errRecordNotFound)
+	}
+
+	record := toDNSPodRecord(z.records[index])
+	enabled := 1
+	if z.records[index].Disabled {
+		enabled = 0
+	}
+	return map[string]interface{}{
+		"RecordInfo": map[string]interface{}{
+			"Id":         record.RecordId,
+			"SubDomain":  record.Name,
+			"RecordType": record.Type,
+			"RecordLine": record.Line,
+			"Value":      record.Value,
+			"MX":         record.MX,
+			"TTL":        record.TTL,
+			"Enabled":    enabled,
+			"DomainId":   req.DomainId,
+		},
+	}, nil
+}
+
+// createRecord 添加记录，RecordLine为必填参数
+func (e *DNSPod) createRecord(req dnspodRequest) (map[string]interface{}, *dnspodError) {
+	record, apiErr := dnspodRecordParams(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z, apiErr := e.zone(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	created, err := e.create(z, record)
+	if err != nil {
+		return nil, dnspodStoreError(err)
+	}
+
+	id, _ := strconv.Atoi(created.ID)
+	return map[string]interface{}{"RecordId": id}, nil
+}
+
+// modifyRecord 修改记录
+func (e *DNSPod) modifyRecord(req dnspodRequest) (map[string]interface{}, *dnspodError) {
+	if req.RecordId == 0 {
+		return nil, &dnspodError{"MissingParameter", "缺少参数RecordId。"}
+	}
+	record, apiErr := dnspodRecordParams(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	record.ID = strconv.Itoa(req.RecordId)
+	// 与DNSPod一致，未指定Status时修改后的记录为启用状态
+	record.Disabled = req.Status == "DISABLE"
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z, apiErr := e.zone(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if err := z.update(record); err != nil {
+		return nil, dnspodStoreError(err)
+	}
+	return map[string]interface{}{"RecordId": req.RecordId}, nil
+}
+
+// modifyRecordStatus 暂停或启用记录，Status取值为ENABLE或DISABLE
+func (e *DNSPod) modifyRecordStatus(req dnspodRequest) (map[string]interface{}, *dnspodError) {
+	if req.RecordId == 0 {
+		return nil, &dnspodError{"MissingParameter", "缺少参数RecordId。"}
+	}
+	if req.Status != "ENABLE" && req.Status != "DISABLE" {
+		return nil, &dnspodError{"InvalidParameterValue.RecordStatusInvalid", "记录状态不正确。"}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z, apiErr := e.zone(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	index := z.find(strconv.Itoa(req.RecordId))
+	if index < 0 {
+		return nil, dnspodStoreError(errRecordNotFound)
+	}
+	z.records[index].Disabled = req.Status == "DISABLE"
+	return map[string]interface{}{"RecordId": req.RecordId}, nil
+}
+
+// deleteRecord 删除记录
+func (e *DNSPod) deleteRecord(req dnspodRequest) (map[string]interface{}, *dnspodError) {
+	if req.RecordId == 0 {
+		return nil, &dnspodError{"MissingParameter", "缺少参数RecordId。"}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	z, apiErr := e.zone(req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if err := z.remove(strconv.Itoa(req.RecordId)); err != nil {
+		return nil, dnspodStoreError(err)
+	}
+	return map[string]interface{}{}, nil
+}
+
+// zone 按Domain和DomainId查找域名，同时指定时DomainId优先且必须与Domain一致，调用方需要持有锁
+func (e *DNSPod) zone(req dnspodRequest) (*zone, *dnspodError) {
+	if req.Domain == "" && req.DomainId == 0 {
+		return nil, &dnspodError{"MissingParameter", "缺少参数Domain。"}
+	}
+
+	var z *zone
+	if req.DomainId != 0 {
+		z = e.zoneByID(strconv.Itoa(req.DomainId))
+		if z != nil && req.Domain != "" && z.name != normalizeDomain(req.Domain) {
+			return nil, &dnspodError{"InvalidParameter.DomainIdInvalid", "域名编号与域名不一致。"}
+		}
+	} else {
+		z = e.zoneByName(normalizeDomain(req.Domain))
+	}
+	if z == nil {
+		return nil, dnspodStoreError(errZoneNotFound)
+	}
+	return z, nil
+}
+
+// writeError 输出腾讯云格式的错误响应，HTTP状态码为200
+func (e *DNSPod) writeError(w http.ResponseWriter, requestID string, apiErr dnspodError) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"Response": map[string]interface{}{
+			"Error": map[string]string{
+				"Code":    apiErr.code,
+				"Message": apiErr.message,
+			},
+			"RequestId": requestID,
+		},
+	})
+}
+
+// dnspodStoreError 转换存储层错误
+func dnspodStoreError(err error) *dnspodError {
+	for storeErr, apiErr := range dnspodStoreErrors {
+		if errors.Is(err, storeErr) {
+			return &apiErr
+		}
+	}
+	return &dnspodError{"InternalError", err.Error()}
+}
+
+// dnspodRecordParams 解析添加和修改记录的参数
+func dnspodRecordParams(req dnspodRequest) (Record, *dnspodError) {
+	for name, value := range map[string]string{"RecordType": req.RecordType, "RecordLine": req.RecordLine, "Value": req.Value} {
+		if value == "" {
+			return Record{}, &dnspodError{"MissingParameter", "缺少参数" + name + "。"}
+		}
+	}
+
+	record := Record{
+		Name:  req.SubDomain,
+		Type:  strings.ToUpper(req.RecordType),
+		Value: req.Value,
+		TTL:   req.TTL,
+		Line:  req.RecordLine,
+	}
+	if record.TTL == 0 {
+		record.TTL = 600
+	}
+	if record.TTL < 1 || record.TTL > 604800 {
+		return Record{}, &dnspodError{"InvalidParameter.InvalidTTL", "TTL值不正确。"}
+	}
+	if record.Type == "MX" {
+		if req.MX < 1 || req.MX > 20 {
+			return Record{}, &dnspodError{"InvalidParameter.MxInvalid", "MX优先级不正确，范围为1-20。"}
+		}
+		record.Priority = req.MX
+	}
+	return record, nil
+}
+
+// dnspodLimit 解析Limit参数
+func dnspodLimit(req dnspodRequest, defaultLimit, maxLimit int) (int, *dnspodError) {
+	if req.Offset < 0 || req.Limit < 0 || req.Limit > maxLimit {
+		return 0, &dnspodError{"InvalidParameterValue.LimitInvalid", "分页参数不正确。"}
+	}
+	if req.Limit == 0 {
+		return defaultLimit, nil
+	}
+	return req.Limit, nil
+}
+
+// toDNSPodRecord 转换为DNSPod返回的记录格式
+func toDNSPodRecord(record Record) dnspodRecord {
+	id, _ := strconv.Atoi(record.ID)
+	return dnspodRecord{
+		RecordId:  id,
+		Value:     record.Value,
+		Status:    recordStatus(record),
+		UpdatedOn: time.Now().Format("2006-01-02 15:04:05"),
+		Name:      record.Name,
+		Line:      record.Line,
+		LineId:    "0",
+		Type:      record.Type,
+		Remark:    "",
+		TTL:       record.TTL,
+		MX:        record.Priority,
+	}
+}
+
+// dnspodRequestID 生成UUID格式的请求ID
+func dnspodRequestID() string {
+	id := randomHex(16)
+	return id[0:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:32]
+}
@@ -0,0 +1,332 @@
+// Package emulator 提供基于httptest的DNS服务商API模拟器，用于在不访问真实服务商的情况下测试适配器
+//
+// 模拟器实现了CloudFlare v4、阿里云云解析和腾讯云DNSPod接口的请求签名校验、分页和错误响应，
+// 将适配器的Endpoint指向模拟器的URL即可使用。模拟器只保存在内存中，关闭后数据丢失。
+package emulator
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// 可注入的错误类型，与模拟DNS服务商的failure_error取值一致
+const (
+	FaultRateLimit = "rate_limit" // 请求频率超限
+	FaultAuth      = "auth"       // 凭据无效
+	FaultServer    = "server"     // 服务商内部错误
+)
+
+// 存储层错误，由各模拟器转换为对应服务商的错误码
+var (
+	errZoneNotFound   = errors.New("zone not found")
+	errRecordNotFound = errors.New("record not found")
+	errDuplicate      = errors.New("record already exists")
+	errCNAMEConflict  = errors.New("cname conflicts with other records")
+)
+
+// Record 模拟器中保存的DNS记录
+type Record struct {
+	ID       string
+	Name     string // 子域名，主域名为"@"
+	Type     string
+	Value    string
+	TTL      int
+	Priority int
+	Weight   int
+	Port     int
+	Line     string
+	Proxied  bool
+	Comment  string
+	Tags     []string
+	Disabled bool // 记录已暂停，阿里云和DNSPod支持
+}
+
+// zone 模拟器中托管的域名
+type zone struct {
+	id      string
+	name    string
+	records []Record
+}
+
+// store 模拟器的内存数据，各服务商模拟器共用记录冲突检查和错误注入逻辑
+type store struct {
+	mu     sync.Mutex
+	zones  []*zone
+	nextID int
+	faults []string
+	nonces map[string]bool
+}
+
+// newStore 创建空的内存数据
+func newStore() *store {
+	return &store{
+		nextID: 100000,
+		nonces: make(map[string]bool),
+	}
+}
+
+// AddZone 添加托管域名并返回域名ID，域名已存在时返回已有的ID
+func (s *store) AddZone(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name = normalizeDomain(name)
+	if z := s.zoneByName(name); z != nil {
+		return z.id
+	}
+	z := &zone{id: s.newID(), name: name}
+	s.zones = append(s.zones, z)
+	return z.id
+}
+
+// SeedRecords 直接写入指定数量的TXT记录，用于检查适配器的分页处理
+func (s *store) SeedRecords(domain string, count int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z := s.zoneByName(normalizeDomain(domain))
+	if z == nil {
+		return fmt.Errorf("%w: %s", errZoneNotFound, domain)
+	}
+	for i := 0; i < count; i++ {
+		id := s.newID()
+		z.records = append(z.records, Record{
+			ID:    id,
+			Name:  "seed-" + id,
+			Type:  "TXT",
+			Value: "seed",
+			TTL:   600,
+		})
+	}
+	return nil
+}
+
+// Records 获取域名下的全部记录
+func (s *store) Records(domain string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z := s.zoneByName(normalizeDomain(domain))
+	if z == nil {
+		return nil
+	}
+	records := make([]Record, len(z.records))
+	copy(records, z.records)
+	return records
+}
+
+// InjectFault 使下一次请求返回指定类型的错误，多次调用时按顺序依次生效
+func (s *store) InjectFault(kind string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, kind)
+}
+
+// takeFault 取出下一个待返回的错误，没有时返回空字符串
+func (s *store) takeFault() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.faults) == 0 {
+		return ""
+	}
+	kind := s.faults[0]
+	s.faults = s.faults[1:]
+	return kind
+}
+
+// useNonce 记录请求使用的随机串，重复使用时返回false
+func (s *store) useNonce(nonce string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nonces[nonce] {
+		return false
+	}
+	s.nonces[nonce] = true
+	return true
+}
+
+// newID 生成递增的ID，调用方需要持有锁
+func (s *store) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%d", s.nextID)
+}
+
+// zoneByName 按名称查找域名，调用方需要持有锁
+func (s *store) zoneByName(name string) *zone {
+	for _, z := range s.zones {
+		if z.name == name {
+			return z
+		}
+	}
+	return nil
+}
+
+// zoneByID 按ID查找域名，调用方需要持有锁
+func (s *store) zoneByID(id string) *zone {
+	for _, z := range s.zones {
+		if z.id == id {
+			return z
+		}
+	}
+	return nil
+}
+
+// create 校验冲突后添加记录，调用方需要持有锁
+func (s *store) create(z *zone, record Record) (Record, error) {
+	record.Name = normalizeName(record.Name)
+	record.Type = strings.ToUpper(record.Type)
+	if err := z.check(record, ""); err != nil {
+		return Record{}, err
+	}
+	record.ID = s.newID()
+	z.records = append(z.records, record)
+	return record, nil
+}
+
+// find 按ID查找记录的下标，不存在时返回-1
+func (z *zone) find(id string) int {
+	for i, record := range z.records {
+		if record.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// update 校验冲突后替换记录，调用方需要持有锁
+func (z *zone) update(record Record) error {
+	index := z.find(record.ID)
+	if index < 0 {
+		return errRecordNotFound
+	}
+	record.Name = normalizeName(record.Name)
+	record.Type = strings.ToUpper(record.Type)
+	if err := z.check(record, record.ID); err != nil {
+		return err
+	}
+	z.records[index] = record
+	return nil
+}
+
+// remove 删除记录，调用方需要持有锁
+func (z *zone) remove(id string) error {
+	index := z.find(id)
+	if index < 0 {
+		return errRecordNotFound
+	}
+	z.records = append(z.records[:index], z.records[index+1:]...)
+	return nil
+}
+
+// check 检查记录是否与已有记录重复，或违反CNAME不能与同名其他记录共存的规则
+func (z *zone) check(record Record, excludeID string) error {
+	for _, existing := range z.records {
+		if existing.ID == excludeID || !strings.EqualFold(existing.Name, record.Name) {
+			continue
+		}
+		if existing.Type == record.Type && existing.Value == record.Value && existing.Line == record.Line {
+			return errDuplicate
+		}
+		if (existing.Type == "CNAME") != (record.Type == "CNAME") {
+			return errCNAMEConflict
+		}
+	}
+	return nil
+}
+
+// recordStatus 阿里云和DNSPod格式的记录状态
+func recordStatus(record Record) string {
+	if record.Disabled {
+		return "DISABLE"
+	}
+	return "ENABLE"
+}
+
+// normalizeDomain 规范化域名
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+}
+
+// normalizeName 规范化子域名，空子域名视为主域名
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+// randomHex 生成指定字节数的随机十六进制字符串，用于生成凭据和请求ID
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("emulator: 生成随机数失败: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// sha256Hex 计算SHA256哈希值并返回十六进制字符串
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 计算HMAC-SHA256
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// parseAuthorizationFields 解析Authorization请求头中以逗号分隔的key=value字段
+func parseAuthorizationFields(value string) map[string]string {
+	fields := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok {
+			fields[key] = val
+		}
+	}
+	return fields
+}
+
+// containsString 判断切片中是否包含指定字符串
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// pageBounds 计算分页的起止下标
+func pageBounds(total, offset, limit int) (int, int) {
+	start := offset
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// writeJSON 输出JSON响应
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,177 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 服务商错误分类，适配器返回的错误可以通过errors.Is判断分类
+var (
+	// ErrAuthFailed 凭据无效、签名错误或权限不足
+	ErrAuthFailed = errors.New("服务商认证失败")
+	// ErrZoneNotFound 域名未托管在服务商账号下
+	ErrZoneNotFound = errors.New("域名不存在")
+	// ErrRecordNotFound 记录不存在
+	ErrRecordNotFound = errors.New("记录不存在")
+	// ErrRecordConflict 记录已存在或与已有记录冲突
+	ErrRecordConflict = errors.New("记录已存在")
+	// ErrRateLimited 请求频率超过服务商限制
+	ErrRateLimited = errors.New("请求频率超限")
+	// ErrQuotaExceeded 记录数量等配额不足
+	ErrQuotaExceeded = errors.New("服务商配额不足")
+	// ErrUnsupported 服务商不支持该操作，调用方可以改用其他方式完成
+	ErrUnsupported = errors.New("服务商不支持该操作")
+)
+
+// ProviderError 服务商接口返回的错误，保留服务商的原始错误码和请求ID
+type ProviderError struct {
+	Provider   string        // 服务商名称，用于错误信息
+	Kind       error         // 错误分类，无法归类时为nil
+	Code       string        // 服务商原始错误码
+	Message    string        // 服务商返回的错误信息
+	RequestID  string        // 服务商返回的请求ID
+	StatusCode int           // HTTP状态码，非HTTP接口为0
+	RetryAfter time.Duration // 服务商要求的重试等待时间，未指定时为0
+	Temporary  bool          // 是否为服务商内部错误等临时错误
+}
+
+// Error 实现error接口
+func (e *ProviderError) Error() string {
+	if e.Code == "" && e.StatusCode != 0 {
+		return fmt.Sprintf("API请求失败，状态码: %d, 响应: %s", e.StatusCode, e.Message)
+	}
+	message := fmt.Sprintf("%s API错误: %s - %s", e.Provider, e.Code, e.Message)
+	if e.RequestID != "" {
+		message += fmt.Sprintf(" (RequestId: %s)", e.RequestID)
+	}
+	return message
+}
+
+// Unwrap 返回错误分类，使errors.Is可以匹配分类错误
+func (e *ProviderError) Unwrap() error {
+	return e.Kind
+}
+
+// Retryable 是否可以重试
+func (e *ProviderError) Retryable() bool {
+	return e.Kind == ErrRateLimited || e.Temporary || e.StatusCode >= 500
+}
+
+// newHTTPError 根据HTTP响应构建服务商错误，分类优先使用错误码，其次使用HTTP状态码
+func newHTTPError(provider string, resp *http.Response, code, message, requestID string) *ProviderError {
+	kind := classifyErrorCode(code)
+	if kind == nil {
+		kind = classifyStatusCode(resp.StatusCode)
+	}
+
+	return &ProviderError{
+		Provider:   provider,
+		Kind:       kind,
+		Code:       code,
+		Message:    message,
+		RequestID:  requestID,
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// newStatusError 根据HTTP状态码构建服务商错误，用于无法解析出错误码的响应
+func newStatusError(provider string, resp *http.Response, body string) *ProviderError {
+	return newHTTPError(provider, resp, "", body, "")
+}
+
+// classifyStatusCode 根据HTTP状态码判断错误分类
+func classifyStatusCode(statusCode int) error {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuthFailed
+	case http.StatusConflict:
+		return ErrRecordConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// errorCodeKinds 各服务商错误码中表示错误分类的关键字，按顺序匹配
+// 例如RequestLimitExceeded需要先于LimitExceeded匹配为限流
+var errorCodeKinds = []struct {
+	kind     error
+	keywords []string
+}{
+	{ErrRateLimited, []string{"Throttling", "RequestLimitExceeded", "FlowLimitExceeded", "RateLimit", "TooManyRequests", "PriorRequestNotComplete"}},
+	{ErrAuthFailed, []string{"AuthFailure", "Unauthorized", "AccessDenied", "Forbidden", "SignatureDoesNotMatch", "IncompleteSignature", "InvalidAccessKey", "InvalidClientTokenId", "InvalidSecurityToken", "InvalidCredential"}},
+	{ErrQuotaExceeded, []string{"QuotaExceeded", "LimitExceeded", "LimitsExceeded", "TooManyResourceRecords"}},
+	{ErrZoneNotFound, []string{"NoSuchHostedZone", "ZoneNotFound", "DomainNotExist", "DomainNotFound", "NoDataOfDomain", "DomainName.NoExist"}},
+	{ErrRecordNotFound, []string{"RecordNotFound", "NoSuchRecord", "NoDataOfRecord", "RecordIdInvalid", "DomainRecordNotBelongToUser"}},
+	{ErrRecordConflict, []string{"Duplicate", "AlreadyExist", "RecordExist", "Conflict"}},
+}
+
+// classifyErrorCode 根据服务商错误码判断错误分类，无法判断时返回nil
+func classifyErrorCode(code string) error {
+	if code == "" {
+		return nil
+	}
+	for _, entry := range errorCodeKinds {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(code, keyword) {
+				return entry.kind
+			}
+		}
+	}
+	return nil
+}
+
+// parseRetryAfter 解析Retry-After响应头，支持秒数和HTTP日期两种格式
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(at); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// IsRetryable 判断错误是否可以重试：限流、服务商临时错误和网络超时可以重试，
+// 认证失败、记录冲突等确定性错误以及请求被取消不重试
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Retryable()
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// RetryAfter 返回服务商要求的重试等待时间或熔断剩余时间，未指定时返回0
+func RetryAfter(err error) time.Duration {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.RetryAfter
+	}
+	var openErr *CircuitOpenError
+	if errors.As(err, &openErr) {
+		return openErr.RetryAfter
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClassifyErrorCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want error
+	}{
+		{"", nil},
+		{"Throttling.User", ErrRateLimited},
+		{"RequestLimitExceeded", ErrRateLimited},
+		{"LimitExceeded.RecordQuota", ErrQuotaExceeded},
+		{"AuthFailure.SignatureFailure", ErrAuthFailed},
+		{"InvalidAccessKeyId.NotFound", ErrAuthFailed},
+		{"SignatureDoesNotMatch", ErrAuthFailed},
+		{"InvalidDomainName.NoExist", ErrZoneNotFound},
+		{"DomainName.NoExist", ErrZoneNotFound},
+		{"NoSuchHostedZone", ErrZoneNotFound},
+		{"ResourceNotFound.NoDataOfRecord", ErrRecordNotFound},
+		{"DomainRecordNotBelongToUser", ErrRecordNotFound},
+		{"DomainRecordDuplicate", ErrRecordConflict},
+		{"InvalidParameter.RecordExist", ErrRecordConflict},
+		{"InternalError", nil},
+	}
+	for _, tt := range tests {
+		if got := classifyErrorCode(tt.code); got != tt.want {
+			t.Errorf("classifyErrorCode(%q) = %v，期望%v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestNewHTTPErrorKind(t *testing.T) {
+	tests := []struct {
+		status    int
+		code      string
+		want      error
+		retryable bool
+	}{
+		// 错误码优先于HTTP状态码
+		{http.StatusBadRequest, "DomainRecordDuplicate", ErrRecordConflict, false},
+		{http.StatusForbidden, "Throttling", ErrRateLimited, true},
+		{http.StatusUnauthorized, "", ErrAuthFailed, false},
+		{http.StatusForbidden, "", ErrAuthFailed, false},
+		{http.StatusConflict, "", ErrRecordConflict, false},
+		{http.StatusTooManyRequests, "", ErrRateLimited, true},
+		{http.StatusNotFound, "", nil, false},
+		{http.StatusUnprocessableEntity, "", nil, false},
+		{http.StatusBadGateway, "", nil, true},
+		{http.StatusServiceUnavailable, "", nil, true},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+		var err *ProviderError
+		if tt.code == "" {
+			err = newStatusError("测试", resp, "body")
+		} else {
+			err = newHTTPError("测试", resp, tt.code, "message", "request-id")
+		}
+		if err.Kind != tt.want || err.StatusCode != tt.status {
+			t.Errorf("状态码%d、错误码%q的分类为%v，期望%v", tt.status, tt.code, err.Kind, tt.want)
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("状态码%d、错误码%q的错误应匹配%v", tt.status, tt.code, tt.want)
+		}
+		if IsRetryable(err) != tt.retryable {
+			t.Errorf("状态码%d、错误码%q的可重试判断应为%v", tt.status, tt.code, tt.retryable)
+		}
+	}
+}
+
+func TestNewHTTPErrorRetryAfter(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "7")
+	err := newHTTPError("测试", &http.Response{StatusCode: http.StatusTooManyRequests, Header: header}, "", "", "")
+	if RetryAfter(fmt.Errorf("包装: %w", err)) != 7*time.Second {
+		t.Fatalf("Retry-After应被解析并可从包装的错误中读取，实际为%v", err.RetryAfter)
+	}
+}
+
+// timeoutError 模拟网络超时错误
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"请求取消", context.Canceled, false},
+		{"包装的请求取消", fmt.Errorf("发起请求失败: %w", context.Canceled), false},
+		{"请求超时", context.DeadlineExceeded, true},
+		{"网络错误", fmt.Errorf("发起请求失败: %w", timeoutError{}), true},
+		{"限流", &ProviderError{Kind: ErrRateLimited}, true},
+		{"临时错误", &ProviderError{Temporary: true}, true},
+		{"服务端错误", &ProviderError{StatusCode: http.StatusInternalServerError}, true},
+		{"认证失败", &ProviderError{Kind: ErrAuthFailed, StatusCode: http.StatusUnauthorized}, false},
+		{"记录冲突", &ProviderError{Kind: ErrRecordConflict, StatusCode: http.StatusBadRequest}, false},
+		{"普通错误", errors.New("解析响应失败"), false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable = %v，期望%v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package providers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -25,28 +27,16 @@ func (f *ProviderFactory) SetRetryConfig(config RetryConfig) {
 
 // IsSupported 检查是否支持指定的DNS提供商类型
 func (f *ProviderFactory) IsSupported(providerType string) bool {
-	supportedTypes := []string{
-		"aliyun", "dnspod", "huawei", "baidu", "west",
-		"volcengine", "dnsla", "cloudflare", "namesilo", "powerdns",
-	}
-	
-	for _, supported := range supportedTypes {
-		if supported == providerType {
-			return true
-		}
-	}
-	return false
+	_, exists := Lookup(providerType)
+	return exists
 }
 
 // GetSupportedTypes 获取支持的DNS提供商类型列表
 func (f *ProviderFactory) GetSupportedTypes() []string {
-	return []string{
-		"aliyun", "dnspod", "huawei", "baidu", "west",
-		"volcengine", "dnsla", "cloudflare", "namesilo", "powerdns",
-	}
+	return SupportedProviders()
 }
 
-// CreateProvider 创建DNS服务商实例
+// CreateProvider 创建DNS服务商实例，返回的实例已包装限流、重试和熔断
 func (f *ProviderFactory) CreateProvider(providerType string, config map[string]string) (DNSProvider, error) {
 	// 将map[string]string转换为ProviderConfig
 	providerConfig := ProviderConfig{
@@ -58,213 +48,161 @@ func (f *ProviderFactory) CreateProvider(providerType string, config map[string]
 		ExtraParams: config, // 保存所有原始参数
 	}
 	
-	switch providerType {
-	case "aliyun":
-		return NewAliyunProvider(providerConfig)
-	case "dnspod":
-		return NewDNSPodProvider(providerConfig)
-	case "huawei":
-		return NewHuaweiProvider(providerConfig)
-	case "baidu":
-		return NewBaiduProvider(providerConfig)
-	case "west":
-		return NewWestProvider(providerConfig)
-	case "volcengine":
-		return NewVolcengineProvider(providerConfig)
-	case "dnsla":
-		return NewDNSLAProvider(providerConfig)
-	case "cloudflare":
-		return NewCloudflareProvider(providerConfig)
-	case "namesilo":
-		return NewNamesiloProvider(providerConfig)
-	case "powerdns":
-		return NewPowerDNSProvider(providerConfig)
-	default:
+	provider, err := f.createProvider(providerType, providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewResilientProvider(provider, providerType, providerConfig, f.retryConfig), nil
+}
+
+// createProvider 通过注册信息创建未包装的DNS服务商实例
+func (f *ProviderFactory) createProvider(providerType string, providerConfig ProviderConfig) (DNSProvider, error) {
+	definition, exists := Lookup(providerType)
+	if !exists {
 		return nil, fmt.Errorf("不支持的DNS服务商: %s", providerType)
 	}
+	return definition.Constructor(providerConfig)
 }
 
-// ProviderManager DNS服务商管理器
+// CredentialLoader 按凭据ID加载服务商类型和解密后的配置，由数据库层实现
+type CredentialLoader func(ctx context.Context, credentialID uint) (providerType string, config map[string]string, err error)
+
+// ProviderManager DNS服务商管理器，按凭据ID（models.DNSProvider.ID）缓存服务商实例
+// 实例在首次使用时通过CredentialLoader加载，凭据更新或删除后需要调用Invalidate，可以并发使用
 type ProviderManager struct {
-	factory   *ProviderFactory
-	providers map[string]DNSProvider
-	configs   map[string]ProviderConfig
+	factory *ProviderFactory
+	loader  CredentialLoader
+	
+	mu        sync.Mutex
+	providers map[uint]*managedProvider
+}
+
+// managedProvider 缓存的服务商实例，ready关闭后provider和err可用
+type managedProvider struct {
+	ready    chan struct{}
+	provider DNSProvider
+	err      error
 }
 
 // NewProviderManager 创建DNS服务商管理器
-func NewProviderManager() *ProviderManager {
+func NewProviderManager(factory *ProviderFactory, loader CredentialLoader) *ProviderManager {
 	return &ProviderManager{
-		factory:   NewProviderFactory(),
-		providers: make(map[string]DNSProvider),
-		configs:   make(map[string]ProviderConfig),
+		factory:   factory,
+		loader:    loader,
+		providers: make(map[uint]*managedProvider),
 	}
 }
 
-// RegisterProvider 注册DNS服务商
-func (m *ProviderManager) RegisterProvider(name, providerType string, config map[string]string) error {
-	provider, err := m.factory.CreateProvider(providerType, config)
-	if err != nil {
-		return fmt.Errorf("创建DNS服务商失败: %v", err)
+// Factory 获取创建服务商实例的工厂，用于保存凭据前校验配置等不缓存实例的场景
+func (m *ProviderManager) Factory() *ProviderFactory {
+	return m.factory
+}
+
+// GetProvider 获取凭据对应的DNS服务商实例，未缓存时从数据库加载
+// 同一凭据的并发请求只加载一次，加载失败的结果不缓存
+func (m *ProviderManager) GetProvider(ctx context.Context, credentialID uint) (DNSProvider, error) {
+	m.mu.Lock()
+	entry, exists := m.providers[credentialID]
+	if !exists {
+		entry = &managedProvider{ready: make(chan struct{})}
+		m.providers[credentialID] = entry
 	}
+	m.mu.Unlock()
 	
-	// 验证配置
-	if err := provider.ValidateConfig(); err != nil {
-		return fmt.Errorf("DNS服务商配置验证失败: %v", err)
+	if !exists {
+		// 加载结果由等待中的其他请求共享，不能因为发起请求的ctx取消而失败
+		m.load(context.WithoutCancel(ctx), credentialID, entry)
 	}
 	
-	m.providers[name] = provider
-	// 将map配置转换为ProviderConfig保存
-	providerConfig := ProviderConfig{
-		APIKey:      config["api_key"],
-		APISecret:   config["api_secret"],
-		Token:       config["token"],
-		Region:      config["region"],
-		Endpoint:    config["endpoint"],
-		ExtraParams: config,
+	select {
+	case <-entry.ready:
+		return entry.provider, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	m.configs[name] = providerConfig
-	return nil
 }
 
-// GetProvider 获取DNS服务商
-func (m *ProviderManager) GetProvider(name string) (DNSProvider, error) {
-	provider, exists := m.providers[name]
-	if !exists {
-		return nil, fmt.Errorf("DNS服务商 '%s' 未注册", name)
+// load 加载凭据并创建服务商实例
+func (m *ProviderManager) load(ctx context.Context, credentialID uint, entry *managedProvider) {
+	defer close(entry.ready)
+	
+	entry.provider, entry.err = m.create(ctx, credentialID)
+	if entry.err != nil {
+		m.mu.Lock()
+		if m.providers[credentialID] == entry {
+			delete(m.providers, credentialID)
+		}
+		m.mu.Unlock()
 	}
-	return provider, nil
 }
 
-// ListProviders 获取所有已注册的DNS服务商
-func (m *ProviderManager) ListProviders() []string {
-	var names []string
-	for name := range m.providers {
-		names = append(names, name)
+// create 通过CredentialLoader加载凭据配置并创建服务商实例
+func (m *ProviderManager) create(ctx context.Context, credentialID uint) (DNSProvider, error) {
+	if m.loader == nil {
+		return nil, fmt.Errorf("DNS服务商凭据 %d 未注册", credentialID)
 	}
-	return names
-}
-
-// TestProvider 测试DNS服务商连接
-func (m *ProviderManager) TestProvider(name string) error {
-	provider, err := m.GetProvider(name)
+	
+	providerType, config, err := m.loader(ctx, credentialID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	
-	return provider.TestConnection(ctx)
-}
-
-// TestAllProviders 测试所有DNS服务商连接
-func (m *ProviderManager) TestAllProviders() map[string]error {
-	results := make(map[string]error)
-	for name := range m.providers {
-		results[name] = m.TestProvider(name)
+	provider, err := m.factory.CreateProvider(providerType, config)
+	if err != nil {
+		return nil, fmt.Errorf("创建DNS服务商失败: %w", err)
 	}
-	return results
+	return provider, nil
 }
 
-// RemoveProvider 移除DNS服务商
-func (m *ProviderManager) RemoveProvider(name string) {
-	delete(m.providers, name)
-	delete(m.configs, name)
+// RegisterProvider 缓存已创建的服务商实例，用于保存凭据后复用测试连接时创建的实例
+func (m *ProviderManager) RegisterProvider(credentialID uint, provider DNSProvider) {
+	entry := &managedProvider{ready: make(chan struct{}), provider: provider}
+	close(entry.ready)
+	
+	m.mu.Lock()
+	m.providers[credentialID] = entry
+	m.mu.Unlock()
 }
 
-// UpdateProvider 更新DNS服务商配置
-func (m *ProviderManager) UpdateProvider(name, providerType string, config map[string]string) error {
-	// 先移除旧的
-	m.RemoveProvider(name)
-	
-	// 注册新的
-	return m.RegisterProvider(name, providerType, config)
+// Invalidate 移除缓存的服务商实例，凭据更新或删除后调用，下次使用时重新加载
+// 正在加载中的实例仍会返回给已经在等待的请求，但不会被缓存
+func (m *ProviderManager) Invalidate(credentialID uint) {
+	m.mu.Lock()
+	delete(m.providers, credentialID)
+	m.mu.Unlock()
 }
 
-// RetryOperation 重试操作的通用方法
-func (m *ProviderManager) RetryOperation(operation func() error) error {
-	retryConfig := m.factory.retryConfig
-	var lastErr error
+// ListProviders 获取已缓存的凭据ID
+func (m *ProviderManager) ListProviders() []uint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	
-	for attempt := 0; attempt <= retryConfig.MaxRetries; attempt++ {
-		if attempt > 0 {
-			delay := time.Duration(float64(retryConfig.InitialDelay) * 
-				pow(retryConfig.BackoffFactor, float64(attempt-1)))
-			if delay > retryConfig.MaxDelay {
-				delay = retryConfig.MaxDelay
-			}
-			time.Sleep(delay)
-		}
-		
-		if err := operation(); err != nil {
-			lastErr = err
-			// 检查是否是可重试的错误
-			if !isRetryableError(err) {
-				return err
-			}
-			continue
-		}
-		
-		return nil
+	ids := make([]uint, 0, len(m.providers))
+	for id := range m.providers {
+		ids = append(ids, id)
 	}
-	
-	return fmt.Errorf("操作失败，已重试%d次: %v", retryConfig.MaxRetries, lastErr)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
 }
 
-// isRetryableError 判断错误是否可重试
-func isRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-	
-	// 网络相关错误通常可以重试
-	errorStr := err.Error()
-	retryableErrors := []string{
-		"timeout",
-		"connection refused",
-		"connection reset",
-		"network is unreachable",
-		"temporary failure",
-		"server error",
-		"rate limit",
-		"too many requests",
+// TestProvider 测试DNS服务商连接
+func (m *ProviderManager) TestProvider(ctx context.Context, credentialID uint) error {
+	provider, err := m.GetProvider(ctx, credentialID)
+	if err != nil {
+		return err
 	}
 	
-	for _, retryable := range retryableErrors {
-		if contains(errorStr, retryable) {
-			return true
-		}
-	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 	
-	return false
+	return provider.TestConnection(ctx)
 }
 
-// pow 简单的幂运算实现
-func pow(base, exp float64) float64 {
-	if exp == 0 {
-		return 1
-	}
-	result := base
-	for i := 1; i < int(exp); i++ {
-		result *= base
+// TestAllProviders 测试所有已缓存的DNS服务商连接
+func (m *ProviderManager) TestAllProviders(ctx context.Context) map[uint]error {
+	results := make(map[uint]error)
+	for _, id := range m.ListProviders() {
+		results[id] = m.TestProvider(ctx, id)
 	}
-	return result
-}
-
-// contains 检查字符串是否包含子字符串
-func contains(str, substr string) bool {
-	return len(str) >= len(substr) && 
-		(len(substr) == 0 || str[len(str)-len(substr):] == substr || 
-		 str[:len(substr)] == substr ||
-		 (len(str) > len(substr) && findSubstring(str, substr)))
+	return results
 }
-
-// findSubstring 查找子字符串
-func findSubstring(str, substr string) bool {
-	for i := 0; i <= len(str)-len(substr); i++ {
-		if str[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
\ No newline at end of file
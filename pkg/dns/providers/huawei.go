@@ -0,0 +1,780 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HuaweiProvider 华为云DNS服务商
+// 华为云以记录集（recordset）为单位管理记录，一个记录集包含多个记录值，
+// 适配器将每个记录值映射为一条DNSRecord，记录ID格式为"记录集ID/记录值摘要"
+type HuaweiProvider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	endpoint   string
+}
+
+// huaweiDefaultLine 华为云默认解析线路
+const huaweiDefaultLine = "default_view"
+
+// huaweiErrorKinds 华为云API网关错误码对应的错误分类
+// APIGW.0101表示接口不存在，通常是区域或接口地址填写错误，不是凭据无效
+var huaweiErrorKinds = map[string]error{
+	"APIGW.0101": ErrInvalidConfig,
+	"APIGW.0301": ErrAuthFailed,
+	"APIGW.0303": ErrAuthFailed,
+	"APIGW.0308": ErrRateLimited,
+}
+
+// init 注册华为云DNS服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "huawei",
+		DisplayName: "华为云DNS",
+		Description: "华为云云解析服务",
+		SortOrder:   4,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
+			SupportsBatch:        false,
+			SupportsLineTypes:    true,
+			MaxRecordsPerDomain:  500,
+			MinTTL:               1,
+			MaxTTL:               2147483647,
+			RateLimit:            10,
+			RateBurst:            10,
+		},
+		ConfigSchema: accessKeySchema("Access Key", "Secret Key",
+			ConfigField{Key: "region", Label: "区域", Type: FieldText, Placeholder: "cn-north-4",
+				Help: "不填写时使用全局接口地址"},
+			ConfigField{Key: "project_id", Label: "项目ID", Type: FieldText, Help: "使用子项目的IAM凭据时填写"},
+		),
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewHuaweiProvider(config)
+		},
+	})
+}
+
+// NewHuaweiProvider 创建华为云DNS服务商实例
+func NewHuaweiProvider(config ProviderConfig) (*HuaweiProvider, error) {
+	if config.APIKey == "" || config.APISecret == "" {
+		return nil, fmt.Errorf("华为云DNS需要Access Key和Secret Key")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		if config.Region != "" {
+			endpoint = "https://dns." + config.Region + ".myhuaweicloud.com"
+		} else {
+			endpoint = "https://dns.myhuaweicloud.com"
+		}
+	}
+
+	return &HuaweiProvider{
+		config:   config,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetName 获取服务商名称
+func (p *HuaweiProvider) GetName() string {
+	return "huawei"
+}
+
+// ValidateConfig 验证API配置
+func (p *HuaweiProvider) ValidateConfig() error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("华为云DNS Access Key不能为空")
+	}
+	if p.config.APISecret == "" {
+		return fmt.Errorf("华为云DNS Secret Key不能为空")
+	}
+	return nil
+}
+
+// TestConnection 测试连接
+func (p *HuaweiProvider) TestConnection(ctx context.Context) error {
+	_, err := p.makeRequest(ctx, "GET", "/v2/zones", url.Values{"type": {"public"}, "limit": {"1"}}, nil)
+	return err
+}
+
+// huaweiZone 华为云API返回的域名
+type huaweiZone struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	RecordNum int    `json:"record_num"`
+}
+
+// ListZones 获取账号下托管的全部公网域名
+func (p *HuaweiProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	const pageSize = 500
+	var zones []Zone
+
+	for offset := 0; ; offset += pageSize {
+		query := url.Values{
+			"type":   {"public"},
+			"limit":  {strconv.Itoa(pageSize)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		response, err := p.makeRequest(ctx, "GET", "/v2/zones", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Zones    []huaweiZone `json:"zones"`
+			Metadata struct {
+				TotalCount int `json:"total_count"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, zone := range result.Zones {
+			zones = append(zones, Zone{
+				ID:          zone.ID,
+				Name:        strings.TrimSuffix(zone.Name, "."),
+				Status:      strings.ToLower(zone.Status),
+				RecordCount: zone.RecordNum,
+			})
+		}
+
+		if len(result.Zones) < pageSize || len(zones) >= result.Metadata.TotalCount {
+			break
+		}
+	}
+
+	return zones, nil
+}
+
+// VerifyZone 校验域名已托管在华为云账号下
+func (p *HuaweiProvider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	return p.getZoneID(ctx, domain)
+}
+
+// huaweiRecordset 华为云API返回的记录集
+type huaweiRecordset struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl"`
+	Records []string `json:"records"`
+	Status  string   `json:"status"`
+	Line    string   `json:"line"`
+}
+
+// ListRecords 获取域名记录列表
+func (p *HuaweiProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *HuaweiProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	recordsets, err := p.listRecordsets(ctx, zoneID, domain, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	for _, recordset := range recordsets {
+		// SOA记录由华为云自动维护，不对外暴露
+		if recordset.Type == "SOA" {
+			continue
+		}
+		for _, value := range recordset.Records {
+			records = append(records, huaweiToDNSRecord(recordset, value, domain))
+		}
+	}
+
+	return filterRecords(records, filter), nil
+}
+
+// AddRecord 添加DNS记录，同名同类型同线路的记录集已存在时追加记录值
+func (p *HuaweiProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	return p.addRecord(ctx, zoneID, domain, record)
+}
+
+// UpdateRecord 更新DNS记录
+func (p *HuaweiProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	_, err := p.ReplaceRecord(ctx, domain, recordID, record)
+	return err
+}
+
+// ReplaceRecord 更新DNS记录并返回新的记录，记录值变化后记录ID随之变化
+func (p *HuaweiProvider) ReplaceRecord(ctx context.Context, domain string, recordID string, record DNSRecord) (*DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	recordset, index, err := p.findValue(ctx, zoneID, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	line := huaweiLine(record.Line)
+	sameSet := strings.EqualFold(recordset.Name, huaweiRecordName(record.Name, domain)) &&
+		strings.EqualFold(recordset.Type, record.Type) &&
+		huaweiLine(recordset.Line) == line
+
+	if sameSet {
+		values := append([]string(nil), recordset.Records...)
+		values[index] = huaweiEncodeValue(record)
+		if err := p.updateRecordset(ctx, zoneID, recordset, values, record.TTL); err != nil {
+			return nil, err
+		}
+
+		updated := huaweiToDNSRecord(*recordset, values[index], domain)
+		updated.TTL = record.TTL
+		return &updated, nil
+	}
+
+	// 子域名、类型或线路变化时需要把记录值移动到另一个记录集
+	added, err := p.addRecord(ctx, zoneID, domain, record)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.removeValue(ctx, zoneID, recordset, index); err != nil {
+		// 原记录值删除失败时删除新添加的记录值，避免同一记录同时存在于两个记录集
+		if cleanupErr := p.deleteValue(ctx, zoneID, added.ID); cleanupErr != nil {
+			return nil, fmt.Errorf("%w（删除新添加的记录失败: %v）", err, cleanupErr)
+		}
+		return nil, err
+	}
+	return added, nil
+}
+
+// DeleteRecord 删除DNS记录，记录集中最后一个记录值被删除时删除整个记录集
+func (p *HuaweiProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	return p.deleteValue(ctx, zoneID, recordID)
+}
+
+// deleteValue 按记录ID删除记录值
+func (p *HuaweiProvider) deleteValue(ctx context.Context, zoneID, recordID string) error {
+	recordset, index, err := p.findValue(ctx, zoneID, recordID)
+	if err != nil {
+		return err
+	}
+
+	return p.removeValue(ctx, zoneID, recordset, index)
+}
+
+// GetRecord 获取单个记录详情
+func (p *HuaweiProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	recordset, index, err := p.findValue(ctx, zoneID, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := huaweiToDNSRecord(*recordset, recordset.Records[index], domain)
+	return &record, nil
+}
+
+// SetRecordStatus 启用或暂停DNS记录
+// 华为云只能按记录集设置状态，记录集有多个记录值时会影响同一记录集的其他记录，此时返回ErrUnsupported由调用方改为删除和重新创建
+func (p *HuaweiProvider) SetRecordStatus(ctx context.Context, domain string, recordID string, enabled bool) error {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	recordset, _, err := p.findValue(ctx, zoneID, recordID)
+	if err != nil {
+		return err
+	}
+	if len(recordset.Records) > 1 {
+		return fmt.Errorf("%w: 记录集%s有%d个记录值，不能单独设置其中一个的状态", ErrUnsupported, recordset.ID, len(recordset.Records))
+	}
+
+	status := "DISABLE"
+	if enabled {
+		status = "ENABLE"
+	}
+
+	_, err = p.makeRequest(ctx, "PUT", "/v2.1/recordsets/"+recordset.ID+"/statuses/set", nil, map[string]interface{}{"status": status})
+	return err
+}
+
+// BatchAddRecords 批量添加DNS记录
+func (p *HuaweiProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	var results []DNSRecord
+	var errors []error
+
+	for _, record := range records {
+		result, err := p.addRecord(ctx, zoneID, domain, record)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	if len(errors) > 0 {
+		return results, fmt.Errorf("批量添加记录时发生错误: %v", errors)
+	}
+
+	return results, nil
+}
+
+// addRecord 在指定域名下添加记录值
+func (p *HuaweiProvider) addRecord(ctx context.Context, zoneID, domain string, record DNSRecord) (*DNSRecord, error) {
+	name := huaweiRecordName(record.Name, domain)
+	line := huaweiLine(record.Line)
+	value := huaweiEncodeValue(record)
+
+	recordsets, err := p.listRecordsets(ctx, zoneID, domain, RecordFilter{Name: record.Name, Type: record.Type})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recordsets {
+		recordset := recordsets[i]
+		if !strings.EqualFold(recordset.Name, name) || !strings.EqualFold(recordset.Type, record.Type) || huaweiLine(recordset.Line) != line {
+			continue
+		}
+
+		for _, existing := range recordset.Records {
+			if existing == value {
+				return nil, fmt.Errorf("%w: %s %s %s", ErrRecordConflict, record.Name, record.Type, record.Value)
+			}
+		}
+
+		values := append(append([]string(nil), recordset.Records...), value)
+		if err := p.updateRecordset(ctx, zoneID, &recordset, values, record.TTL); err != nil {
+			return nil, err
+		}
+
+		created := huaweiToDNSRecord(recordset, value, domain)
+		created.TTL = record.TTL
+		return &created, nil
+	}
+
+	data := map[string]interface{}{
+		"name":    name,
+		"type":    strings.ToUpper(record.Type),
+		"ttl":     record.TTL,
+		"records": []string{value},
+		"line":    line,
+	}
+
+	response, err := p.makeRequest(ctx, "POST", "/v2.1/zones/"+zoneID+"/recordsets", nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var recordset huaweiRecordset
+	if err := json.Unmarshal(response, &recordset); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	created := huaweiToDNSRecord(recordset, value, domain)
+	return &created, nil
+}
+
+// removeValue 从记录集中删除一个记录值
+func (p *HuaweiProvider) removeValue(ctx context.Context, zoneID string, recordset *huaweiRecordset, index int) error {
+	if len(recordset.Records) <= 1 {
+		_, err := p.makeRequest(ctx, "DELETE", "/v2.1/zones/"+zoneID+"/recordsets/"+recordset.ID, nil, nil)
+		return err
+	}
+
+	values := make([]string, 0, len(recordset.Records)-1)
+	values = append(values, recordset.Records[:index]...)
+	values = append(values, recordset.Records[index+1:]...)
+	return p.updateRecordset(ctx, zoneID, recordset, values, recordset.TTL)
+}
+
+// updateRecordset 更新记录集的记录值和TTL
+func (p *HuaweiProvider) updateRecordset(ctx context.Context, zoneID string, recordset *huaweiRecordset, values []string, ttl int) error {
+	if ttl <= 0 {
+		ttl = recordset.TTL
+	}
+
+	data := map[string]interface{}{
+		"name":    recordset.Name,
+		"type":    recordset.Type,
+		"ttl":     ttl,
+		"records": values,
+	}
+
+	_, err := p.makeRequest(ctx, "PUT", "/v2.1/zones/"+zoneID+"/recordsets/"+recordset.ID, nil, data)
+	if err != nil {
+		return err
+	}
+
+	recordset.Records = values
+	recordset.TTL = ttl
+	return nil
+}
+
+// findValue 根据记录ID查找记录集及记录值所在位置
+func (p *HuaweiProvider) findValue(ctx context.Context, zoneID, recordID string) (*huaweiRecordset, int, error) {
+	recordsetID, digest, ok := strings.Cut(recordID, "/")
+	if !ok || recordsetID == "" || digest == "" {
+		return nil, 0, fmt.Errorf("无效的记录ID: %s", recordID)
+	}
+
+	response, err := p.makeRequest(ctx, "GET", "/v2.1/zones/"+zoneID+"/recordsets/"+recordsetID, nil, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var recordset huaweiRecordset
+	if err := json.Unmarshal(response, &recordset); err != nil {
+		return nil, 0, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	for i, value := range recordset.Records {
+		if valueDigest(value) == digest {
+			return &recordset, i, nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf("%w: %s", ErrRecordNotFound, recordID)
+}
+
+// listRecordsets 分页获取域名下的记录集
+func (p *HuaweiProvider) listRecordsets(ctx context.Context, zoneID, domain string, filter RecordFilter) ([]huaweiRecordset, error) {
+	const pageSize = 500
+	var recordsets []huaweiRecordset
+
+	for offset := 0; ; offset += pageSize {
+		query := url.Values{
+			"limit":  {strconv.Itoa(pageSize)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		// name参数为模糊匹配，调用方需要再做精确过滤
+		if filter.Name != "" {
+			query.Set("name", huaweiRecordName(filter.Name, domain))
+		}
+		if filter.Type != "" {
+			query.Set("type", strings.ToUpper(filter.Type))
+		}
+
+		response, err := p.makeRequest(ctx, "GET", "/v2.1/zones/"+zoneID+"/recordsets", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Recordsets []huaweiRecordset `json:"recordsets"`
+			Metadata   struct {
+				TotalCount int `json:"total_count"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		recordsets = append(recordsets, result.Recordsets...)
+
+		if len(result.Recordsets) < pageSize || len(recordsets) >= result.Metadata.TotalCount {
+			break
+		}
+	}
+
+	return recordsets, nil
+}
+
+// getZoneID 获取域名的Zone ID，结果按凭据缓存
+func (p *HuaweiProvider) getZoneID(ctx context.Context, domain string) (string, error) {
+	return cachedZoneID(ctx, "huawei", p.config, domain, p.lookupZoneID)
+}
+
+// lookupZoneID 查询域名的Zone ID
+func (p *HuaweiProvider) lookupZoneID(ctx context.Context, domain string) (string, error) {
+	query := url.Values{
+		"type": {"public"},
+		"name": {domain + "."},
+	}
+	response, err := p.makeRequest(ctx, "GET", "/v2/zones", query, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Zones []huaweiZone `json:"zones"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return "", fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	for _, zone := range result.Zones {
+		if strings.TrimSuffix(zone.Name, ".") == domain {
+			return zone.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
+}
+
+// makeRequest 发起API请求
+func (p *HuaweiProvider) makeRequest(ctx context.Context, method, path string, query url.Values, data interface{}) ([]byte, error) {
+	var payload []byte
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("序列化请求数据失败: %w", err)
+		}
+		payload = jsonData
+	}
+
+	requestURL := p.endpoint + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sdk-Date", time.Now().UTC().Format(huaweiDateFormat))
+	if projectID := p.config.ExtraParams["project_id"]; projectID != "" {
+		req.Header.Set("X-Project-Id", projectID)
+	}
+	req.Header.Set("Authorization", huaweiSign(req, payload, p.config.APIKey, p.config.APISecret))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发起请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errorResp struct {
+			Code      string `json:"code"`
+			Message   string `json:"message"`
+			ErrorCode string `json:"error_code"`
+			ErrorMsg  string `json:"error_msg"`
+		}
+		if err := json.Unmarshal(body, &errorResp); err == nil {
+			code, message := errorResp.Code, errorResp.Message
+			if code == "" {
+				code, message = errorResp.ErrorCode, errorResp.ErrorMsg
+			}
+			if code != "" {
+				apiErr := newHTTPError("华为云DNS", resp, code, message, resp.Header.Get("X-Request-Id"))
+				if kind, ok := huaweiErrorKinds[code]; ok {
+					apiErr.Kind = kind
+				}
+				return nil, apiErr
+			}
+		}
+		return nil, newStatusError("华为云DNS", resp, string(body))
+	}
+
+	return body, nil
+}
+
+// huaweiDateFormat X-Sdk-Date请求头的时间格式
+const huaweiDateFormat = "20060102T150405Z"
+
+// huaweiSign 按华为云API网关SDK-HMAC-SHA256规则生成Authorization请求头
+func huaweiSign(req *http.Request, payload []byte, accessKey, secretKey string) string {
+	// 参与签名的请求头
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for key, values := range req.Header {
+		lower := strings.ToLower(key)
+		if lower == "authorization" || len(values) == 0 {
+			continue
+		}
+		headers[lower] = strings.TrimSpace(values[0])
+	}
+
+	signedHeaders := make([]string, 0, len(headers))
+	for key := range headers {
+		signedHeaders = append(signedHeaders, key)
+	}
+	sort.Strings(signedHeaders)
+
+	var canonicalHeaders strings.Builder
+	for _, key := range signedHeaders {
+		canonicalHeaders.WriteString(key + ":" + headers[key] + "\n")
+	}
+
+	canonicalRequest := req.Method + "\n" +
+		huaweiCanonicalURI(req.URL.Path) + "\n" +
+		huaweiCanonicalQuery(req.URL.Query()) + "\n" +
+		canonicalHeaders.String() + "\n" +
+		strings.Join(signedHeaders, ";") + "\n" +
+		sha256Hex(string(payload))
+
+	stringToSign := "SDK-HMAC-SHA256\n" +
+		req.Header.Get("X-Sdk-Date") + "\n" +
+		sha256Hex(canonicalRequest)
+
+	signature := hex.EncodeToString(hmacSha256([]byte(secretKey), stringToSign))
+
+	return "SDK-HMAC-SHA256 Access=" + accessKey +
+		", SignedHeaders=" + strings.Join(signedHeaders, ";") +
+		", Signature=" + signature
+}
+
+// huaweiCanonicalURI 规范化请求路径，路径必须以"/"结尾
+func huaweiCanonicalURI(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEscape(segment)
+	}
+
+	uri := strings.Join(segments, "/")
+	if !strings.HasSuffix(uri, "/") {
+		uri += "/"
+	}
+	return uri
+}
+
+// huaweiCanonicalQuery 规范化查询字符串
+func huaweiCanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEscape(key)+"="+uriEscape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEscape 按RFC 3986编码，空格编码为%20
+func uriEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// huaweiRecordName 将子域名转换为华为云要求的完整域名（以"."结尾）
+func huaweiRecordName(name, domain string) string {
+	if name == "" || name == "@" {
+		return domain + "."
+	}
+	return name + "." + domain + "."
+}
+
+// huaweiLine 规范化解析线路，未指定时使用默认线路
+func huaweiLine(line string) string {
+	if line == "" || line == "default" {
+		return huaweiDefaultLine
+	}
+	return line
+}
+
+// valueDigest 计算记录值摘要，用于在多值记录集中定位记录值
+func valueDigest(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// huaweiEncodeValue 将DNSRecord转换为华为云记录值格式
+func huaweiEncodeValue(record DNSRecord) string {
+	switch strings.ToUpper(record.Type) {
+	case "MX":
+		return fmt.Sprintf("%d %s", record.Priority, record.Value)
+	case "SRV":
+		return fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Value)
+	case "TXT":
+		if strings.HasPrefix(record.Value, "\"") {
+			return record.Value
+		}
+		return strconv.Quote(record.Value)
+	default:
+		return record.Value
+	}
+}
+
+// huaweiToDNSRecord 将记录集中的单个记录值转换为DNSRecord
+func huaweiToDNSRecord(recordset huaweiRecordset, value, domain string) DNSRecord {
+	name := strings.TrimSuffix(recordset.Name, ".")
+	if name == domain {
+		name = "@"
+	} else {
+		name = strings.TrimSuffix(name, "."+domain)
+	}
+
+	status := "active"
+	if recordset.Status != "" && recordset.Status != "ACTIVE" {
+		status = strings.ToLower(recordset.Status)
+	}
+
+	record := DNSRecord{
+		ID:     recordset.ID + "/" + valueDigest(value),
+		Name:   name,
+		Type:   recordset.Type,
+		Value:  value,
+		TTL:    recordset.TTL,
+		Line:   recordset.Line,
+		Status: status,
+	}
+
+	fields := strings.Fields(value)
+	switch {
+	case recordset.Type == "MX" && len(fields) == 2:
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Value = fields[1]
+	case recordset.Type == "SRV" && len(fields) == 4:
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Weight, _ = strconv.Atoi(fields[1])
+		record.Port, _ = strconv.Atoi(fields[2])
+		record.Value = fields[3]
+	case recordset.Type == "TXT":
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			record.Value = unquoted
+		}
+	}
+
+	return record
+}
@@ -0,0 +1,457 @@
+package providers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	huaweiTestAccessKey = "HWTESTAK"
+	huaweiTestSecretKey = "hw-test-secret"
+	huaweiTestZoneID    = "ff8080827c2b3b1d"
+)
+
+// huaweiFake 华为云DNS接口的内存实现，校验每个请求的SDK-HMAC-SHA256签名
+type huaweiFake struct {
+	t           *testing.T
+	mu          sync.Mutex
+	recordsets  map[string]*huaweiRecordset
+	nextID      int
+	statusCalls int
+	failWrites  string // 对该记录集的修改和删除返回服务端错误
+}
+
+func newHuaweiFake(t *testing.T) (*huaweiFake, *HuaweiProvider) {
+	t.Helper()
+	fake := &huaweiFake{t: t, recordsets: make(map[string]*huaweiRecordset)}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	provider, err := NewHuaweiProvider(ProviderConfig{
+		APIKey:    huaweiTestAccessKey,
+		APISecret: huaweiTestSecretKey,
+		Endpoint:  server.URL,
+	})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	return fake, provider
+}
+
+func (f *huaweiFake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if err := verifyHuaweiSignature(r, body); err != "" {
+		writeHuaweiError(w, http.StatusUnauthorized, "APIGW.0301", err)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/v2/zones":
+		zones := []huaweiZone{}
+		if name := r.URL.Query().Get("name"); name == "" || name == "example.com." {
+			zones = append(zones, huaweiZone{ID: huaweiTestZoneID, Name: "example.com.", Status: "ACTIVE"})
+		}
+		writeHuaweiJSON(w, map[string]interface{}{"zones": zones, "metadata": map[string]int{"total_count": len(zones)}})
+	case len(parts) == 4 && parts[0] == "v2.1" && parts[3] == "recordsets" && parts[2] == huaweiTestZoneID:
+		if r.Method == "POST" {
+			var recordset huaweiRecordset
+			if err := json.Unmarshal(body, &recordset); err != nil {
+				writeHuaweiError(w, http.StatusBadRequest, "DNS.0303", "invalid body")
+				return
+			}
+			f.nextID++
+			recordset.ID = "rs" + strconv.Itoa(f.nextID)
+			recordset.Status = "ACTIVE"
+			f.recordsets[recordset.ID] = &recordset
+			writeHuaweiJSON(w, recordset)
+			return
+		}
+		f.listRecordsets(w, r.URL.Query())
+	case len(parts) == 5 && parts[0] == "v2.1" && parts[3] == "recordsets" && parts[2] == huaweiTestZoneID:
+		recordset, ok := f.recordsets[parts[4]]
+		if !ok {
+			writeHuaweiError(w, http.StatusNotFound, "DNS.0312", "recordset not found")
+			return
+		}
+		if r.Method != "GET" && recordset.ID == f.failWrites {
+			writeHuaweiError(w, http.StatusInternalServerError, "DNS.0500", "internal error")
+			return
+		}
+		switch r.Method {
+		case "GET":
+			writeHuaweiJSON(w, recordset)
+		case "PUT":
+			var update huaweiRecordset
+			if err := json.Unmarshal(body, &update); err != nil {
+				writeHuaweiError(w, http.StatusBadRequest, "DNS.0303", "invalid body")
+				return
+			}
+			recordset.TTL = update.TTL
+			recordset.Records = update.Records
+			writeHuaweiJSON(w, recordset)
+		case "DELETE":
+			delete(f.recordsets, recordset.ID)
+			writeHuaweiJSON(w, recordset)
+		}
+	case r.Method == "PUT" && len(parts) == 5 && parts[1] == "recordsets" && parts[3] == "statuses" && parts[4] == "set":
+		recordset, ok := f.recordsets[parts[2]]
+		if !ok {
+			writeHuaweiError(w, http.StatusNotFound, "DNS.0312", "recordset not found")
+			return
+		}
+		f.statusCalls++
+		var request struct {
+			Status string `json:"status"`
+		}
+		json.Unmarshal(body, &request)
+		switch request.Status {
+		case "ENABLE":
+			recordset.Status = "ACTIVE"
+		case "DISABLE":
+			recordset.Status = "DISABLE"
+		default:
+			writeHuaweiError(w, http.StatusBadRequest, "DNS.0303", "invalid status")
+			return
+		}
+		writeHuaweiJSON(w, map[string]string{"id": recordset.ID, "status": recordset.Status})
+	default:
+		writeHuaweiError(w, http.StatusNotFound, "APIGW.0101", "The API does not exist or has not been published in the environment")
+	}
+}
+
+// listRecordsets 按name（模糊匹配）和type筛选记录集
+func (f *huaweiFake) listRecordsets(w http.ResponseWriter, query url.Values) {
+	ids := make([]string, 0, len(f.recordsets))
+	for id := range f.recordsets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	recordsets := []huaweiRecordset{}
+	for _, id := range ids {
+		recordset := f.recordsets[id]
+		if name := query.Get("name"); name != "" && !strings.Contains(recordset.Name, name) {
+			continue
+		}
+		if recordType := query.Get("type"); recordType != "" && recordset.Type != recordType {
+			continue
+		}
+		recordsets = append(recordsets, *recordset)
+	}
+	writeHuaweiJSON(w, map[string]interface{}{"recordsets": recordsets, "metadata": map[string]int{"total_count": len(recordsets)}})
+}
+
+// verifyHuaweiSignature 按API网关规则独立计算签名并与Authorization请求头比较，校验通过时返回空字符串
+func verifyHuaweiSignature(r *http.Request, body []byte) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "SDK-HMAC-SHA256 ") {
+		return "missing authorization"
+	}
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(auth, "SDK-HMAC-SHA256 "), ", ") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			fields[key] = value
+		}
+	}
+	if fields["Access"] != huaweiTestAccessKey {
+		return "unknown access key"
+	}
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	if !containsHeader(signedHeaders, "host") || !containsHeader(signedHeaders, "x-sdk-date") {
+		return "host and x-sdk-date must be signed"
+	}
+
+	var headers strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	path := r.URL.EscapedPath()
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			pairs = append(pairs, url.QueryEscape(key)+"="+strings.ReplaceAll(url.QueryEscape(value), "+", "%20"))
+		}
+	}
+
+	payloadHash := sha256.Sum256(body)
+	canonical := r.Method + "\n" + path + "\n" + strings.Join(pairs, "&") + "\n" + headers.String() + "\n" +
+		fields["SignedHeaders"] + "\n" + hex.EncodeToString(payloadHash[:])
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	stringToSign := "SDK-HMAC-SHA256\n" + r.Header.Get("X-Sdk-Date") + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	mac := hmac.New(sha256.New, []byte(huaweiTestSecretKey))
+	mac.Write([]byte(stringToSign))
+	if hex.EncodeToString(mac.Sum(nil)) != fields["Signature"] {
+		return "signature mismatch"
+	}
+	return ""
+}
+
+func containsHeader(headers []string, name string) bool {
+	for _, header := range headers {
+		if header == name {
+			return true
+		}
+	}
+	return false
+}
+
+func writeHuaweiJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeHuaweiError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", "req-test")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error_code": code, "error_msg": message})
+}
+
+func TestHuaweiRecordIDRoundTrip(t *testing.T) {
+	fake, provider := newHuaweiFake(t)
+	ctx := context.Background()
+
+	first, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+	second, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300})
+	if err != nil {
+		t.Fatalf("添加第二个记录值失败: %v", err)
+	}
+
+	// 同名同类型的记录值合并到同一个记录集，记录ID为"记录集ID/记录值摘要"
+	firstSet, firstDigest, _ := strings.Cut(first.ID, "/")
+	secondSet, secondDigest, _ := strings.Cut(second.ID, "/")
+	if firstSet != secondSet || firstDigest == secondDigest {
+		t.Fatalf("记录ID不符合预期: %s, %s", first.ID, second.ID)
+	}
+	if firstDigest != valueDigest("192.0.2.1") {
+		t.Fatalf("记录值摘要不一致: %s", first.ID)
+	}
+	if len(fake.recordsets) != 1 {
+		t.Fatalf("期望1个记录集，实际为%d个", len(fake.recordsets))
+	}
+
+	if _, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300}); !errors.Is(err, ErrRecordConflict) {
+		t.Fatalf("重复添加应返回ErrRecordConflict，实际为: %v", err)
+	}
+
+	for _, want := range []*DNSRecord{first, second} {
+		got, err := provider.GetRecord(ctx, "example.com", want.ID)
+		if err != nil {
+			t.Fatalf("获取记录%s失败: %v", want.ID, err)
+		}
+		if got.Value != want.Value || got.Name != "www" || got.Type != "A" {
+			t.Fatalf("获取的记录不一致: %+v", got)
+		}
+	}
+
+	// 记录值变化后记录ID随之变化，原ID不再有效
+	replaced, err := provider.ReplaceRecord(ctx, "example.com", first.ID, DNSRecord{Name: "www", Type: "A", Value: "192.0.2.3", TTL: 600})
+	if err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	if replaced.ID == first.ID || !strings.HasPrefix(replaced.ID, firstSet+"/") {
+		t.Fatalf("更新后的记录ID不符合预期: %s", replaced.ID)
+	}
+	if _, err := provider.GetRecord(ctx, "example.com", first.ID); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("原记录ID应返回ErrRecordNotFound，实际为: %v", err)
+	}
+
+	records, err := provider.ListRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("获取记录列表失败: %v", err)
+	}
+	if len(records) != 2 || records[0].TTL != 600 {
+		t.Fatalf("记录列表不符合预期: %+v", records)
+	}
+
+	// 删除一个记录值保留记录集，删除最后一个记录值时删除整个记录集
+	if err := provider.DeleteRecord(ctx, "example.com", second.ID); err != nil {
+		t.Fatalf("删除记录失败: %v", err)
+	}
+	if len(fake.recordsets) != 1 {
+		t.Fatalf("删除一个记录值后记录集不应被删除")
+	}
+	if err := provider.DeleteRecord(ctx, "example.com", replaced.ID); err != nil {
+		t.Fatalf("删除记录失败: %v", err)
+	}
+	if len(fake.recordsets) != 0 {
+		t.Fatalf("删除最后一个记录值后记录集应被删除")
+	}
+}
+
+func TestHuaweiReplaceRecordMoveFailure(t *testing.T) {
+	fake, provider := newHuaweiFake(t)
+	ctx := context.Background()
+
+	original, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+	if _, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "api", Type: "A", Value: "192.0.2.8", TTL: 300}); err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+
+	// 子域名变化时先添加到目标记录集，原记录集删除失败时删除新添加的记录值
+	originalSet, _, _ := strings.Cut(original.ID, "/")
+	fake.failWrites = originalSet
+	if _, err := provider.ReplaceRecord(ctx, "example.com", original.ID, DNSRecord{Name: "api", Type: "A", Value: "192.0.2.1", TTL: 300}); err == nil {
+		t.Fatalf("原记录值删除失败时应返回错误")
+	}
+
+	records, err := provider.ListRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("获取记录列表失败: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("新添加的记录值应被删除，实际记录为: %+v", records)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", original.ID); err != nil || got.Name != "www" {
+		t.Fatalf("原记录应保持不变: %+v, %v", got, err)
+	}
+}
+
+func TestHuaweiRecordValues(t *testing.T) {
+	_, provider := newHuaweiFake(t)
+	ctx := context.Background()
+
+	records := []DNSRecord{
+		{Name: "@", Type: "MX", Value: "mail.example.com.", TTL: 300, Priority: 10},
+		{Name: "_sip._tcp", Type: "SRV", Value: "sip.example.com.", TTL: 300, Priority: 1, Weight: 5, Port: 5060},
+		{Name: "txt", Type: "TXT", Value: "v=spf1 -all", TTL: 300},
+	}
+	for _, record := range records {
+		created, err := provider.AddRecord(ctx, "example.com", record)
+		if err != nil {
+			t.Fatalf("添加%s记录失败: %v", record.Type, err)
+		}
+		got, err := provider.GetRecord(ctx, "example.com", created.ID)
+		if err != nil {
+			t.Fatalf("获取%s记录失败: %v", record.Type, err)
+		}
+		if got.Name != record.Name || got.Value != record.Value || got.Priority != record.Priority ||
+			got.Weight != record.Weight || got.Port != record.Port {
+			t.Fatalf("%s记录不一致: %+v", record.Type, got)
+		}
+	}
+}
+
+func TestHuaweiRecordStatus(t *testing.T) {
+	_, provider := newHuaweiFake(t)
+	ctx := context.Background()
+
+	single, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "api", Type: "A", Value: "192.0.2.9", TTL: 300})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+	if !SupportsRecordStatus(provider) {
+		t.Fatalf("华为云应支持暂停记录")
+	}
+
+	// 记录集只有一个记录值时使用记录集状态接口
+	if err := provider.SetRecordStatus(ctx, "example.com", single.ID, false); err != nil {
+		t.Fatalf("暂停记录失败: %v", err)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", single.ID); err != nil || RecordEnabled(*got) {
+		t.Fatalf("记录应处于暂停状态: %+v, %v", got, err)
+	}
+	if err := provider.SetRecordStatus(ctx, "example.com", single.ID, true); err != nil {
+		t.Fatalf("启用记录失败: %v", err)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", single.ID); err != nil || !RecordEnabled(*got) {
+		t.Fatalf("记录应处于启用状态: %+v, %v", got, err)
+	}
+
+	recordsetID, _, _ := strings.Cut(single.ID, "/")
+	if err := provider.SetRecordStatus(ctx, "example.com", recordsetID+"/missing", false); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("记录值不存在时应返回ErrRecordNotFound，实际为: %v", err)
+	}
+}
+
+func TestHuaweiRecordStatusMultiValue(t *testing.T) {
+	fake, provider := newHuaweiFake(t)
+	ctx := context.Background()
+
+	first, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+	second, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300})
+	if err != nil {
+		t.Fatalf("添加第二个记录值失败: %v", err)
+	}
+
+	// 记录集状态会影响同一记录集的其他记录值，多值记录集不能使用状态接口
+	if err := provider.SetRecordStatus(ctx, "example.com", first.ID, false); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("多值记录集应返回ErrUnsupported，实际为: %v", err)
+	}
+	if fake.statusCalls != 0 {
+		t.Fatalf("多值记录集不应调用状态接口，实际调用%d次", fake.statusCalls)
+	}
+	for _, id := range []string{first.ID, second.ID} {
+		if got, err := provider.GetRecord(ctx, "example.com", id); err != nil || !RecordEnabled(*got) {
+			t.Fatalf("记录%s应保持启用: %+v, %v", id, got, err)
+		}
+	}
+}
+
+func TestHuaweiErrorKinds(t *testing.T) {
+	_, provider := newHuaweiFake(t)
+	ctx := context.Background()
+
+	// 接口地址错误时网关返回APIGW.0101，属于配置错误而不是认证失败
+	_, err := provider.makeRequest(ctx, "GET", "/v2/unknown", nil, nil)
+	if !errors.Is(err, ErrInvalidConfig) || errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("APIGW.0101应归类为ErrInvalidConfig，实际为: %v", err)
+	}
+
+	provider.config.APISecret = "wrong-secret"
+	if err := provider.TestConnection(ctx); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("签名错误应返回ErrAuthFailed，实际为: %v", err)
+	}
+
+	if _, err := provider.ListRecords(ctx, "missing.com"); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("签名错误时不应返回域名不存在，实际为: %v", err)
+	}
+}
+
+func TestHuaweiZoneNotFound(t *testing.T) {
+	_, provider := newHuaweiFake(t)
+	if _, err := provider.VerifyZone(context.Background(), "missing.com"); !errors.Is(err, ErrZoneNotFound) {
+		t.Fatalf("未托管的域名应返回ErrZoneNotFound，实际为: %v", err)
+	}
+}
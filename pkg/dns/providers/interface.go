@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -33,6 +34,108 @@ type DNSProvider interface {
 	
 	// TestConnection 测试连接
 	TestConnection(ctx context.Context) error
+	
+	// ListZones 获取账号下托管的全部域名，分页由各服务商内部完成
+	ListZones(ctx context.Context) ([]Zone, error)
+}
+
+// ZoneVerifier 支持校验域名是否托管在服务商账号下的服务商
+type ZoneVerifier interface {
+	// VerifyZone 校验域名已托管，返回服务商侧的域名ID
+	VerifyZone(ctx context.Context, domain string) (string, error)
+}
+
+// LineLister 支持查询可用解析线路的服务商
+type LineLister interface {
+	// ListLines 获取域名可用的解析线路
+	ListLines(ctx context.Context, domain string) ([]Line, error)
+}
+
+// RecordFilter 记录查询条件，为空的字段不参与过滤
+type RecordFilter struct {
+	Name string `json:"name"` // 子域名，根域名使用"@"
+	Type string `json:"type"` // 记录类型
+}
+
+// FilteredRecordLister 支持在服务商侧按条件查询记录的服务商
+type FilteredRecordLister interface {
+	// ListRecordsFiltered 按条件获取域名记录列表
+	ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error)
+}
+
+// ListRecordsWithFilter 按条件获取域名记录，服务商不支持条件查询时在本地过滤
+func ListRecordsWithFilter(ctx context.Context, provider DNSProvider, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	if lister, ok := provider.(FilteredRecordLister); ok {
+		return lister.ListRecordsFiltered(ctx, domain, filter)
+	}
+	
+	records, err := provider.ListRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(records, filter), nil
+}
+
+// RecordReplacer 更新记录后记录ID可能发生变化的服务商
+type RecordReplacer interface {
+	// ReplaceRecord 更新DNS记录并返回更新后的记录（包含新的记录ID）
+	ReplaceRecord(ctx context.Context, domain string, recordID string, record DNSRecord) (*DNSRecord, error)
+}
+
+// UpdateRecordWithResult 更新DNS记录并返回更新后的记录，服务商记录ID不变时沿用原ID
+func UpdateRecordWithResult(ctx context.Context, provider DNSProvider, domain string, recordID string, record DNSRecord) (*DNSRecord, error) {
+	if replacer, ok := provider.(RecordReplacer); ok {
+		return replacer.ReplaceRecord(ctx, domain, recordID, record)
+	}
+	
+	if err := provider.UpdateRecord(ctx, domain, recordID, record); err != nil {
+		return nil, err
+	}
+	record.ID = recordID
+	return &record, nil
+}
+
+// RecordStatusSetter 支持暂停和启用记录的服务商，暂停的记录保留在服务商侧但不参与解析
+type RecordStatusSetter interface {
+	// SetRecordStatus 启用或暂停DNS记录
+	SetRecordStatus(ctx context.Context, domain string, recordID string, enabled bool) error
+}
+
+// SupportsRecordStatus 判断服务商是否支持暂停记录，已包装的实例按被包装的服务商判断
+func SupportsRecordStatus(provider DNSProvider) bool {
+	if resilient, ok := provider.(*ResilientProvider); ok {
+		provider = resilient.Unwrap()
+	}
+	_, ok := provider.(RecordStatusSetter)
+	return ok
+}
+
+// RecordEnabled 判断服务商返回的记录是否处于启用状态，各服务商的状态取值不同
+func RecordEnabled(record DNSRecord) bool {
+	switch strings.ToLower(record.Status) {
+	case "disable", "disabled", "inactive", "paused":
+		return false
+	}
+	return true
+}
+
+// filterRecords 在本地按条件过滤记录
+func filterRecords(records []DNSRecord, filter RecordFilter) []DNSRecord {
+	if filter.Name == "" && filter.Type == "" {
+		return records
+	}
+	
+	var matched []DNSRecord
+	for _, record := range records {
+		if filter.Name != "" && !strings.EqualFold(record.Name, filter.Name) {
+			continue
+		}
+		if filter.Type != "" && !strings.EqualFold(record.Type, filter.Type) {
+			continue
+		}
+		matched = append(matched, record)
+	}
+	return matched
 }
 
 // DNSRecord DNS记录结构
@@ -47,6 +150,25 @@ type DNSRecord struct {
 	Port     int    `json:"port"`     // SRV记录端口
 	Line     string `json:"line"`     // 解析线路
 	Status   string `json:"status"`   // 记录状态
+	
+	// Extra 服务商特有的记录属性，如Route 53的别名和加权路由配置
+	Extra map[string]string `json:"extra,omitempty"`
+}
+
+// Zone 服务商托管的域名
+type Zone struct {
+	ID          string `json:"id"`           // 服务商侧的域名ID
+	Name        string `json:"name"`         // 域名名称
+	Status      string `json:"status"`       // 域名状态
+	RecordCount int    `json:"record_count"` // 记录数量，服务商不提供时为0
+}
+
+// Line 服务商的解析线路
+type Line struct {
+	ID    string `json:"id"`    // 服务商侧的线路ID
+	Code  string `json:"code"`  // 线路代码
+	Name  string `json:"name"`  // 线路名称
+	Group string `json:"group"` // 所属线路分组，顶级线路为空
 }
 
 // ProviderConfig DNS服务商配置
@@ -75,20 +197,6 @@ var DefaultRetryConfig = RetryConfig{
 	BackoffFactor: 2.0,
 }
 
-// SupportedProviders 支持的DNS服务商列表
-var SupportedProviders = []string{
-	"aliyun",
-	"dnspod",
-	"huawei",
-	"baidu",
-	"west",
-	"volcengine",
-	"dnsla",
-	"cloudflare",
-	"namesilo",
-	"powerdns",
-}
-
 // ProviderFeatures DNS服务商功能特性
 type ProviderFeatures struct {
 	SupportedRecordTypes []string `json:"supported_record_types"`
@@ -97,39 +205,14 @@ type ProviderFeatures struct {
 	MaxRecordsPerDomain  int      `json:"max_records_per_domain"`
 	MinTTL               int      `json:"min_ttl"`
 	MaxTTL               int      `json:"max_ttl"`
+	RateLimit            float64  `json:"rate_limit"` // 服务商公布的每秒请求数限制，0为不限制
+	RateBurst            int      `json:"rate_burst"` // 允许的突发请求数
 }
 
-// GetProviderFeatures 获取服务商功能特性
+// GetProviderFeatures 获取服务商功能特性，未注册的服务商返回默认功能特性
 func GetProviderFeatures(providerType string) ProviderFeatures {
-	features := map[string]ProviderFeatures{
-		"aliyun": {
-			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
-			SupportsBatch:        true,
-			SupportsLineTypes:    true,
-			MaxRecordsPerDomain:  10000,
-			MinTTL:               1,
-			MaxTTL:               604800,
-		},
-		"dnspod": {
-			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV"},
-			SupportsBatch:        true,
-			SupportsLineTypes:    true,
-			MaxRecordsPerDomain:  10000,
-			MinTTL:               1,
-			MaxTTL:               604800,
-		},
-		"cloudflare": {
-			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
-			SupportsBatch:        false,
-			SupportsLineTypes:    false,
-			MaxRecordsPerDomain:  20000,
-			MinTTL:               60,
-			MaxTTL:               604800,
-		},
-	}
-	
-	if feature, exists := features[providerType]; exists {
-		return feature
+	if definition, exists := Lookup(providerType); exists {
+		return definition.Features
 	}
 	
 	// 默认功能特性
@@ -140,5 +223,7 @@ func GetProviderFeatures(providerType string) ProviderFeatures {
 		MaxRecordsPerDomain:  1000,
 		MinTTL:               300,
 		MaxTTL:               86400,
+		RateLimit:            5,
+		RateBurst:            5,
 	}
 }
\ No newline at end of file
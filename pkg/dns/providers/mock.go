@@ -0,0 +1,626 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockProvider 模拟DNS服务商，记录保存在内存或JSON文件中，用于本地开发、演示和前端联调
+// ExtraParams支持以下参数：
+//   - store_file: JSON文件路径，为空时记录只保存在内存中，服务重启后丢失
+//   - zones: 初始托管的域名，多个以逗号分隔，默认为example.com
+//   - auto_create_zone: 为true时校验域名发现不存在会自动创建
+//   - latency_ms: 每次调用的模拟延迟（毫秒）
+//   - failure_percent: 调用失败的概率（0-100）
+//   - failure_error: 模拟失败的错误类型，server、rate_limit、auth或timeout，默认为server
+//   - failure_operations: 注入失败的操作，多个以逗号分隔，为空时所有操作都可能失败
+type MockProvider struct {
+	config         ProviderConfig
+	store          *mockStore
+	autoCreateZone bool
+	latency        time.Duration
+	failurePercent int
+	failureError   string
+	failureOps     map[string]bool
+}
+
+// mockZone 模拟服务商托管的域名
+type mockZone struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Records []DNSRecord `json:"records"`
+}
+
+// mockStore 模拟服务商的数据，内存存储按账号共享，文件存储按文件路径共享
+type mockStore struct {
+	mu     sync.Mutex
+	path   string
+	NextID int64                `json:"next_id"`
+	Zones  map[string]*mockZone `json:"zones"`
+
+	faults []string // InjectFault注入的待返回错误，不保存到文件
+}
+
+// mockStores 已打开的模拟数据，按账号标识或文件路径共享，实例重建后数据仍然保留
+var mockStores sync.Map
+
+// mockLines 模拟服务商支持的解析线路
+var mockLines = []Line{
+	{ID: "default", Code: "default", Name: "默认"},
+	{ID: "telecom", Code: "telecom", Name: "电信"},
+	{ID: "unicom", Code: "unicom", Name: "联通"},
+	{ID: "mobile", Code: "mobile", Name: "移动"},
+	{ID: "oversea", Code: "oversea", Name: "境外"},
+}
+
+// init 注册模拟DNS服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "mock",
+		DisplayName: "模拟DNS",
+		Description: "数据保存在本地的模拟服务商，用于开发和演示",
+		SortOrder:   100,
+		Development: true,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "PTR"},
+			SupportsBatch:        true,
+			SupportsLineTypes:    true,
+			MaxRecordsPerDomain:  10000,
+			MinTTL:               1,
+			MaxTTL:               86400,
+		},
+		ConfigSchema: []ConfigField{
+			{Key: "api_key", Label: "账号标识", Type: FieldText, Default: "default",
+				Help: "相同账号标识的凭据共享同一份内存数据"},
+			{Key: "store_file", Label: "数据文件", Type: FieldText, Placeholder: "data/mock-dns.json",
+				Help: "填写后记录保存到JSON文件，服务重启后保留"},
+			{Key: "zones", Label: "托管域名", Type: FieldText, Default: "example.com",
+				Help: "初始托管的域名，多个以逗号分隔"},
+			{Key: "auto_create_zone", Label: "自动创建域名", Type: FieldBoolean, Default: "false"},
+			{Key: "latency_ms", Label: "模拟延迟（毫秒）", Type: FieldNumber, Default: "0", Min: intPtr(0), Max: intPtr(60000)},
+			{Key: "failure_percent", Label: "失败概率（%）", Type: FieldNumber, Default: "0", Min: intPtr(0), Max: intPtr(100)},
+			{Key: "failure_error", Label: "失败类型", Type: FieldSelect, Default: "server",
+				Options: []string{"server", "rate_limit", "auth", "timeout"}},
+			{Key: "failure_operations", Label: "注入失败的操作", Type: FieldText, Placeholder: "AddRecord,UpdateRecord",
+				Help: "为空时所有操作都可能失败"},
+		},
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewMockProvider(config)
+		},
+	})
+}
+
+// NewMockProvider 创建模拟DNS服务商实例
+func NewMockProvider(config ProviderConfig) (*MockProvider, error) {
+	params := config.ExtraParams
+
+	provider := &MockProvider{
+		config:         config,
+		autoCreateZone: params["auto_create_zone"] == "true",
+		failureError:   params["failure_error"],
+		failureOps:     make(map[string]bool),
+	}
+	if provider.failureError == "" {
+		provider.failureError = "server"
+	}
+	if value := params["latency_ms"]; value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("无效的模拟延迟: %s", value)
+		}
+		provider.latency = time.Duration(ms) * time.Millisecond
+	}
+	if value := params["failure_percent"]; value != "" {
+		percent, err := strconv.Atoi(value)
+		if err != nil || percent < 0 || percent > 100 {
+			return nil, fmt.Errorf("无效的失败概率: %s", value)
+		}
+		provider.failurePercent = percent
+	}
+	for _, operation := range strings.Split(params["failure_operations"], ",") {
+		if operation = strings.TrimSpace(operation); operation != "" {
+			provider.failureOps[operation] = true
+		}
+	}
+
+	zones := params["zones"]
+	if zones == "" {
+		zones = "example.com"
+	}
+	store, err := openMockStore(config, strings.Split(zones, ","))
+	if err != nil {
+		return nil, err
+	}
+	provider.store = store
+	return provider, nil
+}
+
+// openMockStore 打开模拟数据并补充配置中的域名，文件存储首次打开时从文件加载
+func openMockStore(config ProviderConfig, zones []string) (*mockStore, error) {
+	store, err := loadMockStore(config)
+	if err != nil {
+		return nil, err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	changed := false
+	for _, zone := range zones {
+		if zone = normalizeMockDomain(zone); zone != "" && store.Zones[zone] == nil {
+			store.createZone(zone)
+			changed = true
+		}
+	}
+	if changed {
+		if err := store.save(); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// loadMockStore 获取已打开的模拟数据，未打开时从文件加载或创建内存存储
+func loadMockStore(config ProviderConfig) (*mockStore, error) {
+	key := "memory:" + config.APIKey
+	path := config.ExtraParams["store_file"]
+	if path != "" {
+		absolute, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("无效的数据文件路径: %w", err)
+		}
+		path = absolute
+		key = "file:" + path
+	}
+
+	if existing, ok := mockStores.Load(key); ok {
+		return existing.(*mockStore), nil
+	}
+
+	store := &mockStore{path: path}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("读取数据文件失败: %w", err)
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, store); err != nil {
+				return nil, fmt.Errorf("解析数据文件失败: %w", err)
+			}
+		}
+	}
+	if store.Zones == nil {
+		store.Zones = make(map[string]*mockZone)
+	}
+
+	actual, _ := mockStores.LoadOrStore(key, store)
+	return actual.(*mockStore), nil
+}
+
+// createZone 创建域名，调用方需要持有锁
+func (s *mockStore) createZone(name string) *mockZone {
+	s.NextID++
+	zone := &mockZone{ID: "zone-" + strconv.FormatInt(s.NextID, 10), Name: name}
+	s.Zones[name] = zone
+	return zone
+}
+
+// nextRecordID 生成记录ID，调用方需要持有锁
+func (s *mockStore) nextRecordID() string {
+	s.NextID++
+	return strconv.FormatInt(s.NextID, 10)
+}
+
+// save 将数据写入文件，内存存储不做处理，调用方需要持有锁
+func (s *mockStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化模拟数据失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("创建数据目录失败: %w", err)
+	}
+
+	// 先写入临时文件再替换，避免写入中断导致数据文件损坏
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("写入数据文件失败: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("写入数据文件失败: %w", err)
+	}
+	return nil
+}
+
+// normalizeMockDomain 统一域名格式
+func normalizeMockDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// mockError 构建模拟服务商的错误
+func mockError(kind error, code, message string) *ProviderError {
+	return &ProviderError{Provider: "模拟DNS", Kind: kind, Code: code, Message: message}
+}
+
+// GetName 获取服务商名称
+func (p *MockProvider) GetName() string {
+	return "mock"
+}
+
+// ValidateConfig 验证API配置
+func (p *MockProvider) ValidateConfig() error {
+	switch p.failureError {
+	case "server", "rate_limit", "auth", "timeout":
+		return nil
+	}
+	return fmt.Errorf("不支持的失败类型: %s", p.failureError)
+}
+
+// simulate 模拟网络延迟并按配置注入失败
+func (p *MockProvider) simulate(ctx context.Context, operation string) error {
+	if p.latency > 0 {
+		if err := sleepContext(ctx, p.latency); err != nil {
+			return fmt.Errorf("发起请求失败: %w", err)
+		}
+	}
+
+	if kind := p.store.takeFault(); kind != "" {
+		return mockFailure(operation, kind)
+	}
+
+	if p.failurePercent == 0 || (len(p.failureOps) > 0 && !p.failureOps[operation]) {
+		return nil
+	}
+	if rand.IntN(100) >= p.failurePercent {
+		return nil
+	}
+	return mockFailure(operation, p.failureError)
+}
+
+// InjectFault 使下一次调用返回指定类型的错误，多次调用时按顺序依次生效
+// kind与failure_error的取值相同，注入的错误保存在模拟数据中，同一账号的实例共享
+func (p *MockProvider) InjectFault(kind string) {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	p.store.faults = append(p.store.faults, kind)
+}
+
+// takeFault 取出下一个注入的错误，没有时返回空字符串
+func (s *mockStore) takeFault() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.faults) == 0 {
+		return ""
+	}
+	kind := s.faults[0]
+	s.faults = s.faults[1:]
+	return kind
+}
+
+// mockFailure 构建模拟失败的错误
+func mockFailure(operation, kind string) error {
+	switch kind {
+	case "rate_limit":
+		err := mockError(ErrRateLimited, "Throttling", operation+"请求频率超限")
+		err.StatusCode = 429
+		err.RetryAfter = time.Second
+		return err
+	case "auth":
+		err := mockError(ErrAuthFailed, "InvalidAccessKey", "模拟凭据无效")
+		err.StatusCode = 401
+		return err
+	case "timeout":
+		return fmt.Errorf("发起请求失败: %s超时: %w", operation, context.DeadlineExceeded)
+	default:
+		err := mockError(nil, "InternalError", operation+"模拟服务端错误")
+		err.StatusCode = 503
+		err.Temporary = true
+		return err
+	}
+}
+
+// zone 获取域名，autoCreate为true且配置了auto_create_zone时自动创建，调用方需要持有锁
+func (p *MockProvider) zone(domain string, autoCreate bool) (*mockZone, error) {
+	name := normalizeMockDomain(domain)
+	if zone := p.store.Zones[name]; zone != nil {
+		return zone, nil
+	}
+	if autoCreate && p.autoCreateZone {
+		return p.store.createZone(name), nil
+	}
+	return nil, mockError(ErrZoneNotFound, "ZoneNotFound", "域名不存在: "+domain)
+}
+
+// TestConnection 测试连接
+func (p *MockProvider) TestConnection(ctx context.Context) error {
+	return p.simulate(ctx, "TestConnection")
+}
+
+// ListZones 获取账号下托管的全部域名
+func (p *MockProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	if err := p.simulate(ctx, "ListZones"); err != nil {
+		return nil, err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	zones := make([]Zone, 0, len(p.store.Zones))
+	for _, zone := range p.store.Zones {
+		zones = append(zones, Zone{
+			ID:          zone.ID,
+			Name:        zone.Name,
+			Status:      "active",
+			RecordCount: len(zone.Records),
+		})
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Name < zones[j].Name
+	})
+	return zones, nil
+}
+
+// VerifyZone 校验域名已托管，配置了auto_create_zone时自动创建不存在的域名
+func (p *MockProvider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	if err := p.simulate(ctx, "VerifyZone"); err != nil {
+		return "", err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	zone, err := p.zone(domain, true)
+	if err != nil {
+		return "", err
+	}
+	if err := p.store.save(); err != nil {
+		return "", err
+	}
+	return zone.ID, nil
+}
+
+// ListLines 获取域名可用的解析线路
+func (p *MockProvider) ListLines(ctx context.Context, domain string) ([]Line, error) {
+	if err := p.simulate(ctx, "ListLines"); err != nil {
+		return nil, err
+	}
+	return append([]Line(nil), mockLines...), nil
+}
+
+// ListRecords 获取域名记录列表
+func (p *MockProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	if err := p.simulate(ctx, "ListRecords"); err != nil {
+		return nil, err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	zone, err := p.zone(domain, false)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]DNSRecord, len(zone.Records))
+	for i, record := range zone.Records {
+		records[i] = copyMockRecord(record)
+	}
+	return records, nil
+}
+
+// GetRecord 获取单个记录详情
+func (p *MockProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	if err := p.simulate(ctx, "GetRecord"); err != nil {
+		return nil, err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	zone, err := p.zone(domain, false)
+	if err != nil {
+		return nil, err
+	}
+	index := findMockRecord(zone, recordID)
+	if index < 0 {
+		return nil, mockError(ErrRecordNotFound, "RecordNotFound", "记录不存在: "+recordID)
+	}
+
+	record := copyMockRecord(zone.Records[index])
+	return &record, nil
+}
+
+// AddRecord 添加DNS记录
+func (p *MockProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	created, err := p.addRecords(ctx, "AddRecord", domain, []DNSRecord{record})
+	if err != nil {
+		return nil, err
+	}
+	return &created[0], nil
+}
+
+// BatchAddRecords 批量添加DNS记录，任意一条记录冲突时全部不添加
+func (p *MockProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	return p.addRecords(ctx, "BatchAddRecords", domain, records)
+}
+
+// addRecords 添加记录，operation用于按操作注入失败
+func (p *MockProvider) addRecords(ctx context.Context, operation, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	if err := p.simulate(ctx, operation); err != nil {
+		return nil, err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	zone, err := p.zone(domain, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := append([]DNSRecord(nil), zone.Records...)
+	created := make([]DNSRecord, 0, len(records))
+	for _, record := range records {
+		record = normalizeMockRecord(record)
+		if err := checkMockConflict(pending, record, ""); err != nil {
+			return nil, err
+		}
+		record.ID = p.store.nextRecordID()
+		pending = append(pending, record)
+		created = append(created, copyMockRecord(record))
+	}
+
+	zone.Records = pending
+	if err := p.store.save(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateRecord 更新DNS记录
+func (p *MockProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	if err := p.simulate(ctx, "UpdateRecord"); err != nil {
+		return err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	zone, err := p.zone(domain, false)
+	if err != nil {
+		return err
+	}
+	index := findMockRecord(zone, recordID)
+	if index < 0 {
+		return mockError(ErrRecordNotFound, "RecordNotFound", "记录不存在: "+recordID)
+	}
+
+	record = normalizeMockRecord(record)
+	if err := checkMockConflict(zone.Records, record, recordID); err != nil {
+		return err
+	}
+	record.ID = recordID
+	record.Status = zone.Records[index].Status
+	zone.Records[index] = record
+	return p.store.save()
+}
+
+// SetRecordStatus 启用或暂停DNS记录
+func (p *MockProvider) SetRecordStatus(ctx context.Context, domain string, recordID string, enabled bool) error {
+	if err := p.simulate(ctx, "SetRecordStatus"); err != nil {
+		return err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	zone, err := p.zone(domain, false)
+	if err != nil {
+		return err
+	}
+	index := findMockRecord(zone, recordID)
+	if index < 0 {
+		return mockError(ErrRecordNotFound, "RecordNotFound", "记录不存在: "+recordID)
+	}
+
+	zone.Records[index].Status = "inactive"
+	if enabled {
+		zone.Records[index].Status = "active"
+	}
+	return p.store.save()
+}
+
+// DeleteRecord 删除DNS记录
+func (p *MockProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	if err := p.simulate(ctx, "DeleteRecord"); err != nil {
+		return err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+
+	zone, err := p.zone(domain, false)
+	if err != nil {
+		return err
+	}
+	index := findMockRecord(zone, recordID)
+	if index < 0 {
+		return mockError(ErrRecordNotFound, "RecordNotFound", "记录不存在: "+recordID)
+	}
+
+	zone.Records = append(zone.Records[:index], zone.Records[index+1:]...)
+	return p.store.save()
+}
+
+// findMockRecord 查找记录所在的位置，不存在时返回-1
+func findMockRecord(zone *mockZone, recordID string) int {
+	for i, record := range zone.Records {
+		if record.ID == recordID {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeMockRecord 补全记录的默认值
+func normalizeMockRecord(record DNSRecord) DNSRecord {
+	record.Type = strings.ToUpper(record.Type)
+	if record.Name == "" {
+		record.Name = "@"
+	}
+	if record.TTL == 0 {
+		record.TTL = 600
+	}
+	if record.Line == "" {
+		record.Line = "default"
+	}
+	record.Status = "active"
+	return copyMockRecord(record)
+}
+
+// copyMockRecord 复制记录，避免调用方修改存储中的Extra
+func copyMockRecord(record DNSRecord) DNSRecord {
+	if record.Extra != nil {
+		extra := make(map[string]string, len(record.Extra))
+		for key, value := range record.Extra {
+			extra[key] = value
+		}
+		record.Extra = extra
+	}
+	return record
+}
+
+// checkMockConflict 检查记录是否与已有记录冲突，excludeID为正在更新的记录
+// 相同线路下的相同记录重复，或CNAME与同名的其他记录共存时视为冲突
+func checkMockConflict(records []DNSRecord, record DNSRecord, excludeID string) error {
+	for _, existing := range records {
+		if existing.ID == excludeID || !strings.EqualFold(existing.Name, record.Name) || existing.Line != record.Line {
+			continue
+		}
+		if existing.Type == record.Type && existing.Value == record.Value {
+			return mockError(ErrRecordConflict, "DomainRecordDuplicate",
+				fmt.Sprintf("记录已存在: %s %s %s", record.Name, record.Type, record.Value))
+		}
+		if existing.Type != record.Type && (existing.Type == "CNAME" || record.Type == "CNAME") {
+			return mockError(ErrRecordConflict, "DomainRecordConflict",
+				fmt.Sprintf("CNAME记录不能与同名的其他记录共存: %s", record.Name))
+		}
+	}
+	return nil
+}
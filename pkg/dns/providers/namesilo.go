@@ -0,0 +1,456 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NamesiloProvider Namesilo域名DNS服务商
+// Namesilo的接口无论成功与否都返回HTTP 200，需要根据reply.code判断结果；
+// 更新记录后记录ID会发生变化，适配器通过ReplaceRecord返回新的记录ID
+type NamesiloProvider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	endpoint   string
+	limiter    *namesiloLimiter
+}
+
+// Namesilo接口返回码
+const (
+	NamesiloCodeSuccess         = 300
+	NamesiloCodeInvalidAPIKey   = 110
+	NamesiloCodeSubAccount      = 112
+	NamesiloCodeIPNotAllowed    = 113
+	NamesiloCodeInvalidDomain   = 114
+	NamesiloCodeDomainNotActive = 200
+	NamesiloCodeInternalError   = 201
+	NamesiloCodeGeneralError    = 210
+	NamesiloCodeDNSModifyError  = 280
+)
+
+// namesiloErrorKinds Namesilo返回码对应的错误分类
+var namesiloErrorKinds = map[int]error{
+	109:                         ErrAuthFailed,
+	NamesiloCodeInvalidAPIKey:   ErrAuthFailed,
+	111:                         ErrAuthFailed,
+	NamesiloCodeSubAccount:      ErrAuthFailed,
+	NamesiloCodeIPNotAllowed:    ErrAuthFailed,
+	NamesiloCodeInvalidDomain:   ErrZoneNotFound,
+	NamesiloCodeDomainNotActive: ErrZoneNotFound,
+}
+
+// namesiloError 根据返回码构建服务商错误
+func namesiloError(operation string, code int, detail string) *ProviderError {
+	return &ProviderError{
+		Provider:  "Namesilo",
+		Kind:      namesiloErrorKinds[code],
+		Code:      strconv.Itoa(code),
+		Message:   operation + ": " + detail,
+		Temporary: code == 115 || code == NamesiloCodeInternalError,
+	}
+}
+
+// namesiloLimiter 按账号限制请求间隔
+type namesiloLimiter struct {
+	mu       sync.Mutex
+	next     time.Time
+	interval time.Duration
+}
+
+// namesiloLimiters 按API Key共享的限流器，NameSilo按账号限制请求频率，使用同一API Key的凭据和重建后的实例共用限流状态
+var namesiloLimiters sync.Map
+
+// wait 等待到允许发起下一次请求
+func (l *namesiloLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// init 注册Namesilo服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "namesilo",
+		DisplayName: "Namesilo",
+		Description: "Namesilo域名DNS服务",
+		SortOrder:   9,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "SRV", "CAA"},
+			SupportsBatch:        false,
+			SupportsLineTypes:    false,
+			MaxRecordsPerDomain:  1000,
+			MinTTL:               3600,
+			MaxTTL:               2592001,
+			RateLimit:            1,
+			RateBurst:            1,
+		},
+		ConfigSchema: []ConfigField{
+			{Key: "api_key", Label: "API Key", Type: FieldText, Secret: true, Required: true,
+				Help: "在Namesilo账号的API Manager中生成"},
+			{Key: "min_interval_ms", Label: "请求最小间隔（毫秒）", Type: FieldNumber, Default: "1000", Min: intPtr(0),
+				Help: "同一账号两次请求之间的最小间隔，Namesilo限制每秒1次请求"},
+		},
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewNamesiloProvider(config)
+		},
+	})
+}
+
+// NewNamesiloProvider 创建Namesilo服务商实例
+// ExtraParams中的min_interval_ms可调整同一账号两次请求的最小间隔，默认1000毫秒
+func NewNamesiloProvider(config ProviderConfig) (*NamesiloProvider, error) {
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("Namesilo需要API Key")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://www.namesilo.com/api"
+	}
+
+	interval := time.Second
+	if ms, err := strconv.Atoi(config.ExtraParams["min_interval_ms"]); err == nil && ms >= 0 {
+		interval = time.Duration(ms) * time.Millisecond
+	}
+
+	limiter, _ := namesiloLimiters.LoadOrStore(config.APIKey, &namesiloLimiter{interval: interval})
+
+	return &NamesiloProvider{
+		config:   config,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		limiter:  limiter.(*namesiloLimiter),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetName 获取服务商名称
+func (p *NamesiloProvider) GetName() string {
+	return "namesilo"
+}
+
+// ValidateConfig 验证API配置
+func (p *NamesiloProvider) ValidateConfig() error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("Namesilo API Key不能为空")
+	}
+	return nil
+}
+
+// TestConnection 测试连接
+func (p *NamesiloProvider) TestConnection(ctx context.Context) error {
+	_, err := p.makeRequest(ctx, "listDomains", nil)
+	return err
+}
+
+// ListZones 获取账号下的全部域名
+func (p *NamesiloProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	response, err := p.makeRequest(ctx, "listDomains", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Domains struct {
+			Domain namesiloList[string] `json:"domain"`
+		} `json:"domains"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	zones := make([]Zone, 0, len(result.Domains.Domain))
+	for _, domain := range result.Domains.Domain {
+		zones = append(zones, Zone{
+			ID:     domain,
+			Name:   domain,
+			Status: "active",
+		})
+	}
+	return zones, nil
+}
+
+// namesiloRecord Namesilo API返回的记录
+type namesiloRecord struct {
+	RecordID string          `json:"record_id"`
+	Type     string          `json:"type"`
+	Host     string          `json:"host"`
+	Value    string          `json:"value"`
+	TTL      json.Number     `json:"ttl"`
+	Distance json.RawMessage `json:"distance"`
+}
+
+// toDNSRecord 转换为通用DNS记录，主机名由完整域名转换为子域名
+func (r namesiloRecord) toDNSRecord(domain string) DNSRecord {
+	name := strings.TrimSuffix(r.Host, ".")
+	if name == domain {
+		name = "@"
+	} else {
+		name = strings.TrimSuffix(name, "."+domain)
+	}
+
+	ttl, _ := r.TTL.Int64()
+	priority, _ := strconv.Atoi(strings.Trim(string(r.Distance), `"`))
+
+	return DNSRecord{
+		ID:       r.RecordID,
+		Name:     name,
+		Type:     r.Type,
+		Value:    r.Value,
+		TTL:      int(ttl),
+		Priority: priority,
+		Status:   "active",
+	}
+}
+
+// ListRecords 获取域名记录列表
+func (p *NamesiloProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	response, err := p.makeRequest(ctx, "dnsListRecords", url.Values{"domain": {domain}})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ResourceRecord namesiloList[namesiloRecord] `json:"resource_record"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	records := make([]DNSRecord, 0, len(result.ResourceRecord))
+	for _, record := range result.ResourceRecord {
+		records = append(records, record.toDNSRecord(domain))
+	}
+	return records, nil
+}
+
+// AddRecord 添加DNS记录
+func (p *NamesiloProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	params := namesiloRecordParams(domain, record)
+	params.Set("rrtype", strings.ToUpper(record.Type))
+
+	response, err := p.makeRequest(ctx, "dnsAddRecord", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return namesiloResultRecord(response, record)
+}
+
+// UpdateRecord 更新DNS记录
+func (p *NamesiloProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	_, err := p.ReplaceRecord(ctx, domain, recordID, record)
+	return err
+}
+
+// ReplaceRecord 更新DNS记录并返回新的记录，Namesilo更新后会分配新的记录ID
+func (p *NamesiloProvider) ReplaceRecord(ctx context.Context, domain string, recordID string, record DNSRecord) (*DNSRecord, error) {
+	params := namesiloRecordParams(domain, record)
+	params.Set("rrid", recordID)
+
+	response, err := p.makeRequest(ctx, "dnsUpdateRecord", params)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := namesiloResultRecord(response, record)
+	if err != nil {
+		return nil, err
+	}
+	if updated.ID == "" {
+		updated.ID = recordID
+	}
+	return updated, nil
+}
+
+// DeleteRecord 删除DNS记录
+func (p *NamesiloProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	_, err := p.makeRequest(ctx, "dnsDeleteRecord", url.Values{
+		"domain": {domain},
+		"rrid":   {recordID},
+	})
+	return err
+}
+
+// GetRecord 获取单个记录详情，Namesilo没有单条查询接口，通过记录列表查找
+func (p *NamesiloProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	records, err := p.ListRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.ID == recordID {
+			return &record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrRecordNotFound, recordID)
+}
+
+// BatchAddRecords 批量添加DNS记录，受账号限流影响逐条提交
+func (p *NamesiloProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	var results []DNSRecord
+	var errors []error
+
+	for _, record := range records {
+		result, err := p.AddRecord(ctx, domain, record)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	if len(errors) > 0 {
+		return results, fmt.Errorf("批量添加记录时发生错误: %v", errors)
+	}
+
+	return results, nil
+}
+
+// makeRequest 发起API请求，返回reply内容；reply.code不为300时返回ProviderError
+func (p *NamesiloProvider) makeRequest(ctx context.Context, operation string, params url.Values) ([]byte, error) {
+	if err := p.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("version", "1")
+	query.Set("type", "json")
+	query.Set("key", p.config.APIKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.endpoint+"/"+operation+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发起请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newStatusError("Namesilo", resp, string(body))
+	}
+
+	var result struct {
+		Reply json.RawMessage `json:"reply"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || len(result.Reply) == 0 {
+		return nil, fmt.Errorf("解析响应失败: %s", string(body))
+	}
+
+	var reply struct {
+		Code   json.Number `json:"code"`
+		Detail string      `json:"detail"`
+	}
+	if err := json.Unmarshal(result.Reply, &reply); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	code, _ := reply.Code.Int64()
+	if code != NamesiloCodeSuccess {
+		return nil, namesiloError(operation, int(code), reply.Detail)
+	}
+
+	return result.Reply, nil
+}
+
+// namesiloList Namesilo由XML转换而来的JSON在只有一个元素时返回对象而非数组，统一解析为切片
+type namesiloList[T any] []T
+
+// UnmarshalJSON 兼容单个对象和数组两种格式
+func (l *namesiloList[T]) UnmarshalJSON(data []byte) error {
+	data = []byte(strings.TrimSpace(string(data)))
+	if len(data) == 0 || string(data) == "null" || string(data) == `""` {
+		*l = nil
+		return nil
+	}
+
+	if data[0] == '[' {
+		var items []T
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		*l = items
+		return nil
+	}
+
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+	*l = []T{item}
+	return nil
+}
+
+// namesiloRecordParams 构建添加和更新记录的公共参数
+func namesiloRecordParams(domain string, record DNSRecord) url.Values {
+	host := record.Name
+	if host == "@" {
+		host = ""
+	}
+
+	params := url.Values{
+		"domain":  {domain},
+		"rrhost":  {host},
+		"rrvalue": {record.Value},
+		"rrttl":   {strconv.Itoa(record.TTL)},
+	}
+	if strings.ToUpper(record.Type) == "MX" {
+		params.Set("rrdistance", strconv.Itoa(record.Priority))
+	}
+	return params
+}
+
+// namesiloResultRecord 从写接口的响应中读取记录ID并构建记录
+func namesiloResultRecord(response []byte, record DNSRecord) (*DNSRecord, error) {
+	var result struct {
+		RecordID string `json:"record_id"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	created := record
+	created.ID = result.RecordID
+	created.Status = "active"
+	return &created, nil
+}
@@ -0,0 +1,223 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// namesiloFake Namesilo接口的内存实现，HTTP状态码始终为200，结果由reply.code表示
+type namesiloFake struct {
+	mu        sync.Mutex
+	apiKey    string
+	records   []namesiloRecord
+	nextID    int
+	replyCode int // 不为0时所有请求返回该错误码
+	requests  []time.Time
+}
+
+// newNamesiloFake 创建模拟服务器，API Key包含测试名称，避免不同测试共用按API Key共享的限流器，测试结束后删除限流器
+func newNamesiloFake(t *testing.T, intervalMS string) (*namesiloFake, *NamesiloProvider) {
+	t.Helper()
+	fake := &namesiloFake{apiKey: "namesilo-" + t.Name()}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { namesiloLimiters.Delete(fake.apiKey) })
+
+	provider, err := NewNamesiloProvider(ProviderConfig{
+		APIKey:      fake.apiKey,
+		Endpoint:    server.URL + "/api",
+		ExtraParams: map[string]string{"min_interval_ms": intervalMS},
+	})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	return fake, provider
+}
+
+func (f *namesiloFake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, time.Now())
+
+	query := r.URL.Query()
+	switch {
+	case f.replyCode != 0:
+		writeNamesiloReply(w, map[string]interface{}{"code": f.replyCode, "detail": "injected error"})
+		return
+	case query.Get("key") != f.apiKey:
+		writeNamesiloReply(w, map[string]interface{}{"code": NamesiloCodeInvalidAPIKey, "detail": "Invalid API Key"})
+		return
+	case query.Get("type") != "json" || query.Get("version") != "1":
+		writeNamesiloReply(w, map[string]interface{}{"code": 104, "detail": "Invalid API type"})
+		return
+	}
+	if domain := query.Get("domain"); domain != "" && domain != "example.com" {
+		writeNamesiloReply(w, map[string]interface{}{"code": NamesiloCodeInvalidDomain, "detail": "Invalid domain syntax or domain not in account"})
+		return
+	}
+
+	host := "example.com"
+	if rrhost := query.Get("rrhost"); rrhost != "" {
+		host = rrhost + ".example.com"
+	}
+
+	switch strings.TrimPrefix(r.URL.Path, "/api/") {
+	case "listDomains":
+		// 只有一个域名时返回对象而不是数组
+		writeNamesiloReply(w, map[string]interface{}{"code": "300", "detail": "success", "domains": map[string]string{"domain": "example.com"}})
+	case "dnsListRecords":
+		var list interface{} = f.records
+		if len(f.records) == 1 {
+			list = f.records[0]
+		}
+		writeNamesiloReply(w, map[string]interface{}{"code": 300, "detail": "success", "resource_record": list})
+	case "dnsAddRecord":
+		f.nextID++
+		id := fmt.Sprintf("rr%d", f.nextID)
+		f.records = append(f.records, namesiloRecord{RecordID: id, Type: query.Get("rrtype"), Host: host, Value: query.Get("rrvalue"), TTL: json.Number(query.Get("rrttl"))})
+		writeNamesiloReply(w, map[string]interface{}{"code": 300, "detail": "success", "record_id": id})
+	case "dnsUpdateRecord":
+		for i := range f.records {
+			if f.records[i].RecordID == query.Get("rrid") {
+				// 更新后分配新的记录ID
+				f.nextID++
+				f.records[i].RecordID = fmt.Sprintf("rr%d", f.nextID)
+				f.records[i].Host = host
+				f.records[i].Value = query.Get("rrvalue")
+				f.records[i].TTL = json.Number(query.Get("rrttl"))
+				writeNamesiloReply(w, map[string]interface{}{"code": 300, "detail": "success", "record_id": f.records[i].RecordID})
+				return
+			}
+		}
+		writeNamesiloReply(w, map[string]interface{}{"code": NamesiloCodeDNSModifyError, "detail": "Invalid rrid"})
+	default:
+		writeNamesiloReply(w, map[string]interface{}{"code": 102, "detail": "Invalid operation"})
+	}
+}
+
+func writeNamesiloReply(w http.ResponseWriter, reply map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"request": map[string]string{"operation": "test", "ip": "127.0.0.1"},
+		"reply":   reply,
+	})
+}
+
+func TestNamesiloReplyCodes(t *testing.T) {
+	fake, provider := newNamesiloFake(t, "0")
+	ctx := context.Background()
+
+	tests := []struct {
+		code      int
+		kind      error
+		temporary bool
+	}{
+		{109, ErrAuthFailed, false},
+		{NamesiloCodeInvalidAPIKey, ErrAuthFailed, false},
+		{NamesiloCodeSubAccount, ErrAuthFailed, false},
+		{NamesiloCodeIPNotAllowed, ErrAuthFailed, false},
+		{NamesiloCodeInvalidDomain, ErrZoneNotFound, false},
+		{NamesiloCodeDomainNotActive, ErrZoneNotFound, false},
+		{115, nil, true},
+		{NamesiloCodeInternalError, nil, true},
+		{NamesiloCodeDNSModifyError, nil, false},
+	}
+	for _, tt := range tests {
+		fake.replyCode = tt.code
+		err := provider.TestConnection(ctx)
+
+		var providerErr *ProviderError
+		if !errors.As(err, &providerErr) {
+			t.Fatalf("返回码%d应返回ProviderError，实际为: %v", tt.code, err)
+		}
+		if providerErr.Kind != tt.kind || providerErr.Code != fmt.Sprint(tt.code) || providerErr.Temporary != tt.temporary {
+			t.Errorf("返回码%d的错误不符合预期: %+v", tt.code, providerErr)
+		}
+		if IsRetryable(err) != tt.temporary {
+			t.Errorf("返回码%d的可重试判断应为%v", tt.code, tt.temporary)
+		}
+	}
+}
+
+func TestNamesiloRecords(t *testing.T) {
+	fake, provider := newNamesiloFake(t, "0")
+	ctx := context.Background()
+
+	zones, err := provider.ListZones(ctx)
+	if err != nil || len(zones) != 1 || zones[0].Name != "example.com" {
+		t.Fatalf("单个域名应解析为只有一个元素的列表: %+v, %v", zones, err)
+	}
+
+	created, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 3600})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+
+	// 只有一条记录时列表接口返回对象
+	got, err := provider.GetRecord(ctx, "example.com", created.ID)
+	if err != nil || got.Name != "www" || got.TTL != 3600 {
+		t.Fatalf("获取记录失败: %+v, %v", got, err)
+	}
+
+	updated, err := provider.ReplaceRecord(ctx, "example.com", created.ID, DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 7200})
+	if err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	if updated.ID == created.ID || updated.ID != fake.records[0].RecordID {
+		t.Fatalf("更新后应返回新的记录ID: %s -> %s", created.ID, updated.ID)
+	}
+	if _, err := provider.GetRecord(ctx, "example.com", created.ID); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("原记录ID应返回ErrRecordNotFound，实际为: %v", err)
+	}
+
+	if _, err := provider.ListRecords(ctx, "missing.example"); !errors.Is(err, ErrZoneNotFound) {
+		t.Fatalf("不在账号下的域名应返回ErrZoneNotFound，实际为: %v", err)
+	}
+}
+
+func TestNamesiloRateLimit(t *testing.T) {
+	fake, provider := newNamesiloFake(t, "50")
+	ctx := context.Background()
+
+	// 使用同一API Key重建的实例共用限流状态
+	rebuilt, err := NewNamesiloProvider(provider.config)
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	if rebuilt.limiter != provider.limiter {
+		t.Fatalf("同一API Key的实例应共用限流器")
+	}
+
+	for _, p := range []*NamesiloProvider{provider, rebuilt, provider} {
+		if err := p.TestConnection(ctx); err != nil {
+			t.Fatalf("测试连接失败: %v", err)
+		}
+	}
+	for i := 1; i < len(fake.requests); i++ {
+		// 留出少量计时误差
+		if gap := fake.requests[i].Sub(fake.requests[i-1]); gap < 45*time.Millisecond {
+			t.Fatalf("第%d次请求与上一次请求的间隔为%v，小于最小间隔", i+1, gap)
+		}
+	}
+
+	// 等待限流时上下文取消直接返回
+	provider.limiter.mu.Lock()
+	provider.limiter.next = time.Now().Add(time.Hour)
+	provider.limiter.mu.Unlock()
+	cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := provider.TestConnection(cancelled); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("等待限流时应返回上下文错误，实际为: %v", err)
+	}
+	if len(fake.requests) != 3 {
+		t.Fatalf("上下文取消后不应发起请求，实际请求%d次", len(fake.requests))
+	}
+}
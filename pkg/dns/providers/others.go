@@ -1,321 +0,0 @@
-package providers
-
-import (
-	"context"
-	"fmt"
-)
-
-// 华为云DNS服务商
-type HuaweiProvider struct {
-	config ProviderConfig
-}
-
-func NewHuaweiProvider(config ProviderConfig) (*HuaweiProvider, error) {
-	return &HuaweiProvider{config: config}, nil
-}
-
-func (p *HuaweiProvider) GetName() string {
-	return "huawei"
-}
-
-func (p *HuaweiProvider) ValidateConfig() error {
-	return fmt.Errorf("华为云DNS适配器暂未实现，敬请期待")
-}
-
-func (p *HuaweiProvider) TestConnection(ctx context.Context) error {
-	return fmt.Errorf("华为云DNS适配器暂未实现")
-}
-
-func (p *HuaweiProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("华为云DNS适配器暂未实现")
-}
-
-func (p *HuaweiProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
-	return nil, fmt.Errorf("华为云DNS适配器暂未实现")
-}
-
-func (p *HuaweiProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
-	return fmt.Errorf("华为云DNS适配器暂未实现")
-}
-
-func (p *HuaweiProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
-	return fmt.Errorf("华为云DNS适配器暂未实现")
-}
-
-func (p *HuaweiProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
-	return nil, fmt.Errorf("华为云DNS适配器暂未实现")
-}
-
-func (p *HuaweiProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("华为云DNS适配器暂未实现")
-}
-
-// 百度云DNS服务商
-type BaiduProvider struct {
-	config ProviderConfig
-}
-
-func NewBaiduProvider(config ProviderConfig) (*BaiduProvider, error) {
-	return &BaiduProvider{config: config}, nil
-}
-
-func (p *BaiduProvider) GetName() string {
-	return "baidu"
-}
-
-func (p *BaiduProvider) ValidateConfig() error {
-	return fmt.Errorf("百度云DNS适配器暂未实现，敬请期待")
-}
-
-func (p *BaiduProvider) TestConnection(ctx context.Context) error {
-	return fmt.Errorf("百度云DNS适配器暂未实现")
-}
-
-func (p *BaiduProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("百度云DNS适配器暂未实现")
-}
-
-func (p *BaiduProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
-	return nil, fmt.Errorf("百度云DNS适配器暂未实现")
-}
-
-func (p *BaiduProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
-	return fmt.Errorf("百度云DNS适配器暂未实现")
-}
-
-func (p *BaiduProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
-	return fmt.Errorf("百度云DNS适配器暂未实现")
-}
-
-func (p *BaiduProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
-	return nil, fmt.Errorf("百度云DNS适配器暂未实现")
-}
-
-func (p *BaiduProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("百度云DNS适配器暂未实现")
-}
-
-// 西部数码DNS服务商
-type WestProvider struct {
-	config ProviderConfig
-}
-
-func NewWestProvider(config ProviderConfig) (*WestProvider, error) {
-	return &WestProvider{config: config}, nil
-}
-
-func (p *WestProvider) GetName() string {
-	return "west"
-}
-
-func (p *WestProvider) ValidateConfig() error {
-	return fmt.Errorf("西部数码DNS适配器暂未实现，敬请期待")
-}
-
-func (p *WestProvider) TestConnection(ctx context.Context) error {
-	return fmt.Errorf("西部数码DNS适配器暂未实现")
-}
-
-func (p *WestProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("西部数码DNS适配器暂未实现")
-}
-
-func (p *WestProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
-	return nil, fmt.Errorf("西部数码DNS适配器暂未实现")
-}
-
-func (p *WestProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
-	return fmt.Errorf("西部数码DNS适配器暂未实现")
-}
-
-func (p *WestProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
-	return fmt.Errorf("西部数码DNS适配器暂未实现")
-}
-
-func (p *WestProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
-	return nil, fmt.Errorf("西部数码DNS适配器暂未实现")
-}
-
-func (p *WestProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("西部数码DNS适配器暂未实现")
-}
-
-// 火山引擎DNS服务商
-type VolcengineProvider struct {
-	config ProviderConfig
-}
-
-func NewVolcengineProvider(config ProviderConfig) (*VolcengineProvider, error) {
-	return &VolcengineProvider{config: config}, nil
-}
-
-func (p *VolcengineProvider) GetName() string {
-	return "volcengine"
-}
-
-func (p *VolcengineProvider) ValidateConfig() error {
-	return fmt.Errorf("火山引擎DNS适配器暂未实现，敬请期待")
-}
-
-func (p *VolcengineProvider) TestConnection(ctx context.Context) error {
-	return fmt.Errorf("火山引擎DNS适配器暂未实现")
-}
-
-func (p *VolcengineProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("火山引擎DNS适配器暂未实现")
-}
-
-func (p *VolcengineProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
-	return nil, fmt.Errorf("火山引擎DNS适配器暂未实现")
-}
-
-func (p *VolcengineProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
-	return fmt.Errorf("火山引擎DNS适配器暂未实现")
-}
-
-func (p *VolcengineProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
-	return fmt.Errorf("火山引擎DNS适配器暂未实现")
-}
-
-func (p *VolcengineProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
-	return nil, fmt.Errorf("火山引擎DNS适配器暂未实现")
-}
-
-func (p *VolcengineProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("火山引擎DNS适配器暂未实现")
-}
-
-// DNSLA服务商
-type DNSLAProvider struct {
-	config ProviderConfig
-}
-
-func NewDNSLAProvider(config ProviderConfig) (*DNSLAProvider, error) {
-	return &DNSLAProvider{config: config}, nil
-}
-
-func (p *DNSLAProvider) GetName() string {
-	return "dnsla"
-}
-
-func (p *DNSLAProvider) ValidateConfig() error {
-	return fmt.Errorf("DNSLA适配器暂未实现，敬请期待")
-}
-
-func (p *DNSLAProvider) TestConnection(ctx context.Context) error {
-	return fmt.Errorf("DNSLA适配器暂未实现")
-}
-
-func (p *DNSLAProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("DNSLA适配器暂未实现")
-}
-
-func (p *DNSLAProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
-	return nil, fmt.Errorf("DNSLA适配器暂未实现")
-}
-
-func (p *DNSLAProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
-	return fmt.Errorf("DNSLA适配器暂未实现")
-}
-
-func (p *DNSLAProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
-	return fmt.Errorf("DNSLA适配器暂未实现")
-}
-
-func (p *DNSLAProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
-	return nil, fmt.Errorf("DNSLA适配器暂未实现")
-}
-
-func (p *DNSLAProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("DNSLA适配器暂未实现")
-}
-
-// Namesilo服务商
-type NamesiloProvider struct {
-	config ProviderConfig
-}
-
-func NewNamesiloProvider(config ProviderConfig) (*NamesiloProvider, error) {
-	return &NamesiloProvider{config: config}, nil
-}
-
-func (p *NamesiloProvider) GetName() string {
-	return "namesilo"
-}
-
-func (p *NamesiloProvider) ValidateConfig() error {
-	return fmt.Errorf("Namesilo适配器暂未实现，敬请期待")
-}
-
-func (p *NamesiloProvider) TestConnection(ctx context.Context) error {
-	return fmt.Errorf("Namesilo适配器暂未实现")
-}
-
-func (p *NamesiloProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("Namesilo适配器暂未实现")
-}
-
-func (p *NamesiloProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
-	return nil, fmt.Errorf("Namesilo适配器暂未实现")
-}
-
-func (p *NamesiloProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
-	return fmt.Errorf("Namesilo适配器暂未实现")
-}
-
-func (p *NamesiloProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
-	return fmt.Errorf("Namesilo适配器暂未实现")
-}
-
-func (p *NamesiloProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
-	return nil, fmt.Errorf("Namesilo适配器暂未实现")
-}
-
-func (p *NamesiloProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("Namesilo适配器暂未实现")
-}
-
-// PowerDNS服务商
-type PowerDNSProvider struct {
-	config ProviderConfig
-}
-
-func NewPowerDNSProvider(config ProviderConfig) (*PowerDNSProvider, error) {
-	return &PowerDNSProvider{config: config}, nil
-}
-
-func (p *PowerDNSProvider) GetName() string {
-	return "powerdns"
-}
-
-func (p *PowerDNSProvider) ValidateConfig() error {
-	return fmt.Errorf("PowerDNS适配器暂未实现，敬请期待")
-}
-
-func (p *PowerDNSProvider) TestConnection(ctx context.Context) error {
-	return fmt.Errorf("PowerDNS适配器暂未实现")
-}
-
-func (p *PowerDNSProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("PowerDNS适配器暂未实现")
-}
-
-func (p *PowerDNSProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
-	return nil, fmt.Errorf("PowerDNS适配器暂未实现")
-}
-
-func (p *PowerDNSProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
-	return fmt.Errorf("PowerDNS适配器暂未实现")
-}
-
-func (p *PowerDNSProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
-	return fmt.Errorf("PowerDNS适配器暂未实现")
-}
-
-func (p *PowerDNSProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
-	return nil, fmt.Errorf("PowerDNS适配器暂未实现")
-}
-
-func (p *PowerDNSProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
-	return nil, fmt.Errorf("PowerDNS适配器暂未实现")
-}
\ No newline at end of file
@@ -0,0 +1,687 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PowerDNSProvider PowerDNS权威服务器HTTP API服务商
+// PowerDNS以RRset为单位管理记录，适配器将RRset中的每个记录值映射为一条DNSRecord，
+// 记录ID格式为"子域名/记录类型/记录值摘要"
+//
+// ExtraParams支持以下参数：
+//   - server_id: 服务器ID，默认为localhost
+//   - zone_kind: 自动创建域名时使用的类型，Native或Master，默认为Native
+//   - auto_create_zone: 为true时校验域名发现不存在会自动创建
+//   - nameservers: 自动创建域名时使用的NS服务器，多个以逗号分隔
+type PowerDNSProvider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	endpoint   string
+	serverID   string
+	zoneKind   string
+}
+
+// powerdnsRecord RRset中的单个记录值
+type powerdnsRecord struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+// powerdnsRRset PowerDNS API中的RRset
+type powerdnsRRset struct {
+	Name       string           `json:"name"`
+	Type       string           `json:"type"`
+	TTL        int              `json:"ttl,omitempty"`
+	ChangeType string           `json:"changetype,omitempty"`
+	Records    []powerdnsRecord `json:"records"`
+}
+
+// powerdnsZone PowerDNS API中的域名
+type powerdnsZone struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Kind   string          `json:"kind"`
+	RRsets []powerdnsRRset `json:"rrsets"`
+}
+
+// init 注册PowerDNS服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "powerdns",
+		DisplayName: "PowerDNS",
+		Description: "PowerDNS开源DNS服务器",
+		SortOrder:   10,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "PTR"},
+			SupportsBatch:        true,
+			SupportsLineTypes:    false,
+			MaxRecordsPerDomain:  100000,
+			MinTTL:               1,
+			MaxTTL:               2147483647,
+		},
+		ConfigSchema: []ConfigField{
+			{Key: "endpoint", Label: "API地址", Type: FieldText, Required: true, Placeholder: "http://127.0.0.1:8081",
+				Help: "PowerDNS配置中webserver-address和webserver-port对应的地址"},
+			{Key: "api_key", Label: "API Key", Type: FieldText, Secret: true, Required: true, Help: "PowerDNS配置中的api-key"},
+			{Key: "server_id", Label: "服务器ID", Type: FieldText, Default: "localhost"},
+			{Key: "auto_create_zone", Label: "自动创建域名", Type: FieldBoolean, Default: "false",
+				Help: "接入域名时如果PowerDNS中不存在该域名则自动创建"},
+			{Key: "zone_kind", Label: "域名类型", Type: FieldSelect, Default: "Native", Options: []string{"Native", "Master"}},
+			{Key: "nameservers", Label: "NS服务器", Type: FieldText, Placeholder: "ns1.example.com.,ns2.example.com.",
+				Help: "自动创建域名时使用，多个以逗号分隔"},
+		},
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewPowerDNSProvider(config)
+		},
+	})
+}
+
+// NewPowerDNSProvider 创建PowerDNS服务商实例
+func NewPowerDNSProvider(config ProviderConfig) (*PowerDNSProvider, error) {
+	if config.Endpoint == "" || config.APIKey == "" {
+		return nil, fmt.Errorf("PowerDNS需要API地址和API Key")
+	}
+
+	serverID := config.ExtraParams["server_id"]
+	if serverID == "" {
+		serverID = "localhost"
+	}
+
+	zoneKind := config.ExtraParams["zone_kind"]
+	if zoneKind == "" {
+		zoneKind = "Native"
+	}
+
+	return &PowerDNSProvider{
+		config:   config,
+		endpoint: strings.TrimSuffix(config.Endpoint, "/"),
+		serverID: serverID,
+		zoneKind: zoneKind,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetName 获取服务商名称
+func (p *PowerDNSProvider) GetName() string {
+	return "powerdns"
+}
+
+// ValidateConfig 验证API配置
+func (p *PowerDNSProvider) ValidateConfig() error {
+	if p.config.Endpoint == "" {
+		return fmt.Errorf("PowerDNS API地址不能为空")
+	}
+	if p.config.APIKey == "" {
+		return fmt.Errorf("PowerDNS API Key不能为空")
+	}
+	if p.zoneKind != "Native" && p.zoneKind != "Master" {
+		return fmt.Errorf("PowerDNS域名类型只支持Native或Master: %s", p.zoneKind)
+	}
+	return nil
+}
+
+// TestConnection 测试连接
+func (p *PowerDNSProvider) TestConnection(ctx context.Context) error {
+	_, err := p.makeRequest(ctx, "GET", p.serverPath(), nil)
+	return err
+}
+
+// ListZones 获取服务器上的全部域名
+func (p *PowerDNSProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	response, err := p.makeRequest(ctx, "GET", p.serverPath()+"/zones", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []powerdnsZone
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	zones := make([]Zone, 0, len(result))
+	for _, zone := range result {
+		zones = append(zones, Zone{
+			ID:     zone.ID,
+			Name:   strings.TrimSuffix(zone.Name, "."),
+			Status: "active",
+		})
+	}
+	return zones, nil
+}
+
+// VerifyZone 校验域名存在，配置了auto_create_zone时自动创建不存在的域名
+func (p *PowerDNSProvider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err == nil || p.config.ExtraParams["auto_create_zone"] != "true" {
+		return zoneID, err
+	}
+
+	return p.createZone(ctx, domain)
+}
+
+// ListRecords 获取域名记录列表
+func (p *PowerDNSProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *PowerDNSProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	query := filter
+	if query.Name != "" {
+		query.Name = powerdnsRecordName(query.Name, domain)
+	}
+	query.Type = strings.ToUpper(query.Type)
+
+	zone, err := p.getZone(ctx, domain, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	for _, rrset := range zone.RRsets {
+		// SOA记录由PowerDNS维护，不对外暴露
+		if rrset.Type == "SOA" {
+			continue
+		}
+		for _, value := range rrset.Records {
+			records = append(records, powerdnsToDNSRecord(rrset, value, domain))
+		}
+	}
+
+	return filterRecords(records, filter), nil
+}
+
+// AddRecord 添加DNS记录，向同名同类型的RRset追加记录值
+func (p *PowerDNSProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	results, err := p.BatchAddRecords(ctx, domain, []DNSRecord{record})
+	if err != nil {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+// UpdateRecord 更新DNS记录
+func (p *PowerDNSProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	_, err := p.ReplaceRecord(ctx, domain, recordID, record)
+	return err
+}
+
+// ReplaceRecord 更新DNS记录并返回新的记录，记录ID随子域名、类型和记录值变化
+func (p *PowerDNSProvider) ReplaceRecord(ctx context.Context, domain string, recordID string, record DNSRecord) (*DNSRecord, error) {
+	oldSet, index, err := p.findValue(ctx, domain, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	content := powerdnsEncodeValue(record)
+	newName := powerdnsRecordName(record.Name, domain)
+	newType := strings.ToUpper(record.Type)
+
+	value := powerdnsRecord{Content: content}
+	var changes []powerdnsRRset
+	if strings.EqualFold(oldSet.Name, newName) && oldSet.Type == newType {
+		// 记录集不变时保留暂停状态
+		value.Disabled = oldSet.Records[index].Disabled
+		values := append([]powerdnsRecord(nil), oldSet.Records...)
+		values[index] = value
+		changes = append(changes, powerdnsReplace(oldSet.Name, oldSet.Type, record.TTL, values))
+	} else {
+		// 子域名或类型变化时，从原RRset移除记录值并追加到目标RRset
+		changes = append(changes, powerdnsRemove(oldSet, index))
+
+		target, err := p.getRRset(ctx, domain, newName, newType)
+		if err != nil {
+			return nil, err
+		}
+		values := append(append([]powerdnsRecord(nil), target.Records...), powerdnsRecord{Content: content})
+		changes = append(changes, powerdnsReplace(newName, newType, record.TTL, values))
+	}
+
+	if err := p.patchRRsets(ctx, domain, changes); err != nil {
+		return nil, err
+	}
+
+	updated := powerdnsToDNSRecord(powerdnsRRset{Name: newName, Type: newType, TTL: record.TTL}, value, domain)
+	return &updated, nil
+}
+
+// DeleteRecord 删除DNS记录，RRset中最后一个记录值被删除时删除整个RRset
+func (p *PowerDNSProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	rrset, index, err := p.findValue(ctx, domain, recordID)
+	if err != nil {
+		return err
+	}
+
+	return p.patchRRsets(ctx, domain, []powerdnsRRset{powerdnsRemove(rrset, index)})
+}
+
+// GetRecord 获取单个记录详情
+func (p *PowerDNSProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	rrset, index, err := p.findValue(ctx, domain, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := powerdnsToDNSRecord(*rrset, rrset.Records[index], domain)
+	return &record, nil
+}
+
+// SetRecordStatus 启用或暂停DNS记录，PowerDNS按记录值设置disabled标记，不影响同一RRset的其他记录值
+func (p *PowerDNSProvider) SetRecordStatus(ctx context.Context, domain string, recordID string, enabled bool) error {
+	rrset, index, err := p.findValue(ctx, domain, recordID)
+	if err != nil {
+		return err
+	}
+
+	values := append([]powerdnsRecord(nil), rrset.Records...)
+	values[index].Disabled = !enabled
+	return p.patchRRsets(ctx, domain, []powerdnsRRset{powerdnsReplace(rrset.Name, rrset.Type, rrset.TTL, values)})
+}
+
+// BatchAddRecords 批量添加DNS记录，所有变更通过一次PATCH请求提交
+func (p *PowerDNSProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	zone, err := p.getZone(ctx, domain, RecordFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*powerdnsRRset)
+	for i := range zone.RRsets {
+		rrset := &zone.RRsets[i]
+		existing[strings.ToLower(rrset.Name)+"/"+rrset.Type] = rrset
+	}
+
+	// 按RRset合并记录值，保持首次出现的顺序
+	var keys []string
+	changes := make(map[string]*powerdnsRRset)
+	results := make([]DNSRecord, 0, len(records))
+
+	for _, record := range records {
+		name := powerdnsRecordName(record.Name, domain)
+		recordType := strings.ToUpper(record.Type)
+		key := strings.ToLower(name) + "/" + recordType
+
+		change, ok := changes[key]
+		if !ok {
+			ttl := record.TTL
+			current, found := existing[key]
+			if found && ttl <= 0 {
+				ttl = current.TTL
+			}
+			rrset := powerdnsReplace(name, recordType, ttl, nil)
+			if found {
+				rrset.Records = append(rrset.Records, current.Records...)
+			}
+			change = &rrset
+			changes[key] = change
+			keys = append(keys, key)
+		}
+
+		content := powerdnsEncodeValue(record)
+		for _, value := range change.Records {
+			if powerdnsSameContent(recordType, value.Content, content) {
+				return nil, fmt.Errorf("%w: %s %s %s", ErrRecordConflict, record.Name, record.Type, record.Value)
+			}
+		}
+		change.Records = append(change.Records, powerdnsRecord{Content: content})
+		if record.TTL > 0 {
+			change.TTL = record.TTL
+		}
+
+		results = append(results, powerdnsToDNSRecord(*change, powerdnsRecord{Content: content}, domain))
+	}
+
+	rrsets := make([]powerdnsRRset, 0, len(keys))
+	for _, key := range keys {
+		rrsets = append(rrsets, *changes[key])
+	}
+	if err := p.patchRRsets(ctx, domain, rrsets); err != nil {
+		return nil, err
+	}
+
+	// RRset的TTL以最后一次设置为准
+	for i := range results {
+		key := strings.ToLower(powerdnsRecordName(results[i].Name, domain)) + "/" + results[i].Type
+		results[i].TTL = changes[key].TTL
+	}
+	return results, nil
+}
+
+// findValue 根据记录ID查找RRset及记录值所在位置
+func (p *PowerDNSProvider) findValue(ctx context.Context, domain, recordID string) (*powerdnsRRset, int, error) {
+	parts := strings.SplitN(recordID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, 0, fmt.Errorf("无效的记录ID: %s", recordID)
+	}
+
+	rrset, err := p.getRRset(ctx, domain, powerdnsRecordName(parts[0], domain), parts[1])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i, value := range rrset.Records {
+		if valueDigest(powerdnsCanonicalContent(rrset.Type, value.Content)) == parts[2] {
+			return rrset, i, nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf("%w: %s", ErrRecordNotFound, recordID)
+}
+
+// getRRset 获取指定名称和类型的RRset，不存在时返回空RRset
+func (p *PowerDNSProvider) getRRset(ctx context.Context, domain, name, recordType string) (*powerdnsRRset, error) {
+	zone, err := p.getZone(ctx, domain, RecordFilter{Name: name, Type: recordType})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range zone.RRsets {
+		rrset := zone.RRsets[i]
+		if strings.EqualFold(rrset.Name, name) && rrset.Type == recordType {
+			return &rrset, nil
+		}
+	}
+
+	return &powerdnsRRset{Name: name, Type: recordType}, nil
+}
+
+// getZone 获取域名详情，filter中的名称为完整域名；旧版本PowerDNS会忽略过滤参数，调用方需再做精确过滤
+func (p *PowerDNSProvider) getZone(ctx context.Context, domain string, filter RecordFilter) (*powerdnsZone, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	path := p.zonePath(zoneID)
+	query := url.Values{}
+	if filter.Name != "" && filter.Type != "" {
+		query.Set("rrset_name", filter.Name)
+		query.Set("rrset_type", filter.Type)
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	response, err := p.makeRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var zone powerdnsZone
+	if err := json.Unmarshal(response, &zone); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+	return &zone, nil
+}
+
+// getZoneID 获取域名在PowerDNS中的ID，结果按凭据缓存
+func (p *PowerDNSProvider) getZoneID(ctx context.Context, domain string) (string, error) {
+	return cachedZoneID(ctx, "powerdns", p.config, domain, p.lookupZoneID)
+}
+
+// lookupZoneID 查询域名在PowerDNS中的ID
+func (p *PowerDNSProvider) lookupZoneID(ctx context.Context, domain string) (string, error) {
+	path := p.serverPath() + "/zones?" + url.Values{"zone": {domain + "."}}.Encode()
+	response, err := p.makeRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var zones []powerdnsZone
+	if err := json.Unmarshal(response, &zones); err != nil {
+		return "", fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	for _, zone := range zones {
+		if strings.EqualFold(strings.TrimSuffix(zone.Name, "."), domain) {
+			return zone.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
+}
+
+// createZone 按配置的域名类型创建域名
+func (p *PowerDNSProvider) createZone(ctx context.Context, domain string) (string, error) {
+	nameservers := []string{}
+	for _, ns := range strings.Split(p.config.ExtraParams["nameservers"], ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			nameservers = append(nameservers, strings.TrimSuffix(ns, ".")+".")
+		}
+	}
+
+	data := map[string]interface{}{
+		"name":        domain + ".",
+		"kind":        p.zoneKind,
+		"nameservers": nameservers,
+	}
+
+	response, err := p.makeRequest(ctx, "POST", p.serverPath()+"/zones", data)
+	if err != nil {
+		return "", fmt.Errorf("创建域名失败: %w", err)
+	}
+
+	var zone powerdnsZone
+	if err := json.Unmarshal(response, &zone); err != nil {
+		return "", fmt.Errorf("解析响应失败: %w", err)
+	}
+	return zone.ID, nil
+}
+
+// patchRRsets 提交RRset变更
+func (p *PowerDNSProvider) patchRRsets(ctx context.Context, domain string, rrsets []powerdnsRRset) error {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	_, err = p.makeRequest(ctx, "PATCH", p.zonePath(zoneID), map[string]interface{}{"rrsets": rrsets})
+	return err
+}
+
+// serverPath 返回服务器API路径
+func (p *PowerDNSProvider) serverPath() string {
+	return "/api/v1/servers/" + url.PathEscape(p.serverID)
+}
+
+// zonePath 返回域名API路径
+func (p *PowerDNSProvider) zonePath(zoneID string) string {
+	return p.serverPath() + "/zones/" + url.PathEscape(zoneID)
+}
+
+// makeRequest 发起API请求
+func (p *PowerDNSProvider) makeRequest(ctx context.Context, method, path string, data interface{}) ([]byte, error) {
+	var body io.Reader
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("序列化请求数据失败: %w", err)
+		}
+		body = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", p.config.APIKey)
+	req.Header.Set("Accept", "application/json")
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发起请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errorResp struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(respBody, &errorResp); err == nil && errorResp.Error != "" {
+			// PowerDNS没有业务错误码，使用HTTP状态码，404只会出现在域名不存在时
+			apiErr := newHTTPError("PowerDNS", resp, strconv.Itoa(resp.StatusCode), errorResp.Error, "")
+			switch {
+			case resp.StatusCode == http.StatusNotFound:
+				apiErr.Kind = ErrZoneNotFound
+			case strings.Contains(errorResp.Error, "onflict") || strings.Contains(errorResp.Error, "already exists"):
+				apiErr.Kind = ErrRecordConflict
+			}
+			return nil, apiErr
+		}
+		return nil, newStatusError("PowerDNS", resp, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+// powerdnsReplace 构建替换RRset的变更
+func powerdnsReplace(name, recordType string, ttl int, records []powerdnsRecord) powerdnsRRset {
+	if ttl <= 0 {
+		ttl = 600
+	}
+	return powerdnsRRset{
+		Name:       name,
+		Type:       recordType,
+		TTL:        ttl,
+		ChangeType: "REPLACE",
+		Records:    records,
+	}
+}
+
+// powerdnsRemove 构建从RRset中移除一个记录值的变更
+func powerdnsRemove(rrset *powerdnsRRset, index int) powerdnsRRset {
+	if len(rrset.Records) <= 1 {
+		return powerdnsRRset{
+			Name:       rrset.Name,
+			Type:       rrset.Type,
+			ChangeType: "DELETE",
+			Records:    []powerdnsRecord{},
+		}
+	}
+
+	values := make([]powerdnsRecord, 0, len(rrset.Records)-1)
+	values = append(values, rrset.Records[:index]...)
+	values = append(values, rrset.Records[index+1:]...)
+	return powerdnsReplace(rrset.Name, rrset.Type, rrset.TTL, values)
+}
+
+// powerdnsRecordName 将子域名转换为完整域名（以"."结尾）
+func powerdnsRecordName(name, domain string) string {
+	if name == "" || name == "@" {
+		return domain + "."
+	}
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "." + domain + "."
+}
+
+// powerdnsEncodeValue 将DNSRecord转换为PowerDNS的记录内容格式
+func powerdnsEncodeValue(record DNSRecord) string {
+	switch strings.ToUpper(record.Type) {
+	case "MX":
+		return fmt.Sprintf("%d %s", record.Priority, powerdnsFQDN(record.Value))
+	case "SRV":
+		return fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, powerdnsFQDN(record.Value))
+	case "CNAME", "NS", "PTR":
+		return powerdnsFQDN(record.Value)
+	case "TXT", "SPF":
+		if strings.HasPrefix(record.Value, "\"") {
+			return record.Value
+		}
+		return strconv.Quote(record.Value)
+	default:
+		return record.Value
+	}
+}
+
+// powerdnsFQDN 主机名补全末尾的"."
+func powerdnsFQDN(host string) string {
+	if host == "" || strings.HasSuffix(host, ".") {
+		return host
+	}
+	return host + "."
+}
+
+// powerdnsCanonicalContent 规范化记录内容，避免PowerDNS改写大小写或IPv6格式后记录ID失效
+func powerdnsCanonicalContent(recordType, content string) string {
+	switch recordType {
+	case "TXT", "SPF":
+		return content
+	case "A", "AAAA":
+		if ip := net.ParseIP(content); ip != nil {
+			return ip.String()
+		}
+	}
+	return strings.ToLower(strings.Join(strings.Fields(content), " "))
+}
+
+// powerdnsSameContent 判断两个记录内容是否相同
+func powerdnsSameContent(recordType, a, b string) bool {
+	return powerdnsCanonicalContent(recordType, a) == powerdnsCanonicalContent(recordType, b)
+}
+
+// powerdnsToDNSRecord 将RRset中的单个记录值转换为DNSRecord
+func powerdnsToDNSRecord(rrset powerdnsRRset, value powerdnsRecord, domain string) DNSRecord {
+	name := strings.TrimSuffix(rrset.Name, ".")
+	if strings.EqualFold(name, domain) {
+		name = "@"
+	} else {
+		name = strings.TrimSuffix(name, "."+domain)
+	}
+
+	status := "active"
+	if value.Disabled {
+		status = "disabled"
+	}
+
+	record := DNSRecord{
+		ID:     name + "/" + rrset.Type + "/" + valueDigest(powerdnsCanonicalContent(rrset.Type, value.Content)),
+		Name:   name,
+		Type:   rrset.Type,
+		Value:  value.Content,
+		TTL:    rrset.TTL,
+		Status: status,
+	}
+
+	fields := strings.Fields(value.Content)
+	switch {
+	case rrset.Type == "MX" && len(fields) == 2:
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Value = strings.TrimSuffix(fields[1], ".")
+	case rrset.Type == "SRV" && len(fields) == 4:
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Weight, _ = strconv.Atoi(fields[1])
+		record.Port, _ = strconv.Atoi(fields[2])
+		record.Value = strings.TrimSuffix(fields[3], ".")
+	case rrset.Type == "CNAME" || rrset.Type == "NS" || rrset.Type == "PTR":
+		record.Value = strings.TrimSuffix(value.Content, ".")
+	case rrset.Type == "TXT" || rrset.Type == "SPF":
+		if unquoted, err := strconv.Unquote(value.Content); err == nil {
+			record.Value = unquoted
+		}
+	}
+
+	return record
+}
@@ -0,0 +1,163 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const powerdnsTestAPIKey = "pdns-test-key"
+
+// powerdnsFake PowerDNS HTTP API的内存实现，按RRset保存记录
+type powerdnsFake struct {
+	mu     sync.Mutex
+	rrsets []powerdnsRRset
+}
+
+func newPowerDNSFake(t *testing.T) (*powerdnsFake, *PowerDNSProvider) {
+	t.Helper()
+	fake := &powerdnsFake{}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	provider, err := NewPowerDNSProvider(ProviderConfig{APIKey: powerdnsTestAPIKey, Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	return fake, provider
+}
+
+func (f *powerdnsFake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-API-Key") != powerdnsTestAPIKey {
+		writePowerDNSError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/api/v1/servers/localhost":
+		writePowerDNSJSON(w, map[string]string{"id": "localhost"})
+	case r.Method == "GET" && r.URL.Path == "/api/v1/servers/localhost/zones":
+		zones := []powerdnsZone{}
+		if zone := r.URL.Query().Get("zone"); zone == "" || zone == "example.com." {
+			zones = append(zones, powerdnsZone{ID: "example.com.", Name: "example.com.", Kind: "Native"})
+		}
+		writePowerDNSJSON(w, zones)
+	case r.URL.Path == "/api/v1/servers/localhost/zones/example.com.":
+		if r.Method == "PATCH" {
+			var patch struct {
+				RRsets []powerdnsRRset `json:"rrsets"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &patch); err != nil {
+				writePowerDNSError(w, http.StatusUnprocessableEntity, "invalid body")
+				return
+			}
+			for _, change := range patch.RRsets {
+				f.apply(change)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		// 按rrset_name和rrset_type过滤，与PowerDNS 4.8之后的行为一致
+		zone := powerdnsZone{ID: "example.com.", Name: "example.com.", Kind: "Native", RRsets: []powerdnsRRset{}}
+		name, recordType := r.URL.Query().Get("rrset_name"), r.URL.Query().Get("rrset_type")
+		for _, rrset := range f.rrsets {
+			if name != "" && (!strings.EqualFold(rrset.Name, name) || rrset.Type != recordType) {
+				continue
+			}
+			zone.RRsets = append(zone.RRsets, rrset)
+		}
+		writePowerDNSJSON(w, zone)
+	default:
+		writePowerDNSError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// apply 执行单个RRset变更，REPLACE整体替换记录值，DELETE删除整个RRset
+func (f *powerdnsFake) apply(change powerdnsRRset) {
+	kept := f.rrsets[:0]
+	for _, rrset := range f.rrsets {
+		if !strings.EqualFold(rrset.Name, change.Name) || rrset.Type != change.Type {
+			kept = append(kept, rrset)
+		}
+	}
+	f.rrsets = kept
+	if change.ChangeType == "REPLACE" && len(change.Records) > 0 {
+		change.ChangeType = ""
+		f.rrsets = append(f.rrsets, change)
+	}
+}
+
+func writePowerDNSJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writePowerDNSError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func TestPowerDNSRecordStatus(t *testing.T) {
+	fake, provider := newPowerDNSFake(t)
+	ctx := context.Background()
+
+	created, err := provider.BatchAddRecords(ctx, "example.com", []DNSRecord{
+		{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300},
+		{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300},
+	})
+	if err != nil {
+		t.Fatalf("批量添加记录失败: %v", err)
+	}
+	if !SupportsRecordStatus(provider) {
+		t.Fatalf("PowerDNS应支持暂停记录")
+	}
+
+	// 暂停只设置该记录值的disabled标记，同一RRset的其他记录值保持启用
+	if err := provider.SetRecordStatus(ctx, "example.com", created[0].ID, false); err != nil {
+		t.Fatalf("暂停记录失败: %v", err)
+	}
+	if !fake.rrsets[0].Records[0].Disabled || fake.rrsets[0].Records[1].Disabled {
+		t.Fatalf("disabled标记不符合预期: %+v", fake.rrsets[0].Records)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", created[0].ID); err != nil || RecordEnabled(*got) {
+		t.Fatalf("记录应处于暂停状态: %+v, %v", got, err)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", created[1].ID); err != nil || !RecordEnabled(*got) {
+		t.Fatalf("同一RRset的其他记录值应保持启用: %+v, %v", got, err)
+	}
+
+	// 修改暂停的记录后仍处于暂停状态
+	updated, err := provider.ReplaceRecord(ctx, "example.com", created[0].ID, DNSRecord{Name: "www", Type: "A", Value: "192.0.2.3", TTL: 600})
+	if err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	if RecordEnabled(*updated) {
+		t.Fatalf("更新返回的记录应处于暂停状态: %+v", updated)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", updated.ID); err != nil || RecordEnabled(*got) || got.TTL != 600 {
+		t.Fatalf("更新后的记录应处于暂停状态: %+v, %v", got, err)
+	}
+
+	if err := provider.SetRecordStatus(ctx, "example.com", updated.ID, true); err != nil {
+		t.Fatalf("启用记录失败: %v", err)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", updated.ID); err != nil || !RecordEnabled(*got) {
+		t.Fatalf("记录应处于启用状态: %+v, %v", got, err)
+	}
+
+	if err := provider.SetRecordStatus(ctx, "example.com", "www/A/missing", false); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("记录值不存在时应返回ErrRecordNotFound，实际为: %v", err)
+	}
+}
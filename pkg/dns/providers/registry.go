@@ -0,0 +1,119 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// 配置字段类型
+const (
+	FieldText    = "text"
+	FieldNumber  = "number"
+	FieldSelect  = "select"
+	FieldBoolean = "boolean"
+)
+
+// ConfigField 服务商配置项，用于生成前端表单和校验凭据配置
+// Key与CreateProvider的config参数一致，api_key、api_secret、token、region、endpoint之外的配置项保存在ExtraParams中
+type ConfigField struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label"`
+	Type        string   `json:"type"`
+	Secret      bool     `json:"secret"` // 密钥类配置，表单中以密码框显示
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"` // select类型的可选值
+	Min         *int     `json:"min,omitempty"`     // number类型的最小值
+	Max         *int     `json:"max,omitempty"`     // number类型的最大值
+	Placeholder string   `json:"placeholder,omitempty"`
+	Help        string   `json:"help,omitempty"`
+
+	// RequiredWith 填写了其中任一配置项时本项必填
+	RequiredWith []string `json:"required_with,omitempty"`
+	// RequiredUnless 填写了其中任一配置项时本项可以不填，未设置RequiredWith时本项默认必填
+	RequiredUnless []string `json:"required_unless,omitempty"`
+}
+
+// ProviderDefinition 服务商注册信息
+type ProviderDefinition struct {
+	Type         string                                           `json:"type"`
+	DisplayName  string                                           `json:"display_name"`
+	Description  string                                           `json:"description"`
+	SortOrder    int                                              `json:"sort_order"`  // 列表中的排序，数值小的在前
+	Development  bool                                             `json:"development"` // 仅用于开发和演示，不写入默认服务商
+	Features     ProviderFeatures                                 `json:"features"`
+	ConfigSchema []ConfigField                                    `json:"config_schema"`
+	Constructor  func(config ProviderConfig) (DNSProvider, error) `json:"-"`
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ProviderDefinition)
+)
+
+// Register 注册DNS服务商，各适配器在init中调用
+// 仓库外的适配器通过空白导入所在的包即可注册，类型重复或缺少构造函数时panic
+func Register(definition ProviderDefinition) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if definition.Type == "" || definition.Constructor == nil {
+		panic("providers: 注册DNS服务商需要类型和构造函数")
+	}
+	if _, exists := registry[definition.Type]; exists {
+		panic(fmt.Sprintf("providers: DNS服务商重复注册: %s", definition.Type))
+	}
+	registry[definition.Type] = definition
+}
+
+// Lookup 获取已注册的DNS服务商
+func Lookup(providerType string) (ProviderDefinition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	definition, exists := registry[providerType]
+	return definition, exists
+}
+
+// Definitions 获取全部已注册的DNS服务商，按SortOrder和类型排序
+func Definitions() []ProviderDefinition {
+	registryMu.RLock()
+	definitions := make([]ProviderDefinition, 0, len(registry))
+	for _, definition := range registry {
+		definitions = append(definitions, definition)
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(definitions, func(i, j int) bool {
+		if definitions[i].SortOrder != definitions[j].SortOrder {
+			return definitions[i].SortOrder < definitions[j].SortOrder
+		}
+		return definitions[i].Type < definitions[j].Type
+	})
+	return definitions
+}
+
+// SupportedProviders 获取支持的DNS服务商类型列表
+func SupportedProviders() []string {
+	definitions := Definitions()
+	types := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		types = append(types, definition.Type)
+	}
+	return types
+}
+
+// intPtr 返回整数的指针，用于配置项的取值范围
+func intPtr(value int) *int {
+	return &value
+}
+
+// accessKeySchema 使用Access Key和Secret Key认证的服务商通用配置项
+func accessKeySchema(keyLabel, secretLabel string, extra ...ConfigField) []ConfigField {
+	schema := []ConfigField{
+		{Key: "api_key", Label: keyLabel, Type: FieldText, Required: true},
+		{Key: "api_secret", Label: secretLabel, Type: FieldText, Secret: true, Required: true},
+	}
+	return append(schema, extra...)
+}
@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+)
+
+// registerForTest 注册测试用的服务商，测试结束后从注册表中删除
+func registerForTest(t *testing.T, definition ProviderDefinition) {
+	t.Helper()
+	if definition.Constructor == nil {
+		definition.Constructor = func(config ProviderConfig) (DNSProvider, error) {
+			return NewMockProvider(config)
+		}
+	}
+	Register(definition)
+	t.Cleanup(func() {
+		registryMu.Lock()
+		delete(registry, definition.Type)
+		registryMu.Unlock()
+	})
+}
+
+// registerPanic 注册服务商并返回panic的信息，未panic时返回空字符串
+func registerPanic(definition ProviderDefinition) (message string) {
+	defer func() {
+		if r := recover(); r != nil {
+			message = r.(string)
+		}
+	}()
+	Register(definition)
+	return ""
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	registerForTest(t, ProviderDefinition{Type: "test-registered"})
+	constructor := func(config ProviderConfig) (DNSProvider, error) { return nil, nil }
+
+	tests := []struct {
+		name       string
+		definition ProviderDefinition
+		want       string
+	}{
+		{"重复注册", ProviderDefinition{Type: "test-registered", Constructor: constructor}, "重复注册: test-registered"},
+		{"重复注册内置服务商", ProviderDefinition{Type: "aliyun", Constructor: constructor}, "重复注册: aliyun"},
+		{"缺少类型", ProviderDefinition{Constructor: constructor}, "需要类型和构造函数"},
+		{"缺少构造函数", ProviderDefinition{Type: "test-no-constructor"}, "需要类型和构造函数"},
+	}
+	for _, tt := range tests {
+		if message := registerPanic(tt.definition); !strings.Contains(message, tt.want) {
+			t.Errorf("%s: panic信息应包含%q，实际为%q", tt.name, tt.want, message)
+		}
+	}
+
+	if _, exists := Lookup("test-no-constructor"); exists {
+		t.Fatalf("注册失败的服务商不应写入注册表")
+	}
+}
+
+func TestDefinitionsOrder(t *testing.T) {
+	registerForTest(t, ProviderDefinition{Type: "test-order-b", SortOrder: -1})
+	registerForTest(t, ProviderDefinition{Type: "test-order-a", SortOrder: -1})
+	registerForTest(t, ProviderDefinition{Type: "test-order-c", SortOrder: -2})
+
+	definitions := Definitions()
+	if len(definitions) < 3 {
+		t.Fatalf("注册的服务商数量不足: %d", len(definitions))
+	}
+
+	// SortOrder小的在前，相同时按类型排序
+	got := []string{definitions[0].Type, definitions[1].Type, definitions[2].Type}
+	if strings.Join(got, ",") != "test-order-c,test-order-a,test-order-b" {
+		t.Fatalf("排序不符合预期: %v", got)
+	}
+	for i := 1; i < len(definitions); i++ {
+		prev, cur := definitions[i-1], definitions[i]
+		if prev.SortOrder > cur.SortOrder || (prev.SortOrder == cur.SortOrder && prev.Type > cur.Type) {
+			t.Fatalf("%s应排在%s之前", cur.Type, prev.Type)
+		}
+	}
+
+	types := SupportedProviders()
+	for i, definition := range definitions {
+		if types[i] != definition.Type {
+			t.Fatalf("SupportedProviders应与Definitions的顺序一致: %v", types)
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// breakerFailureThreshold 连续失败多少次后熔断
+	breakerFailureThreshold = 5
+	// breakerOpenDuration 熔断后暂停调用的时间，到期后放行一次试探请求
+	breakerOpenDuration = 30 * time.Second
+)
+
+// ErrCircuitOpen 同一凭据连续调用失败，熔断期间不再请求服务商
+var ErrCircuitOpen = errors.New("服务商调用已熔断")
+
+// CircuitOpenError 熔断期间返回的错误
+type CircuitOpenError struct {
+	Provider   string
+	RetryAfter time.Duration // 距离熔断结束的时间
+}
+
+// Error 实现error接口
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s连续调用失败，已暂停调用，%s后重试", e.Provider, e.RetryAfter.Round(time.Second))
+}
+
+// Unwrap 返回ErrCircuitOpen，使errors.Is可以匹配
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// circuitBreaker 按凭据统计连续失败次数的熔断器
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// providerBreakers 按凭据共享的熔断器，凭据更新后重建的实例沿用之前的熔断状态
+var providerBreakers sync.Map
+
+// allow 判断是否允许发起请求，熔断到期后只放行一个试探请求
+func (b *circuitBreaker) allow(now time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < breakerFailureThreshold {
+		return 0, true
+	}
+	if now.Before(b.openUntil) {
+		return b.openUntil.Sub(now), false
+	}
+	if b.probing {
+		return breakerOpenDuration, false
+	}
+	b.probing = true
+	return 0, true
+}
+
+// record 记录请求结果：服务商故障类错误计入失败次数，成功或服务商返回的业务错误说明服务商可用，清零失败次数
+// 调用方取消或超时的请求可能没有到达服务商，其他本地错误也不能说明服务商状态，这两类只释放试探名额，不改变熔断状态
+func (b *circuitBreaker) record(ctx context.Context, err error, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	var providerErr *ProviderError
+	switch {
+	case err == nil:
+		b.failures = 0
+	case ctx.Err() != nil || errors.Is(err, context.Canceled):
+		return
+	case isProviderFailure(err):
+		b.failures++
+		if b.failures >= breakerFailureThreshold {
+			b.openUntil = now.Add(breakerOpenDuration)
+		}
+	case errors.As(err, &providerErr):
+		b.failures = 0
+	}
+}
+
+// isProviderFailure 判断错误是否说明服务商不可用或凭据不可用，记录不存在等业务错误不计入
+func isProviderFailure(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	return IsRetryable(err) || errors.Is(err, ErrAuthFailed)
+}
+
+// tokenBucket 令牌桶限流器
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // 每秒补充的令牌数
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// providerLimiters 按凭据共享的令牌桶
+var providerLimiters sync.Map
+
+// wait 取出一个令牌，令牌不足时等待补充
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	deficit := -b.tokens
+	b.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(deficit / b.rate * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// 放弃等待时归还预支的令牌
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ResilientProvider 为服务商调用增加限流、重试和熔断的装饰器
+// 限流器和熔断器按凭据在实例之间共享，不会因为ProviderManager重建实例而重置
+type ResilientProvider struct {
+	provider    DNSProvider
+	retryConfig RetryConfig
+	breaker     *circuitBreaker
+	limiter     *tokenBucket // 服务商不限制请求频率时为nil
+	key         string       // credentialKey生成的凭据标识，用于清除域名ID缓存
+}
+
+// NewResilientProvider 包装DNS服务商实例
+// 请求频率默认使用GetProviderFeatures中服务商公布的限制，ExtraParams中的rate_limit（每秒请求数，0为不限制）可以覆盖
+func NewResilientProvider(provider DNSProvider, providerType string, config ProviderConfig, retryConfig RetryConfig) *ResilientProvider {
+	key := credentialKey(providerType, config)
+	breaker, _ := providerBreakers.LoadOrStore(key, &circuitBreaker{})
+
+	resilient := &ResilientProvider{
+		provider:    provider,
+		retryConfig: retryConfig,
+		breaker:     breaker.(*circuitBreaker),
+		key:         key,
+	}
+
+	features := GetProviderFeatures(providerType)
+	rate, burst := features.RateLimit, features.RateBurst
+	if value, err := strconv.ParseFloat(config.ExtraParams["rate_limit"], 64); err == nil && value >= 0 {
+		rate, burst = value, int(math.Ceil(value))
+	}
+	if rate > 0 {
+		if burst < 1 {
+			burst = 1
+		}
+		limiter, _ := providerLimiters.LoadOrStore(fmt.Sprintf("%s/%g/%d", key, rate, burst), &tokenBucket{
+			rate:   rate,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   time.Now(),
+		})
+		resilient.limiter = limiter.(*tokenBucket)
+	}
+	return resilient
+}
+
+// credentialKey 生成凭据的唯一标识，只保存摘要避免密钥常驻内存
+func credentialKey(providerType string, config ProviderConfig) string {
+	parts := []string{providerType, config.APIKey, config.APISecret, config.Token, config.Region, config.Endpoint}
+	keys := make([]string, 0, len(config.ExtraParams))
+	for key := range config.ExtraParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, key+"="+config.ExtraParams[key])
+	}
+	return providerType + ":" + sha256Hex(strings.Join(parts, "\x00"))
+}
+
+// Unwrap 返回被包装的服务商实例
+func (p *ResilientProvider) Unwrap() DNSProvider {
+	return p.provider
+}
+
+// finalError 包装不能重试的错误，do直接返回其中的原始错误
+type finalError struct {
+	err error
+}
+
+// Error 实现error接口
+func (e *finalError) Error() string {
+	return e.err.Error()
+}
+
+// do 执行一次服务商调用，idempotent为false的操作只在请求被限流拒绝时重试，避免重复创建记录
+// operation返回finalError时不再重试，操作的域名不为空且服务商返回域名不存在时，清除该域名缓存的域名ID
+func (p *ResilientProvider) do(ctx context.Context, domain string, idempotent bool, operation func() error) error {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			delay, ok := p.retryDelay(ctx, attempt, lastErr)
+			if !ok {
+				return lastErr
+			}
+			if err := sleepContext(ctx, delay); err != nil {
+				return lastErr
+			}
+		}
+
+		if p.limiter != nil {
+			if err := p.limiter.wait(ctx); err != nil {
+				if lastErr != nil {
+					return lastErr
+				}
+				return err
+			}
+		}
+		if remaining, ok := p.breaker.allow(time.Now()); !ok {
+			return &CircuitOpenError{Provider: p.provider.GetName(), RetryAfter: remaining}
+		}
+
+		lastErr = operation()
+		var final *finalError
+		if errors.As(lastErr, &final) {
+			lastErr = final.err
+		}
+		p.breaker.record(ctx, lastErr, time.Now())
+		if lastErr == nil {
+			return nil
+		}
+		if domain != "" && errors.Is(lastErr, ErrZoneNotFound) {
+			invalidateZoneID(p.key, domain)
+		}
+		if final != nil || attempt >= p.retryConfig.MaxRetries || !IsRetryable(lastErr) {
+			return lastErr
+		}
+		if !idempotent && !errors.Is(lastErr, ErrRateLimited) {
+			return lastErr
+		}
+	}
+}
+
+// retryDelay 计算第attempt次重试前的等待时间，服务商要求的等待时间超过上限或请求截止时间时放弃重试
+func (p *ResilientProvider) retryDelay(ctx context.Context, attempt int, err error) (time.Duration, bool) {
+	delay := backoffDelay(p.retryConfig, attempt)
+	if retryAfter := RetryAfter(err); retryAfter > 0 {
+		if retryAfter > p.retryConfig.MaxDelay {
+			return 0, false
+		}
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return 0, false
+	}
+	return delay, true
+}
+
+// backoffDelay 计算指数退避时间，在退避时间的后一半内随机取值，避免多个请求同时重试
+func backoffDelay(config RetryConfig, attempt int) time.Duration {
+	delay := float64(config.InitialDelay) * math.Pow(config.BackoffFactor, float64(attempt-1))
+	if delay > float64(config.MaxDelay) {
+		delay = float64(config.MaxDelay)
+	}
+	half := int64(delay / 2)
+	if half <= 0 {
+		return time.Duration(delay)
+	}
+	return time.Duration(half + rand.Int64N(half+1))
+}
+
+// sleepContext 等待指定时间，上下文取消时提前返回
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// GetName 获取服务商名称
+func (p *ResilientProvider) GetName() string {
+	return p.provider.GetName()
+}
+
+// ValidateConfig 验证API配置
+func (p *ResilientProvider) ValidateConfig() error {
+	return p.provider.ValidateConfig()
+}
+
+// ListRecords 获取域名记录列表
+func (p *ResilientProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	var records []DNSRecord
+	err := p.do(ctx, domain, true, func() (err error) {
+		records, err = p.provider.ListRecords(ctx, domain)
+		return err
+	})
+	return records, err
+}
+
+// AddRecord 添加DNS记录
+func (p *ResilientProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	var created *DNSRecord
+	err := p.do(ctx, domain, false, func() (err error) {
+		created, err = p.provider.AddRecord(ctx, domain, record)
+		return err
+	})
+	return created, err
+}
+
+// UpdateRecord 更新DNS记录
+func (p *ResilientProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	return p.do(ctx, domain, true, func() error {
+		return p.provider.UpdateRecord(ctx, domain, recordID, record)
+	})
+}
+
+// DeleteRecord 删除DNS记录
+func (p *ResilientProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	return p.do(ctx, domain, true, func() error {
+		return p.provider.DeleteRecord(ctx, domain, recordID)
+	})
+}
+
+// GetRecord 获取单个记录详情
+func (p *ResilientProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	var record *DNSRecord
+	err := p.do(ctx, domain, true, func() (err error) {
+		record, err = p.provider.GetRecord(ctx, domain, recordID)
+		return err
+	})
+	return record, err
+}
+
+// BatchAddRecords 批量添加DNS记录
+// 逐条添加的服务商可能部分成功，此时重试整批会重复创建已添加的记录，只在没有添加任何记录时重试
+func (p *ResilientProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	var created []DNSRecord
+	err := p.do(ctx, domain, false, func() (err error) {
+		created, err = p.provider.BatchAddRecords(ctx, domain, records)
+		if err != nil && len(created) > 0 {
+			return &finalError{err: err}
+		}
+		return err
+	})
+	return created, err
+}
+
+// TestConnection 测试连接
+func (p *ResilientProvider) TestConnection(ctx context.Context) error {
+	return p.do(ctx, "", true, func() error {
+		return p.provider.TestConnection(ctx)
+	})
+}
+
+// ListZones 获取账号下托管的全部域名
+func (p *ResilientProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	var zones []Zone
+	err := p.do(ctx, "", true, func() (err error) {
+		zones, err = p.provider.ListZones(ctx)
+		return err
+	})
+	return zones, err
+}
+
+// VerifyZone 校验域名已托管，服务商不支持校验接口时通过读取记录列表判断
+func (p *ResilientProvider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	var zoneID string
+	err := p.do(ctx, domain, true, func() (err error) {
+		if verifier, ok := p.provider.(ZoneVerifier); ok {
+			zoneID, err = verifier.VerifyZone(ctx, domain)
+			return err
+		}
+		_, err = p.provider.ListRecords(ctx, domain)
+		return err
+	})
+	return zoneID, err
+}
+
+// ListLines 获取域名可用的解析线路
+func (p *ResilientProvider) ListLines(ctx context.Context, domain string) ([]Line, error) {
+	lister, ok := p.provider.(LineLister)
+	if !ok {
+		return nil, fmt.Errorf("%s不支持查询解析线路", p.provider.GetName())
+	}
+
+	var lines []Line
+	err := p.do(ctx, domain, true, func() (err error) {
+		lines, err = lister.ListLines(ctx, domain)
+		return err
+	})
+	return lines, err
+}
+
+// SetRecordStatus 启用或暂停DNS记录
+func (p *ResilientProvider) SetRecordStatus(ctx context.Context, domain string, recordID string, enabled bool) error {
+	setter, ok := p.provider.(RecordStatusSetter)
+	if !ok {
+		return fmt.Errorf("%w: %s不支持暂停解析记录", ErrUnsupported, p.provider.GetName())
+	}
+
+	return p.do(ctx, domain, true, func() error {
+		return setter.SetRecordStatus(ctx, domain, recordID, enabled)
+	})
+}
+
+// ListRecordsFiltered 按条件获取域名记录列表
+func (p *ResilientProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	var records []DNSRecord
+	err := p.do(ctx, domain, true, func() (err error) {
+		records, err = ListRecordsWithFilter(ctx, p.provider, domain, filter)
+		return err
+	})
+	return records, err
+}
+
+// ReplaceRecord 更新DNS记录并返回更新后的记录
+func (p *ResilientProvider) ReplaceRecord(ctx context.Context, domain string, recordID string, record DNSRecord) (*DNSRecord, error) {
+	var updated *DNSRecord
+	err := p.do(ctx, domain, true, func() (err error) {
+		updated, err = UpdateRecordWithResult(ctx, p.provider, domain, recordID, record)
+		return err
+	})
+	return updated, err
+}
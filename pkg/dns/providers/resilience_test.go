@@ -0,0 +1,241 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// batchStub 按顺序返回预设结果的批量添加服务商
+type batchStub struct {
+	DNSProvider
+	calls   int
+	results [][]DNSRecord
+	errs    []error
+}
+
+func (s *batchStub) GetName() string {
+	return "stub"
+}
+
+func (s *batchStub) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	i := s.calls
+	s.calls++
+	return s.results[i], s.errs[i]
+}
+
+func newBatchStubProvider(t *testing.T, stub *batchStub) *ResilientProvider {
+	config := ProviderConfig{APIKey: t.Name(), ExtraParams: map[string]string{"rate_limit": "0"}}
+	return NewResilientProvider(stub, "mock", config, RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  time.Millisecond,
+		MaxDelay:      10 * time.Millisecond,
+		BackoffFactor: 2,
+	})
+}
+
+func TestResilientBatchAddPartialNotRetried(t *testing.T) {
+	rateLimited := &ProviderError{Provider: "stub", Kind: ErrRateLimited, Code: "Throttling"}
+	stub := &batchStub{
+		results: [][]DNSRecord{{{ID: "1", Name: "a"}}, {{ID: "2", Name: "a"}, {ID: "3", Name: "b"}}},
+		errs:    []error{rateLimited, nil},
+	}
+	provider := newBatchStubProvider(t, stub)
+
+	records := []DNSRecord{{Name: "a", Type: "A"}, {Name: "b", Type: "A"}}
+	created, err := provider.BatchAddRecords(context.Background(), "example.com", records)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("部分成功时应返回原始错误，实际为: %v", err)
+	}
+	if stub.calls != 1 {
+		t.Fatalf("部分成功后不应重试，实际调用%d次", stub.calls)
+	}
+	if len(created) != 1 || created[0].ID != "1" {
+		t.Fatalf("应返回已添加的记录: %+v", created)
+	}
+}
+
+func TestResilientBatchAddRetriedWithoutResults(t *testing.T) {
+	rateLimited := &ProviderError{Provider: "stub", Kind: ErrRateLimited, Code: "Throttling"}
+	stub := &batchStub{
+		results: [][]DNSRecord{nil, {{ID: "1"}, {ID: "2"}}},
+		errs:    []error{rateLimited, nil},
+	}
+	provider := newBatchStubProvider(t, stub)
+
+	created, err := provider.BatchAddRecords(context.Background(), "example.com", []DNSRecord{{Name: "a"}, {Name: "b"}})
+	if err != nil {
+		t.Fatalf("没有添加任何记录时应重试: %v", err)
+	}
+	if stub.calls != 2 || len(created) != 2 {
+		t.Fatalf("期望重试一次后成功，实际调用%d次，返回%d条记录", stub.calls, len(created))
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	ctx := context.Background()
+	serverErr := &ProviderError{Provider: "stub", Code: "InternalError", StatusCode: 500}
+	now := time.Unix(1700000000, 0)
+	breaker := &circuitBreaker{}
+
+	for i := 0; i < breakerFailureThreshold; i++ {
+		if _, ok := breaker.allow(now); !ok {
+			t.Fatalf("第%d次请求前不应熔断", i+1)
+		}
+		breaker.record(ctx, serverErr, now)
+	}
+	if remaining, ok := breaker.allow(now.Add(time.Second)); ok || remaining != breakerOpenDuration-time.Second {
+		t.Fatalf("连续失败后应熔断，实际为%v, %v", remaining, ok)
+	}
+
+	// 熔断到期后只放行一个试探请求，试探失败重新熔断
+	reopenAt := now.Add(breakerOpenDuration)
+	if _, ok := breaker.allow(reopenAt); !ok {
+		t.Fatalf("熔断到期后应放行试探请求")
+	}
+	if _, ok := breaker.allow(reopenAt); ok {
+		t.Fatalf("试探请求返回前不应放行其他请求")
+	}
+	breaker.record(ctx, serverErr, reopenAt)
+	if _, ok := breaker.allow(reopenAt.Add(time.Second)); ok {
+		t.Fatalf("试探失败后应重新熔断")
+	}
+
+	// 试探成功后恢复
+	closeAt := reopenAt.Add(breakerOpenDuration)
+	if _, ok := breaker.allow(closeAt); !ok {
+		t.Fatalf("熔断到期后应放行试探请求")
+	}
+	breaker.record(ctx, nil, closeAt)
+	if _, ok := breaker.allow(closeAt); !ok || breaker.failures != 0 {
+		t.Fatalf("试探成功后应恢复调用")
+	}
+}
+
+func TestCircuitBreakerIgnoresCancelledProbe(t *testing.T) {
+	serverErr := &ProviderError{Provider: "stub", Code: "InternalError", StatusCode: 500}
+	now := time.Unix(1700000000, 0)
+	breaker := &circuitBreaker{}
+	for i := 0; i < breakerFailureThreshold; i++ {
+		breaker.record(context.Background(), serverErr, now)
+	}
+
+	// 调用方取消的试探请求没有到达服务商，不应关闭熔断器，但需要释放试探名额
+	probeAt := now.Add(breakerOpenDuration)
+	if _, ok := breaker.allow(probeAt); !ok {
+		t.Fatalf("熔断到期后应放行试探请求")
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	breaker.record(cancelled, context.Canceled, probeAt)
+	if breaker.failures != breakerFailureThreshold {
+		t.Fatalf("取消的试探请求不应改变失败次数，实际为%d", breaker.failures)
+	}
+	if _, ok := breaker.allow(probeAt); !ok {
+		t.Fatalf("取消的试探请求应释放试探名额")
+	}
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), probeAt)
+	defer cancelExpired()
+	breaker.record(expired, context.DeadlineExceeded, probeAt)
+	if breaker.failures != breakerFailureThreshold {
+		t.Fatalf("超时的试探请求不应改变失败次数，实际为%d", breaker.failures)
+	}
+
+	// 本地错误不能说明服务商状态，服务商返回的业务错误说明服务商可用
+	breaker.allow(probeAt)
+	breaker.record(context.Background(), errors.New("无效的记录ID"), probeAt)
+	if breaker.failures != breakerFailureThreshold {
+		t.Fatalf("本地错误不应改变失败次数，实际为%d", breaker.failures)
+	}
+	breaker.allow(probeAt)
+	breaker.record(context.Background(), &ProviderError{Provider: "stub", Kind: ErrRecordNotFound, StatusCode: 404}, probeAt)
+	if breaker.failures != 0 {
+		t.Fatalf("服务商返回的业务错误应清零失败次数，实际为%d", breaker.failures)
+	}
+}
+
+func TestRetryDelayRetryAfter(t *testing.T) {
+	provider := &ResilientProvider{retryConfig: RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  10 * time.Millisecond,
+		MaxDelay:      2 * time.Second,
+		BackoffFactor: 2,
+	}}
+	ctx := context.Background()
+
+	// 服务商要求的等待时间长于退避时间时使用服务商的要求
+	rateLimited := &ProviderError{Provider: "stub", Kind: ErrRateLimited, RetryAfter: time.Second}
+	if delay, ok := provider.retryDelay(ctx, 1, rateLimited); !ok || delay != time.Second {
+		t.Fatalf("应等待Retry-After指定的时间，实际为%v, %v", delay, ok)
+	}
+
+	// 超过退避上限时放弃重试
+	rateLimited.RetryAfter = 3 * time.Second
+	if _, ok := provider.retryDelay(ctx, 1, rateLimited); ok {
+		t.Fatalf("Retry-After超过退避上限时不应重试")
+	}
+
+	// 请求截止时间早于等待结束时放弃重试
+	rateLimited.RetryAfter = time.Second
+	deadline, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, ok := provider.retryDelay(deadline, 1, rateLimited); ok {
+		t.Fatalf("等待时间超过请求截止时间时不应重试")
+	}
+}
+
+func TestBackoffDelayJitter(t *testing.T) {
+	config := RetryConfig{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, BackoffFactor: 2}
+
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		for i := 0; i < 200; i++ {
+			delay := backoffDelay(config, attempt)
+			if delay < want/2 || delay > want {
+				t.Fatalf("第%d次重试的等待时间%v不在[%v, %v]内", attempt, delay, want/2, want)
+			}
+		}
+	}
+}
+
+func TestTokenBucketPerCredential(t *testing.T) {
+	newProvider := func(apiKey string) *ResilientProvider {
+		config := ProviderConfig{APIKey: apiKey, ExtraParams: map[string]string{"rate_limit": "2"}}
+		return NewResilientProvider(&batchStub{}, "mock", config, DefaultRetryConfig)
+	}
+	first := newProvider(t.Name())
+	second := newProvider(t.Name())
+	other := newProvider(t.Name() + "-other")
+
+	if first.limiter != second.limiter || first.limiter == other.limiter {
+		t.Fatalf("相同凭据应共享令牌桶，不同凭据应使用独立的令牌桶")
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := first.limiter.wait(ctx); err != nil {
+			t.Fatalf("令牌充足时不应等待: %v", err)
+		}
+	}
+
+	// 同一凭据的另一个实例需要等待令牌补充
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := second.limiter.wait(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("令牌耗尽后应等待补充，实际为: %v", err)
+	}
+
+	start := time.Now()
+	if err := other.limiter.wait(ctx); err != nil || time.Since(start) > 50*time.Millisecond {
+		t.Fatalf("其他凭据的令牌桶不应受影响: %v", err)
+	}
+
+	start = time.Now()
+	if err := second.limiter.wait(ctx); err != nil {
+		t.Fatalf("等待令牌失败: %v", err)
+	}
+	if waited := time.Since(start); waited < 300*time.Millisecond {
+		t.Fatalf("每秒2个请求时应等待约0.5秒，实际等待%v", waited)
+	}
+}
@@ -0,0 +1,521 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// RFC2136Provider 基于RFC 2136动态更新的服务商，适用于BIND、Knot等没有HTTP API的权威服务器
+// 记录通过TSIG签名的DNS UPDATE报文增删，通过AXFR区域传送列出，
+// 记录ID格式为"子域名/记录类型/记录值摘要"
+//
+// ExtraParams支持以下参数：
+//   - nameserver: 权威服务器地址，未设置时使用Endpoint
+//   - port: 权威服务器端口，默认为53
+//   - key_name: TSIG密钥名称，未设置时使用APIKey
+//   - algorithm: TSIG算法，默认为hmac-sha256
+//   - secret: Base64编码的TSIG密钥，未设置时使用APISecret
+//   - zones: ListZones返回的域名，多个以逗号分隔
+type RFC2136Provider struct {
+	config    ProviderConfig
+	server    string
+	keyName   string
+	algorithm string
+	secret    string
+	timeout   time.Duration
+}
+
+// rfc2136Algorithms 支持的TSIG算法
+var rfc2136Algorithms = map[string]string{
+	"hmac-sha1":   dns.HmacSHA1,
+	"hmac-sha224": dns.HmacSHA224,
+	"hmac-sha256": dns.HmacSHA256,
+	"hmac-sha384": dns.HmacSHA384,
+	"hmac-sha512": dns.HmacSHA512,
+}
+
+// init 注册RFC 2136服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "rfc2136",
+		DisplayName: "RFC 2136",
+		Description: "通过DNS UPDATE管理BIND、Knot等权威服务器",
+		SortOrder:   12,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "PTR"},
+			SupportsBatch:        true,
+			SupportsLineTypes:    false,
+			MaxRecordsPerDomain:  100000,
+			MinTTL:               1,
+			MaxTTL:               2147483647,
+		},
+		ConfigSchema: []ConfigField{
+			{Key: "nameserver", Label: "权威服务器地址", Type: FieldText, RequiredUnless: []string{"endpoint"},
+				Placeholder: "ns1.example.com", Help: "接受DNS UPDATE的主服务器"},
+			{Key: "port", Label: "端口", Type: FieldNumber, Default: "53", Min: intPtr(1), Max: intPtr(65535)},
+			{Key: "key_name", Label: "TSIG密钥名称", Type: FieldText, RequiredUnless: []string{"api_key"}},
+			{Key: "algorithm", Label: "TSIG算法", Type: FieldSelect, Default: "hmac-sha256",
+				Options: []string{"hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512"}},
+			{Key: "secret", Label: "TSIG密钥", Type: FieldText, Secret: true, RequiredUnless: []string{"api_secret"},
+				Help: "Base64编码的密钥，与服务器上key语句中的secret一致"},
+			{Key: "zones", Label: "托管域名", Type: FieldText, Placeholder: "example.com,example.org",
+				Help: "服务器上托管的域名，多个以逗号分隔，用于导入域名"},
+		},
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewRFC2136Provider(config)
+		},
+	})
+}
+
+// NewRFC2136Provider 创建RFC 2136服务商实例
+func NewRFC2136Provider(config ProviderConfig) (*RFC2136Provider, error) {
+	nameserver := rfc2136Param(config, "nameserver", config.Endpoint)
+	if nameserver == "" {
+		return nil, fmt.Errorf("RFC 2136需要权威服务器地址")
+	}
+
+	port := rfc2136Param(config, "port", "53")
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return nil, fmt.Errorf("无效的端口: %s", port)
+	}
+
+	keyName := rfc2136Param(config, "key_name", config.APIKey)
+	if keyName != "" {
+		keyName = dns.Fqdn(keyName)
+	}
+
+	return &RFC2136Provider{
+		config:    config,
+		server:    net.JoinHostPort(strings.Trim(nameserver, "[]"), port),
+		keyName:   keyName,
+		algorithm: strings.ToLower(rfc2136Param(config, "algorithm", "hmac-sha256")),
+		secret:    rfc2136Param(config, "secret", config.APISecret),
+		timeout:   10 * time.Second,
+	}, nil
+}
+
+// rfc2136Param 读取ExtraParams中的参数，未设置时返回默认值
+func rfc2136Param(config ProviderConfig, key, fallback string) string {
+	if value := strings.TrimSpace(config.ExtraParams[key]); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// GetName 获取服务商名称
+func (p *RFC2136Provider) GetName() string {
+	return "rfc2136"
+}
+
+// ValidateConfig 验证API配置
+func (p *RFC2136Provider) ValidateConfig() error {
+	if p.keyName == "" {
+		return fmt.Errorf("TSIG密钥名称不能为空")
+	}
+	if p.secret == "" {
+		return fmt.Errorf("TSIG密钥不能为空")
+	}
+	if _, ok := rfc2136Algorithms[p.algorithm]; !ok {
+		return fmt.Errorf("不支持的TSIG算法: %s", p.algorithm)
+	}
+	return nil
+}
+
+// TestConnection 测试连接，对配置的域名发起TSIG签名的SOA查询
+func (p *RFC2136Provider) TestConnection(ctx context.Context) error {
+	zone := "."
+	if zones := p.configuredZones(); len(zones) > 0 {
+		zone = zones[0]
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(zone), dns.TypeSOA)
+	_, err := p.exchange(ctx, msg)
+	return err
+}
+
+// ListZones 获取配置的域名，DNS协议本身无法列出服务器上的全部域名
+func (p *RFC2136Provider) ListZones(ctx context.Context) ([]Zone, error) {
+	var zones []Zone
+	for _, name := range p.configuredZones() {
+		zones = append(zones, Zone{
+			ID:     name,
+			Name:   name,
+			Status: "active",
+		})
+	}
+	return zones, nil
+}
+
+// VerifyZone 校验权威服务器对域名有权威SOA记录
+func (p *RFC2136Provider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(domain), dns.TypeSOA)
+
+	response, err := p.exchange(ctx, msg)
+	if err != nil {
+		return "", err
+	}
+
+	for _, rr := range response.Answer {
+		if soa, ok := rr.(*dns.SOA); ok && strings.EqualFold(soa.Hdr.Name, dns.Fqdn(domain)) && response.Authoritative {
+			return domain, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
+}
+
+// ListRecords 获取域名记录列表
+func (p *RFC2136Provider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *RFC2136Provider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	rrs, err := p.transfer(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	for _, rr := range rrs {
+		records = append(records, rfc2136ToDNSRecord(rr, domain))
+	}
+	return filterRecords(records, filter), nil
+}
+
+// AddRecord 添加DNS记录
+func (p *RFC2136Provider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	results, err := p.BatchAddRecords(ctx, domain, []DNSRecord{record})
+	if err != nil {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+// UpdateRecord 更新DNS记录
+func (p *RFC2136Provider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	_, err := p.ReplaceRecord(ctx, domain, recordID, record)
+	return err
+}
+
+// ReplaceRecord 更新DNS记录并返回新的记录，删除旧记录和添加新记录在同一个UPDATE报文中原子执行
+func (p *RFC2136Provider) ReplaceRecord(ctx context.Context, domain string, recordID string, record DNSRecord) (*DNSRecord, error) {
+	old, err := p.findRecord(ctx, domain, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	rr, err := rfc2136NewRR(domain, record)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := new(dns.Msg)
+	msg.SetUpdate(dns.Fqdn(domain))
+	msg.Remove([]dns.RR{old})
+	msg.Insert([]dns.RR{rr})
+	if err := p.update(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	updated := rfc2136ToDNSRecord(rr, domain)
+	return &updated, nil
+}
+
+// DeleteRecord 删除DNS记录
+func (p *RFC2136Provider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	old, err := p.findRecord(ctx, domain, recordID)
+	if err != nil {
+		return err
+	}
+
+	msg := new(dns.Msg)
+	msg.SetUpdate(dns.Fqdn(domain))
+	msg.Remove([]dns.RR{old})
+	return p.update(ctx, msg)
+}
+
+// GetRecord 获取单个记录详情
+func (p *RFC2136Provider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	rr, err := p.findRecord(ctx, domain, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := rfc2136ToDNSRecord(rr, domain)
+	return &record, nil
+}
+
+// BatchAddRecords 批量添加DNS记录，所有记录在同一个UPDATE报文中原子添加
+func (p *RFC2136Provider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	rrs := make([]dns.RR, 0, len(records))
+	for _, record := range records {
+		rr, err := rfc2136NewRR(domain, record)
+		if err != nil {
+			return nil, err
+		}
+		rrs = append(rrs, rr)
+	}
+
+	msg := new(dns.Msg)
+	msg.SetUpdate(dns.Fqdn(domain))
+	msg.Insert(rrs)
+	if err := p.update(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	results := make([]DNSRecord, 0, len(rrs))
+	for _, rr := range rrs {
+		results = append(results, rfc2136ToDNSRecord(rr, domain))
+	}
+	return results, nil
+}
+
+// findRecord 通过区域传送查找记录ID对应的资源记录
+func (p *RFC2136Provider) findRecord(ctx context.Context, domain, recordID string) (dns.RR, error) {
+	parts := strings.SplitN(recordID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("无效的记录ID: %s", recordID)
+	}
+
+	rrs, err := p.transfer(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rr := range rrs {
+		if rfc2136RecordID(rr, domain) == recordID {
+			return rr, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrRecordNotFound, recordID)
+}
+
+// transfer 通过AXFR获取域名的全部资源记录，不包含SOA记录
+func (p *RFC2136Provider) transfer(ctx context.Context, domain string) ([]dns.RR, error) {
+	msg := new(dns.Msg)
+	msg.SetAxfr(dns.Fqdn(domain))
+	p.signMessage(msg)
+
+	transfer := &dns.Transfer{
+		DialTimeout:  p.timeout,
+		ReadTimeout:  p.timeout,
+		WriteTimeout: p.timeout,
+		TsigSecret:   p.tsigSecret(),
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < p.timeout {
+		transfer.ReadTimeout = time.Until(deadline)
+	}
+
+	envelopes, err := transfer.In(msg, p.server)
+	if err != nil {
+		return nil, rfc2136TransportError("区域传送失败", err)
+	}
+
+	var rrs []dns.RR
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			return nil, rfc2136TransportError("区域传送失败", envelope.Error)
+		}
+		for _, rr := range envelope.RR {
+			switch rr.Header().Rrtype {
+			case dns.TypeSOA, dns.TypeTSIG:
+				continue
+			}
+			rrs = append(rrs, rr)
+		}
+	}
+	return rrs, nil
+}
+
+// update 发送UPDATE报文
+func (p *RFC2136Provider) update(ctx context.Context, msg *dns.Msg) error {
+	_, err := p.exchange(ctx, msg)
+	return err
+}
+
+// exchange 通过TCP发送TSIG签名的报文并检查响应码
+func (p *RFC2136Provider) exchange(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	p.signMessage(msg)
+
+	client := &dns.Client{
+		Net:        "tcp",
+		Timeout:    p.timeout,
+		TsigSecret: p.tsigSecret(),
+	}
+
+	response, _, err := client.ExchangeContext(ctx, msg, p.server)
+	if err != nil {
+		return nil, rfc2136TransportError("发起请求失败", err)
+	}
+
+	if response.Rcode != dns.RcodeSuccess {
+		return nil, &ProviderError{
+			Provider:  "RFC 2136",
+			Kind:      rfc2136RcodeKinds[response.Rcode],
+			Code:      dns.RcodeToString[response.Rcode],
+			Message:   "DNS服务器拒绝请求",
+			Temporary: response.Rcode == dns.RcodeServerFailure,
+		}
+	}
+	return response, nil
+}
+
+// rfc2136RcodeKinds DNS响应码对应的错误分类
+var rfc2136RcodeKinds = map[int]error{
+	dns.RcodeNotAuth:   ErrAuthFailed,
+	dns.RcodeRefused:   ErrAuthFailed,
+	dns.RcodeBadSig:    ErrAuthFailed,
+	dns.RcodeNameError: ErrZoneNotFound,
+	dns.RcodeNotZone:   ErrZoneNotFound,
+	dns.RcodeYXRrset:   ErrRecordConflict,
+	dns.RcodeNXRrset:   ErrRecordNotFound,
+}
+
+// rfc2136TransportError 转换收发报文时的错误，响应的TSIG校验失败归类为认证失败
+// 服务器以NOTAUTH拒绝签名错误的请求时，客户端校验响应签名返回ErrAuth或ErrSig，不会返回响应码
+func rfc2136TransportError(action string, err error) error {
+	if errors.Is(err, dns.ErrSig) || errors.Is(err, dns.ErrAuth) || errors.Is(err, dns.ErrTime) {
+		return &ProviderError{
+			Provider: "RFC 2136",
+			Kind:     ErrAuthFailed,
+			Code:     "TSIG",
+			Message:  action + ": " + err.Error(),
+		}
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// signMessage 为报文添加TSIG签名
+func (p *RFC2136Provider) signMessage(msg *dns.Msg) {
+	if p.keyName == "" || p.secret == "" {
+		return
+	}
+	msg.SetTsig(p.keyName, rfc2136Algorithms[p.algorithm], 300, time.Now().Unix())
+}
+
+// tsigSecret 返回TSIG密钥表
+func (p *RFC2136Provider) tsigSecret() map[string]string {
+	if p.keyName == "" || p.secret == "" {
+		return nil
+	}
+	return map[string]string{p.keyName: p.secret}
+}
+
+// configuredZones 返回ExtraParams中配置的域名
+func (p *RFC2136Provider) configuredZones() []string {
+	var zones []string
+	for _, zone := range strings.Split(p.config.ExtraParams["zones"], ",") {
+		if zone = strings.TrimSuffix(strings.TrimSpace(zone), "."); zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// rfc2136NewRR 根据DNSRecord构建资源记录
+func rfc2136NewRR(domain string, record DNSRecord) (dns.RR, error) {
+	name := dns.Fqdn(domain)
+	if record.Name != "" && record.Name != "@" {
+		name = record.Name + "." + name
+	}
+
+	ttl := record.TTL
+	if ttl <= 0 {
+		ttl = 600
+	}
+
+	recordType := strings.ToUpper(record.Type)
+	var data string
+	switch recordType {
+	case "CNAME", "NS", "PTR":
+		data = dns.Fqdn(record.Value)
+	case "MX":
+		data = fmt.Sprintf("%d %s", record.Priority, dns.Fqdn(record.Value))
+	case "SRV":
+		data = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, dns.Fqdn(record.Value))
+	case "TXT":
+		data = record.Value
+		if !strings.HasPrefix(data, "\"") {
+			data = strconv.Quote(data)
+		}
+	default:
+		data = record.Value
+	}
+
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", name, ttl, recordType, data))
+	if err != nil {
+		return nil, fmt.Errorf("无效的记录: %w", err)
+	}
+	if rr == nil {
+		return nil, fmt.Errorf("无效的记录: %s %s", record.Name, record.Type)
+	}
+	return rr, nil
+}
+
+// rfc2136RecordID 生成资源记录的记录ID
+func rfc2136RecordID(rr dns.RR, domain string) string {
+	header := rr.Header()
+	return rfc2136RecordName(header.Name, domain) + "/" + dns.TypeToString[header.Rrtype] + "/" + valueDigest(rfc2136Data(rr))
+}
+
+// rfc2136RecordName 将完整域名转换为子域名，根域名返回"@"
+func rfc2136RecordName(name, domain string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	domain = strings.ToLower(domain)
+	if name == domain {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+domain)
+}
+
+// rfc2136Data 返回资源记录的记录值部分（不含名称、TTL、类别和类型）
+func rfc2136Data(rr dns.RR) string {
+	return strings.TrimSpace(strings.TrimPrefix(rr.String(), rr.Header().String()))
+}
+
+// rfc2136ToDNSRecord 将资源记录转换为DNSRecord
+func rfc2136ToDNSRecord(rr dns.RR, domain string) DNSRecord {
+	header := rr.Header()
+	record := DNSRecord{
+		ID:     rfc2136RecordID(rr, domain),
+		Name:   rfc2136RecordName(header.Name, domain),
+		Type:   dns.TypeToString[header.Rrtype],
+		Value:  rfc2136Data(rr),
+		TTL:    int(header.Ttl),
+		Status: "active",
+	}
+
+	switch value := rr.(type) {
+	case *dns.CNAME:
+		record.Value = strings.TrimSuffix(value.Target, ".")
+	case *dns.NS:
+		record.Value = strings.TrimSuffix(value.Ns, ".")
+	case *dns.PTR:
+		record.Value = strings.TrimSuffix(value.Ptr, ".")
+	case *dns.MX:
+		record.Priority = int(value.Preference)
+		record.Value = strings.TrimSuffix(value.Mx, ".")
+	case *dns.SRV:
+		record.Priority = int(value.Priority)
+		record.Weight = int(value.Weight)
+		record.Port = int(value.Port)
+		record.Value = strings.TrimSuffix(value.Target, ".")
+	case *dns.TXT:
+		record.Value = strings.Join(value.Txt, "")
+	}
+
+	return record
+}
@@ -0,0 +1,286 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	rfc2136TestKey    = "update-key."
+	rfc2136TestSecret = "c2VjcmV0LWtleS1mb3ItdGVzdHMtb25seQ=="
+)
+
+// rfc2136Fake 进程内的权威服务器，处理TSIG签名的SOA查询、AXFR和UPDATE报文
+type rfc2136Fake struct {
+	mu      sync.Mutex
+	zone    string
+	rrs     []dns.RR
+	rcode   int  // 不为0时下一个UPDATE报文以该响应码拒绝
+	lenient bool // 为true时不校验请求签名，用于模拟响应签名无法通过校验
+}
+
+func newRFC2136Fake(t *testing.T, secret string) (*rfc2136Fake, *RFC2136Provider) {
+	t.Helper()
+	fake := &rfc2136Fake{zone: "example.com."}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听端口失败: %v", err)
+	}
+	started := make(chan struct{})
+	server := &dns.Server{
+		Listener:          listener,
+		Net:               "tcp",
+		Handler:           fake,
+		TsigSecret:        map[string]string{rfc2136TestKey: rfc2136TestSecret},
+		NotifyStartedFunc: func() { close(started) },
+		// 默认的MsgAcceptFunc以NOTIMP拒绝UPDATE报文
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go server.ActivateAndServe()
+	<-started
+	t.Cleanup(func() { server.Shutdown() })
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	provider, err := NewRFC2136Provider(ProviderConfig{
+		Endpoint: "127.0.0.1",
+		ExtraParams: map[string]string{
+			"port":     port,
+			"key_name": strings.TrimSuffix(rfc2136TestKey, "."),
+			"secret":   secret,
+			"zones":    "example.com",
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	provider.timeout = 2 * time.Second
+	return fake, provider
+}
+
+// ServeDNS 处理请求，签名错误的请求以NOTAUTH和BADSIG拒绝
+func (f *rfc2136Fake) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reply := new(dns.Msg)
+	tsig := req.IsTsig()
+	if tsig == nil || (w.TsigStatus() != nil && !f.lenient) {
+		reply.SetRcode(req, dns.RcodeNotAuth)
+		if tsig != nil {
+			reply.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+			reply.Extra[len(reply.Extra)-1].(*dns.TSIG).Error = dns.RcodeBadSig
+		}
+		w.WriteMsg(reply)
+		return
+	}
+
+	switch {
+	case req.Opcode == dns.OpcodeUpdate:
+		reply.SetReply(req)
+		if f.rcode != 0 {
+			reply.Rcode, f.rcode = f.rcode, 0
+		} else if !strings.EqualFold(req.Question[0].Name, f.zone) {
+			reply.Rcode = dns.RcodeNotZone
+		} else {
+			f.update(req.Ns)
+		}
+	case req.Question[0].Qtype == dns.TypeAXFR:
+		reply.SetReply(req)
+		reply.Authoritative = true
+		reply.Answer = append(append([]dns.RR{f.soa()}, f.rrs...), f.soa())
+	case req.Question[0].Qtype == dns.TypeSOA && strings.EqualFold(req.Question[0].Name, f.zone):
+		reply.SetReply(req)
+		reply.Authoritative = true
+		reply.Answer = []dns.RR{f.soa()}
+	default:
+		reply.SetRcode(req, dns.RcodeRefused)
+	}
+
+	reply.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+	w.WriteMsg(reply)
+}
+
+// update 按RFC 2136执行更新段：类别IN添加记录，NONE删除指定记录，ANY删除整个记录集
+func (f *rfc2136Fake) update(changes []dns.RR) {
+	for _, change := range changes {
+		header := change.Header()
+		switch header.Class {
+		case dns.ClassINET:
+			f.remove(change, true)
+			f.rrs = append(f.rrs, dns.Copy(change))
+		case dns.ClassNONE:
+			f.remove(change, true)
+		case dns.ClassANY:
+			f.remove(change, false)
+		}
+	}
+}
+
+// remove 删除同名同类型的记录，matchData为true时只删除记录值相同的记录
+func (f *rfc2136Fake) remove(target dns.RR, matchData bool) {
+	kept := f.rrs[:0]
+	for _, rr := range f.rrs {
+		same := strings.EqualFold(rr.Header().Name, target.Header().Name) && rr.Header().Rrtype == target.Header().Rrtype
+		if same && (!matchData || rfc2136Data(rr) == rfc2136Data(target)) {
+			continue
+		}
+		kept = append(kept, rr)
+	}
+	f.rrs = kept
+}
+
+func (f *rfc2136Fake) soa() dns.RR {
+	rr, _ := dns.NewRR(f.zone + " 3600 IN SOA ns1." + f.zone + " hostmaster." + f.zone + " 1 3600 600 86400 300")
+	return rr
+}
+
+func TestRFC2136RecordRoundTrip(t *testing.T) {
+	fake, provider := newRFC2136Fake(t, rfc2136TestSecret)
+	ctx := context.Background()
+
+	if err := provider.TestConnection(ctx); err != nil {
+		t.Fatalf("测试连接失败: %v", err)
+	}
+	if id, err := provider.VerifyZone(ctx, "example.com"); err != nil || id != "example.com" {
+		t.Fatalf("校验域名失败: %q, %v", id, err)
+	}
+
+	created, err := provider.BatchAddRecords(ctx, "example.com", []DNSRecord{
+		{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300},
+		{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300},
+		{Name: "@", Type: "MX", Value: "mail.example.com", TTL: 300, Priority: 10},
+		{Name: "txt", Type: "TXT", Value: "hello world", TTL: 300},
+	})
+	if err != nil {
+		t.Fatalf("批量添加记录失败: %v", err)
+	}
+	if len(fake.rrs) != 4 {
+		t.Fatalf("期望服务器上有4条记录，实际为%d条", len(fake.rrs))
+	}
+
+	// 区域传送列出的记录不包含SOA，记录ID与添加时返回的一致
+	records, err := provider.ListRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("获取记录列表失败: %v", err)
+	}
+	if len(records) != len(created) {
+		t.Fatalf("记录列表不符合预期: %+v", records)
+	}
+	for i, record := range records {
+		if record.ID != created[i].ID {
+			t.Fatalf("记录ID不一致: %s, %s", record.ID, created[i].ID)
+		}
+	}
+	if records[2].Name != "@" || records[2].Value != "mail.example.com" || records[2].Priority != 10 {
+		t.Fatalf("MX记录不一致: %+v", records[2])
+	}
+	if records[3].Value != "hello world" {
+		t.Fatalf("TXT记录不一致: %+v", records[3])
+	}
+
+	replaced, err := provider.ReplaceRecord(ctx, "example.com", created[0].ID, DNSRecord{Name: "www", Type: "A", Value: "192.0.2.3", TTL: 600})
+	if err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	if _, err := provider.GetRecord(ctx, "example.com", created[0].ID); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("原记录ID应返回ErrRecordNotFound，实际为: %v", err)
+	}
+	got, err := provider.GetRecord(ctx, "example.com", replaced.ID)
+	if err != nil || got.Value != "192.0.2.3" || got.TTL != 600 {
+		t.Fatalf("更新后的记录不一致: %+v, %v", got, err)
+	}
+
+	// 删除单条记录只移除该记录值，同一记录集的其他记录值保留
+	if err := provider.DeleteRecord(ctx, "example.com", replaced.ID); err != nil {
+		t.Fatalf("删除记录失败: %v", err)
+	}
+	if _, err := provider.GetRecord(ctx, "example.com", created[1].ID); err != nil {
+		t.Fatalf("同一记录集的其他记录值不应被删除: %v", err)
+	}
+	if len(fake.rrs) != 3 {
+		t.Fatalf("期望服务器上剩余3条记录，实际为%d条", len(fake.rrs))
+	}
+}
+
+func TestRFC2136RcodeKinds(t *testing.T) {
+	fake, provider := newRFC2136Fake(t, rfc2136TestSecret)
+	ctx := context.Background()
+	record := DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300}
+
+	for rcode, kind := range rfc2136RcodeKinds {
+		if rcode == dns.RcodeBadSig {
+			// BADSIG只出现在TSIG记录中，由签名错误的测试覆盖
+			continue
+		}
+		fake.mu.Lock()
+		fake.rcode = rcode
+		fake.mu.Unlock()
+
+		_, err := provider.AddRecord(ctx, "example.com", record)
+		if !errors.Is(err, kind) {
+			t.Errorf("响应码%s应归类为%v，实际为: %v", dns.RcodeToString[rcode], kind, err)
+		}
+	}
+
+	fake.mu.Lock()
+	fake.rcode = dns.RcodeServerFailure
+	fake.mu.Unlock()
+	if _, err := provider.AddRecord(ctx, "example.com", record); !IsRetryable(err) {
+		t.Errorf("SERVFAIL应可重试，实际为: %v", err)
+	}
+
+	if _, err := provider.AddRecord(ctx, "other.com", record); !errors.Is(err, ErrZoneNotFound) {
+		t.Errorf("服务器未托管的域名应返回ErrZoneNotFound，实际为: %v", err)
+	}
+}
+
+func TestRFC2136BadSecret(t *testing.T) {
+	fake, provider := newRFC2136Fake(t, "d3Jvbmctc2VjcmV0")
+	ctx := context.Background()
+
+	// 服务器以NOTAUTH和BADSIG拒绝，客户端校验响应时返回dns.ErrAuth
+	if err := provider.TestConnection(ctx); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("签名错误应返回ErrAuthFailed，实际为: %v", err)
+	}
+	if _, err := provider.ListRecords(ctx, "example.com"); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("区域传送签名错误应返回ErrAuthFailed，实际为: %v", err)
+	}
+
+	// 服务器接受请求但响应签名无法通过校验时，客户端返回dns.ErrSig
+	fake.lenient = true
+	if _, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1"}); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("响应签名错误应返回ErrAuthFailed，实际为: %v", err)
+	}
+}
+
+func TestRFC2136Config(t *testing.T) {
+	provider, err := NewRFC2136Provider(ProviderConfig{
+		APIKey:      "key",
+		APISecret:   rfc2136TestSecret,
+		Endpoint:    "2001:db8::53",
+		ExtraParams: map[string]string{"port": "5353", "zones": " example.com., example.org "},
+	})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	if provider.server != "[2001:db8::53]:5353" || provider.keyName != "key." {
+		t.Fatalf("服务器地址或密钥名称不一致: %s %s", provider.server, provider.keyName)
+	}
+	zones, _ := provider.ListZones(context.Background())
+	if len(zones) != 2 || zones[0].Name != "example.com" || zones[1].Name != "example.org" {
+		t.Fatalf("配置的域名不一致: %+v", zones)
+	}
+
+	if _, err := NewRFC2136Provider(ProviderConfig{Endpoint: "ns1", ExtraParams: map[string]string{"port": strconv.Itoa(70000)}}); err == nil {
+		t.Fatalf("无效的端口应返回错误")
+	}
+}
@@ -0,0 +1,884 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Route53Provider AWS Route 53服务商
+// Route 53以记录集（RRset）为单位管理记录，适配器将记录集中的每个记录值映射为一条DNSRecord，
+// 记录ID格式为"子域名/记录类型/记录值摘要/SetIdentifier"，SetIdentifier仅加权等路由记录才有
+//
+// 别名记录和加权路由记录通过DNSRecord.Extra传递：
+//   - alias_target: 别名目标域名，设置后为别名记录，Value被忽略
+//   - alias_hosted_zone_id: 别名目标所在的Hosted Zone ID
+//   - evaluate_target_health: 是否检查别名目标健康状态，true或false
+//   - set_identifier: 路由记录标识
+//   - weight: 加权路由权重
+type Route53Provider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	endpoint   string
+	region     string
+}
+
+const (
+	// route53APIVersion API版本路径
+	route53APIVersion = "/2013-04-01"
+	// route53Namespace 请求XML命名空间
+	route53Namespace = "https://route53.amazonaws.com/doc/2013-04-01/"
+	// route53MaxChanges 单个变更批次的最大变更数
+	route53MaxChanges = 1000
+)
+
+// init 注册AWS Route 53服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "route53",
+		DisplayName: "AWS Route 53",
+		Description: "Amazon Route 53 DNS服务",
+		SortOrder:   11,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "PTR"},
+			SupportsBatch:        true,
+			SupportsLineTypes:    false,
+			MaxRecordsPerDomain:  10000,
+			MinTTL:               1,
+			MaxTTL:               2147483647,
+			RateLimit:            5,
+			RateBurst:            5,
+		},
+		ConfigSchema: accessKeySchema("Access Key ID", "Secret Access Key",
+			ConfigField{Key: "token", Label: "Session Token", Type: FieldText, Secret: true, Help: "使用STS临时凭据时填写"},
+			ConfigField{Key: "region", Label: "签名区域", Type: FieldText, Default: "us-east-1",
+				Help: "Route 53是全局服务，中国区使用cn-northwest-1"},
+		),
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewRoute53Provider(config)
+		},
+	})
+}
+
+// NewRoute53Provider 创建Route 53服务商实例
+func NewRoute53Provider(config ProviderConfig) (*Route53Provider, error) {
+	if config.APIKey == "" || config.APISecret == "" {
+		return nil, fmt.Errorf("Route 53需要Access Key ID和Secret Access Key")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://route53.amazonaws.com"
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &Route53Provider{
+		config:   config,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		region:   region,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetName 获取服务商名称
+func (p *Route53Provider) GetName() string {
+	return "route53"
+}
+
+// ValidateConfig 验证API配置
+func (p *Route53Provider) ValidateConfig() error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("Route 53 Access Key ID不能为空")
+	}
+	if p.config.APISecret == "" {
+		return fmt.Errorf("Route 53 Secret Access Key不能为空")
+	}
+	return nil
+}
+
+// TestConnection 测试连接
+func (p *Route53Provider) TestConnection(ctx context.Context) error {
+	_, err := p.makeRequest(ctx, "GET", route53APIVersion+"/hostedzone", url.Values{"maxitems": {"1"}}, nil)
+	return err
+}
+
+// route53HostedZone Route 53 API返回的Hosted Zone
+type route53HostedZone struct {
+	ID                     string `xml:"Id"`
+	Name                   string `xml:"Name"`
+	ResourceRecordSetCount int    `xml:"ResourceRecordSetCount"`
+	Config                 struct {
+		PrivateZone bool `xml:"PrivateZone"`
+	} `xml:"Config"`
+}
+
+// ListZones 获取账号下的全部公有Hosted Zone
+func (p *Route53Provider) ListZones(ctx context.Context) ([]Zone, error) {
+	var zones []Zone
+	marker := ""
+
+	for {
+		query := url.Values{"maxitems": {"100"}}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		response, err := p.makeRequest(ctx, "GET", route53APIVersion+"/hostedzone", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			HostedZones []route53HostedZone `xml:"HostedZones>HostedZone"`
+			IsTruncated bool                `xml:"IsTruncated"`
+			NextMarker  string              `xml:"NextMarker"`
+		}
+		if err := xml.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, zone := range result.HostedZones {
+			if zone.Config.PrivateZone {
+				continue
+			}
+			zones = append(zones, Zone{
+				ID:          strings.TrimPrefix(zone.ID, "/hostedzone/"),
+				Name:        route53UnescapeName(zone.Name),
+				Status:      "active",
+				RecordCount: zone.ResourceRecordSetCount,
+			})
+		}
+
+		if !result.IsTruncated || result.NextMarker == "" {
+			break
+		}
+		marker = result.NextMarker
+	}
+
+	return zones, nil
+}
+
+// VerifyZone 校验域名在Route 53中存在公有Hosted Zone
+func (p *Route53Provider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	return p.getZoneID(ctx, domain)
+}
+
+// route53AliasTarget 别名目标
+type route53AliasTarget struct {
+	HostedZoneID         string `xml:"HostedZoneId"`
+	DNSName              string `xml:"DNSName"`
+	EvaluateTargetHealth bool   `xml:"EvaluateTargetHealth"`
+}
+
+// route53RRset Route 53记录集
+type route53RRset struct {
+	Name            string              `xml:"Name"`
+	Type            string              `xml:"Type"`
+	SetIdentifier   string              `xml:"SetIdentifier,omitempty"`
+	Weight          *int64              `xml:"Weight,omitempty"`
+	TTL             *int64              `xml:"TTL,omitempty"`
+	ResourceRecords *route53Values      `xml:"ResourceRecords,omitempty"`
+	AliasTarget     *route53AliasTarget `xml:"AliasTarget,omitempty"`
+}
+
+// route53Values 记录集的记录值列表
+type route53Values struct {
+	Records []route53ResourceRecord `xml:"ResourceRecord"`
+}
+
+// route53ResourceRecord 单个记录值
+type route53ResourceRecord struct {
+	Value string `xml:"Value"`
+}
+
+// newRoute53Values 根据记录值构建记录值列表
+func newRoute53Values(values []string) *route53Values {
+	result := &route53Values{}
+	for _, value := range values {
+		result.Records = append(result.Records, route53ResourceRecord{Value: value})
+	}
+	return result
+}
+
+// values 返回记录集的记录值，别名记录返回别名目标
+func (s route53RRset) values() []string {
+	if s.AliasTarget != nil {
+		return []string{s.AliasTarget.DNSName}
+	}
+	if s.ResourceRecords == nil {
+		return nil
+	}
+	values := make([]string, 0, len(s.ResourceRecords.Records))
+	for _, record := range s.ResourceRecords.Records {
+		values = append(values, record.Value)
+	}
+	return values
+}
+
+// key 返回记录集的唯一标识
+func (s route53RRset) key() string {
+	return strings.ToLower(route53UnescapeName(s.Name)) + "/" + s.Type + "/" + s.SetIdentifier
+}
+
+// route53Change 记录集变更
+type route53Change struct {
+	Action            string       `xml:"Action"`
+	ResourceRecordSet route53RRset `xml:"ResourceRecordSet"`
+}
+
+// ListRecords 获取域名记录列表
+func (p *Route53Provider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *Route53Provider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	var records []DNSRecord
+	err = p.listRRsets(ctx, zoneID, "", "", func(rrset route53RRset) bool {
+		// SOA记录由Route 53维护，不对外暴露
+		if rrset.Type == "SOA" {
+			return true
+		}
+		for _, value := range rrset.values() {
+			records = append(records, route53ToDNSRecord(rrset, value, domain))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(records, filter), nil
+}
+
+// AddRecord 添加DNS记录，向同名同类型的记录集追加记录值
+func (p *Route53Provider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	results, err := p.BatchAddRecords(ctx, domain, []DNSRecord{record})
+	if err != nil {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+// UpdateRecord 更新DNS记录
+func (p *Route53Provider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	_, err := p.ReplaceRecord(ctx, domain, recordID, record)
+	return err
+}
+
+// ReplaceRecord 更新DNS记录并返回新的记录，所有变更在同一个变更批次中原子提交
+func (p *Route53Provider) ReplaceRecord(ctx context.Context, domain string, recordID string, record DNSRecord) (*DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	oldSet, index, err := p.findValue(ctx, zoneID, domain, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := route53NewRRset(domain, record)
+	var changes []route53Change
+
+	if oldSet.key() == target.key() {
+		if target.AliasTarget == nil {
+			values := append([]string(nil), oldSet.values()...)
+			values[index] = route53EncodeValue(record)
+			target.ResourceRecords = newRoute53Values(values)
+		}
+		changes = append(changes, route53Change{Action: "UPSERT", ResourceRecordSet: target})
+	} else {
+		// 子域名、类型或路由标识变化时，从原记录集移除记录值并写入目标记录集
+		changes = append(changes, route53Remove(*oldSet, index))
+
+		existing, err := p.getRRset(ctx, zoneID, target)
+		if err != nil {
+			return nil, err
+		}
+		merged, err := route53Merge(existing, target, record)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, route53Change{Action: "UPSERT", ResourceRecordSet: merged})
+	}
+
+	if err := p.changeRRsets(ctx, zoneID, changes); err != nil {
+		return nil, err
+	}
+
+	updated := route53ToDNSRecord(target, route53EncodeValue(record), domain)
+	if target.AliasTarget != nil {
+		updated = route53ToDNSRecord(target, target.AliasTarget.DNSName, domain)
+	}
+	return &updated, nil
+}
+
+// DeleteRecord 删除DNS记录，记录集中最后一个记录值被删除时删除整个记录集
+func (p *Route53Provider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	rrset, index, err := p.findValue(ctx, zoneID, domain, recordID)
+	if err != nil {
+		return err
+	}
+
+	return p.changeRRsets(ctx, zoneID, []route53Change{route53Remove(*rrset, index)})
+}
+
+// GetRecord 获取单个记录详情
+func (p *Route53Provider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	rrset, index, err := p.findValue(ctx, zoneID, domain, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := route53ToDNSRecord(*rrset, rrset.values()[index], domain)
+	return &record, nil
+}
+
+// BatchAddRecords 批量添加DNS记录，所有记录在同一个变更批次中原子提交
+func (p *Route53Provider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	// 按记录集合并记录值，保持首次出现的顺序
+	var keys []string
+	merged := make(map[string]route53RRset)
+	results := make([]DNSRecord, 0, len(records))
+
+	for _, record := range records {
+		target := route53NewRRset(domain, record)
+		key := target.key()
+
+		current, ok := merged[key]
+		if !ok {
+			existing, err := p.getRRset(ctx, zoneID, target)
+			if err != nil {
+				return nil, err
+			}
+			current = existing
+			keys = append(keys, key)
+		}
+
+		next, err := route53Merge(current, target, record)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = next
+
+		value := route53EncodeValue(record)
+		if target.AliasTarget != nil {
+			value = target.AliasTarget.DNSName
+		}
+		results = append(results, route53ToDNSRecord(next, value, domain))
+	}
+
+	changes := make([]route53Change, 0, len(keys))
+	for _, key := range keys {
+		changes = append(changes, route53Change{Action: "UPSERT", ResourceRecordSet: merged[key]})
+	}
+	if err := p.changeRRsets(ctx, zoneID, changes); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// findValue 根据记录ID查找记录集及记录值所在位置
+func (p *Route53Provider) findValue(ctx context.Context, zoneID, domain, recordID string) (*route53RRset, int, error) {
+	parts := strings.SplitN(recordID, "/", 4)
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, 0, fmt.Errorf("无效的记录ID: %s", recordID)
+	}
+
+	rrset, err := p.getRRset(ctx, zoneID, route53RRset{
+		Name:          route53RecordName(parts[0], domain),
+		Type:          parts[1],
+		SetIdentifier: parts[3],
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i, value := range rrset.values() {
+		if valueDigest(value) == parts[2] {
+			return &rrset, i, nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf("%w: %s", ErrRecordNotFound, recordID)
+}
+
+// getRRset 获取与目标名称、类型和路由标识相同的记录集，不存在时返回不含记录值的记录集
+func (p *Route53Provider) getRRset(ctx context.Context, zoneID string, target route53RRset) (route53RRset, error) {
+	var found *route53RRset
+	reached := false
+
+	err := p.listRRsets(ctx, zoneID, target.Name, target.Type, func(rrset route53RRset) bool {
+		if rrset.key() == target.key() {
+			found = &rrset
+			return false
+		}
+		// 结果从目标位置开始按名称和类型排序，越过目标名称和类型后即可结束
+		sameGroup := strings.EqualFold(route53UnescapeName(rrset.Name), route53UnescapeName(target.Name)) && rrset.Type == target.Type
+		if reached && !sameGroup {
+			return false
+		}
+		reached = reached || sameGroup
+		return true
+	})
+	if err != nil {
+		return route53RRset{}, err
+	}
+
+	if found != nil {
+		return *found, nil
+	}
+	return route53RRset{Name: target.Name, Type: target.Type, SetIdentifier: target.SetIdentifier}, nil
+}
+
+// listRRsets 分页遍历记录集，name和type不为空时从该位置开始列出，visit返回false时停止遍历
+func (p *Route53Provider) listRRsets(ctx context.Context, zoneID, name, recordType string, visit func(route53RRset) bool) error {
+	identifier := ""
+
+	for {
+		query := url.Values{"maxitems": {"300"}}
+		if name != "" {
+			query.Set("name", name)
+		}
+		if recordType != "" {
+			query.Set("type", recordType)
+		}
+		if identifier != "" {
+			query.Set("identifier", identifier)
+		}
+
+		response, err := p.makeRequest(ctx, "GET", route53APIVersion+"/hostedzone/"+zoneID+"/rrset", query, nil)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			ResourceRecordSets   []route53RRset `xml:"ResourceRecordSets>ResourceRecordSet"`
+			IsTruncated          bool           `xml:"IsTruncated"`
+			NextRecordName       string         `xml:"NextRecordName"`
+			NextRecordType       string         `xml:"NextRecordType"`
+			NextRecordIdentifier string         `xml:"NextRecordIdentifier"`
+		}
+		if err := xml.Unmarshal(response, &result); err != nil {
+			return fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, rrset := range result.ResourceRecordSets {
+			if !visit(rrset) {
+				return nil
+			}
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		name, recordType, identifier = result.NextRecordName, result.NextRecordType, result.NextRecordIdentifier
+	}
+}
+
+// changeRRsets 提交记录集变更，单个批次内的变更由Route 53原子执行
+func (p *Route53Provider) changeRRsets(ctx context.Context, zoneID string, changes []route53Change) error {
+	if len(changes) > route53MaxChanges {
+		return fmt.Errorf("单次变更不能超过%d个记录集", route53MaxChanges)
+	}
+
+	request := struct {
+		XMLName xml.Name        `xml:"ChangeResourceRecordSetsRequest"`
+		Xmlns   string          `xml:"xmlns,attr"`
+		Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+	}{
+		Xmlns:   route53Namespace,
+		Changes: changes,
+	}
+
+	payload, err := xml.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("序列化请求数据失败: %w", err)
+	}
+
+	_, err = p.makeRequest(ctx, "POST", route53APIVersion+"/hostedzone/"+zoneID+"/rrset/", nil, append([]byte(xml.Header), payload...))
+	return err
+}
+
+// getZoneID 获取域名的公有Hosted Zone ID，结果按凭据缓存
+func (p *Route53Provider) getZoneID(ctx context.Context, domain string) (string, error) {
+	return cachedZoneID(ctx, "route53", p.config, domain, p.lookupZoneID)
+}
+
+// lookupZoneID 查询域名的公有Hosted Zone ID
+func (p *Route53Provider) lookupZoneID(ctx context.Context, domain string) (string, error) {
+	query := url.Values{
+		"dnsname":  {domain},
+		"maxitems": {"10"},
+	}
+	response, err := p.makeRequest(ctx, "GET", route53APIVersion+"/hostedzonesbyname", query, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		HostedZones []route53HostedZone `xml:"HostedZones>HostedZone"`
+	}
+	if err := xml.Unmarshal(response, &result); err != nil {
+		return "", fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	for _, zone := range result.HostedZones {
+		if !zone.Config.PrivateZone && strings.EqualFold(route53UnescapeName(zone.Name), domain) {
+			return strings.TrimPrefix(zone.ID, "/hostedzone/"), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
+}
+
+// makeRequest 发起API请求
+func (p *Route53Provider) makeRequest(ctx context.Context, method, path string, query url.Values, payload []byte) ([]byte, error) {
+	requestURL := p.endpoint + path
+	if len(query) > 0 {
+		requestURL += "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	p.sign(req, payload, time.Now().UTC())
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发起请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errorResp struct {
+			Error struct {
+				Code    string `xml:"Code"`
+				Message string `xml:"Message"`
+			} `xml:"Error"`
+			Messages  []string `xml:"Messages>Message"`
+			RequestID string   `xml:"RequestId"`
+		}
+		if err := xml.Unmarshal(body, &errorResp); err == nil {
+			if errorResp.Error.Code != "" {
+				return nil, newHTTPError("Route 53", resp, errorResp.Error.Code, errorResp.Error.Message, errorResp.RequestID)
+			}
+			// InvalidChangeBatch错误以Messages列出每个变更的错误
+			if len(errorResp.Messages) > 0 {
+				message := strings.Join(errorResp.Messages, "; ")
+				apiErr := newHTTPError("Route 53", resp, "InvalidChangeBatch", message, errorResp.RequestID)
+				switch {
+				case strings.Contains(message, "already exists"):
+					apiErr.Kind = ErrRecordConflict
+				case strings.Contains(message, "not found"):
+					apiErr.Kind = ErrRecordNotFound
+				}
+				return nil, apiErr
+			}
+		}
+		return nil, newStatusError("Route 53", resp, string(body))
+	}
+
+	return body, nil
+}
+
+// sign 按AWS Signature Version 4规则为请求添加签名
+func (p *Route53Provider) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := amzDate[:8]
+	payloadHash := sha256Hex(string(payload))
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if p.config.Token != "" {
+		req.Header.Set("X-Amz-Security-Token", p.config.Token)
+	}
+
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for key, values := range req.Header {
+		lower := strings.ToLower(key)
+		if len(values) == 0 || (lower != "content-type" && !strings.HasPrefix(lower, "x-amz-")) {
+			continue
+		}
+		headers[lower] = strings.TrimSpace(values[0])
+	}
+
+	signedHeaders := make([]string, 0, len(headers))
+	for key := range headers {
+		signedHeaders = append(signedHeaders, key)
+	}
+	sort.Strings(signedHeaders)
+
+	var canonicalHeaders strings.Builder
+	for _, key := range signedHeaders {
+		canonicalHeaders.WriteString(key + ":" + headers[key] + "\n")
+	}
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+
+	canonicalRequest := req.Method + "\n" +
+		canonicalURI + "\n" +
+		route53CanonicalQuery(req.URL.Query()) + "\n" +
+		canonicalHeaders.String() + "\n" +
+		strings.Join(signedHeaders, ";") + "\n" +
+		payloadHash
+
+	credentialScope := shortDate + "/" + p.region + "/route53/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" +
+		amzDate + "\n" +
+		credentialScope + "\n" +
+		sha256Hex(canonicalRequest)
+
+	kDate := hmacSha256([]byte("AWS4"+p.config.APISecret), shortDate)
+	kRegion := hmacSha256(kDate, p.region)
+	kService := hmacSha256(kRegion, "route53")
+	kSigning := hmacSha256(kService, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(kSigning, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+p.config.APIKey+"/"+credentialScope+
+		", SignedHeaders="+strings.Join(signedHeaders, ";")+
+		", Signature="+signature)
+}
+
+// route53CanonicalQuery 规范化查询字符串
+func route53CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEscape(key)+"="+uriEscape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// route53NewRRset 根据DNSRecord构建目标记录集（不含已有记录值）
+func route53NewRRset(domain string, record DNSRecord) route53RRset {
+	rrset := route53RRset{
+		Name:          route53RecordName(record.Name, domain),
+		Type:          strings.ToUpper(record.Type),
+		SetIdentifier: record.Extra["set_identifier"],
+	}
+
+	if weight, err := strconv.ParseInt(record.Extra["weight"], 10, 64); err == nil && rrset.SetIdentifier != "" {
+		rrset.Weight = &weight
+	}
+
+	if alias := record.Extra["alias_target"]; alias != "" {
+		rrset.AliasTarget = &route53AliasTarget{
+			HostedZoneID:         record.Extra["alias_hosted_zone_id"],
+			DNSName:              strings.TrimSuffix(alias, ".") + ".",
+			EvaluateTargetHealth: record.Extra["evaluate_target_health"] == "true",
+		}
+		return rrset
+	}
+
+	ttl := int64(record.TTL)
+	if ttl <= 0 {
+		ttl = 300
+	}
+	rrset.TTL = &ttl
+	rrset.ResourceRecords = newRoute53Values(nil)
+	return rrset
+}
+
+// route53Merge 将记录值合并到已有记录集，别名记录直接替换
+func route53Merge(existing, target route53RRset, record DNSRecord) (route53RRset, error) {
+	if target.AliasTarget != nil {
+		if len(existing.values()) > 0 && existing.AliasTarget == nil {
+			return route53RRset{}, fmt.Errorf("记录集已存在普通记录，不能设置为别名: %s %s", record.Name, record.Type)
+		}
+		return target, nil
+	}
+	if existing.AliasTarget != nil {
+		return route53RRset{}, fmt.Errorf("记录集已是别名记录: %s %s", record.Name, record.Type)
+	}
+
+	value := route53EncodeValue(record)
+	values := append([]string(nil), existing.values()...)
+	for _, current := range values {
+		if current == value {
+			return route53RRset{}, fmt.Errorf("%w: %s %s %s", ErrRecordConflict, record.Name, record.Type, record.Value)
+		}
+	}
+
+	merged := target
+	merged.ResourceRecords = newRoute53Values(append(values, value))
+	return merged, nil
+}
+
+// route53Remove 构建从记录集中移除一个记录值的变更，DELETE需要提交与现有记录集完全一致的内容
+func route53Remove(rrset route53RRset, index int) route53Change {
+	values := rrset.values()
+	if rrset.AliasTarget != nil || len(values) <= 1 {
+		return route53Change{Action: "DELETE", ResourceRecordSet: rrset}
+	}
+
+	remaining := make([]string, 0, len(values)-1)
+	remaining = append(remaining, values[:index]...)
+	remaining = append(remaining, values[index+1:]...)
+
+	updated := rrset
+	updated.ResourceRecords = newRoute53Values(remaining)
+	return route53Change{Action: "UPSERT", ResourceRecordSet: updated}
+}
+
+// route53RecordName 将子域名转换为完整域名（以"."结尾）
+func route53RecordName(name, domain string) string {
+	if name == "" || name == "@" {
+		return domain + "."
+	}
+	return name + "." + domain + "."
+}
+
+// route53UnescapeName 还原Route 53返回的八进制转义字符（如"\052"表示"*"）并去掉末尾的"."
+func route53UnescapeName(name string) string {
+	var builder strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+3 < len(name) {
+			if code, err := strconv.ParseUint(name[i+1:i+4], 8, 8); err == nil {
+				builder.WriteByte(byte(code))
+				i += 3
+				continue
+			}
+		}
+		builder.WriteByte(name[i])
+	}
+	return strings.TrimSuffix(builder.String(), ".")
+}
+
+// route53EncodeValue 将DNSRecord转换为Route 53记录值格式
+func route53EncodeValue(record DNSRecord) string {
+	switch strings.ToUpper(record.Type) {
+	case "MX":
+		return fmt.Sprintf("%d %s", record.Priority, record.Value)
+	case "SRV":
+		return fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Value)
+	case "TXT", "SPF":
+		if strings.HasPrefix(record.Value, "\"") {
+			return record.Value
+		}
+		return strconv.Quote(record.Value)
+	default:
+		return record.Value
+	}
+}
+
+// route53ToDNSRecord 将记录集中的单个记录值转换为DNSRecord
+func route53ToDNSRecord(rrset route53RRset, value, domain string) DNSRecord {
+	name := route53UnescapeName(rrset.Name)
+	if strings.EqualFold(name, domain) {
+		name = "@"
+	} else {
+		name = strings.TrimSuffix(name, "."+domain)
+	}
+
+	record := DNSRecord{
+		ID:     name + "/" + rrset.Type + "/" + valueDigest(value) + "/" + rrset.SetIdentifier,
+		Name:   name,
+		Type:   rrset.Type,
+		Value:  value,
+		Status: "active",
+	}
+	if rrset.TTL != nil {
+		record.TTL = int(*rrset.TTL)
+	}
+
+	extra := map[string]string{}
+	if rrset.SetIdentifier != "" {
+		extra["set_identifier"] = rrset.SetIdentifier
+	}
+	if rrset.Weight != nil {
+		extra["weight"] = strconv.FormatInt(*rrset.Weight, 10)
+	}
+	if alias := rrset.AliasTarget; alias != nil {
+		record.Value = strings.TrimSuffix(alias.DNSName, ".")
+		extra["alias_target"] = record.Value
+		extra["alias_hosted_zone_id"] = alias.HostedZoneID
+		extra["evaluate_target_health"] = strconv.FormatBool(alias.EvaluateTargetHealth)
+	}
+	if len(extra) > 0 {
+		record.Extra = extra
+	}
+
+	fields := strings.Fields(value)
+	switch {
+	case rrset.AliasTarget != nil:
+	case rrset.Type == "MX" && len(fields) == 2:
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Value = fields[1]
+	case rrset.Type == "SRV" && len(fields) == 4:
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Weight, _ = strconv.Atoi(fields[1])
+		record.Port, _ = strconv.Atoi(fields[2])
+		record.Value = fields[3]
+	case rrset.Type == "TXT" || rrset.Type == "SPF":
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			record.Value = unquoted
+		}
+	}
+
+	return record
+}
@@ -0,0 +1,487 @@
+package providers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	route53TestAccessKey = "AKIDTEST"
+	route53TestSecretKey = "route53-test-secret"
+	route53TestZoneID    = "Z0TEST"
+)
+
+// route53Fake Route 53接口的内存实现，校验每个请求的SigV4签名，DELETE要求与现有记录集完全一致
+type route53Fake struct {
+	mu       sync.Mutex
+	rrsets   map[string]route53RRset
+	pageSize int
+	changes  [][]route53Change
+}
+
+func newRoute53Fake(t *testing.T) (*route53Fake, *Route53Provider) {
+	t.Helper()
+	fake := &route53Fake{rrsets: make(map[string]route53RRset), pageSize: 300}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	provider, err := NewRoute53Provider(ProviderConfig{
+		APIKey:    route53TestAccessKey,
+		APISecret: route53TestSecretKey,
+		Endpoint:  server.URL,
+	})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	return fake, provider
+}
+
+func (f *route53Fake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if message := verifyRoute53Signature(r, body); message != "" {
+		writeRoute53Error(w, http.StatusForbidden, "SignatureDoesNotMatch", message)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/2013-04-01/hostedzonesbyname":
+		var zones []route53HostedZone
+		if strings.EqualFold(r.URL.Query().Get("dnsname"), "example.com") {
+			zones = append(zones, route53HostedZone{ID: "/hostedzone/" + route53TestZoneID, Name: "example.com."})
+		}
+		writeRoute53XML(w, struct {
+			XMLName     xml.Name            `xml:"ListHostedZonesByNameResponse"`
+			HostedZones []route53HostedZone `xml:"HostedZones>HostedZone"`
+		}{HostedZones: zones})
+	case r.Method == "GET" && r.URL.Path == "/2013-04-01/hostedzone/"+route53TestZoneID+"/rrset":
+		f.listRRsets(w, r.URL.Query())
+	case r.Method == "POST" && r.URL.Path == "/2013-04-01/hostedzone/"+route53TestZoneID+"/rrset/":
+		var request struct {
+			Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+		}
+		if err := xml.Unmarshal(body, &request); err != nil {
+			writeRoute53Error(w, http.StatusBadRequest, "MalformedInput", err.Error())
+			return
+		}
+		if message := f.apply(request.Changes); message != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `<InvalidChangeBatch><Messages><Message>`+message+`</Message></Messages><RequestId>req-test</RequestId></InvalidChangeBatch>`)
+			return
+		}
+		f.changes = append(f.changes, request.Changes)
+		writeRoute53XML(w, struct {
+			XMLName xml.Name `xml:"ChangeResourceRecordSetsResponse"`
+			Status  string   `xml:"ChangeInfo>Status"`
+		}{Status: "PENDING"})
+	default:
+		writeRoute53Error(w, http.StatusNotFound, "NoSuchHostedZone", "No hosted zone found")
+	}
+}
+
+// apply 校验并原子执行变更批次，任一变更失败时不修改数据并返回错误信息
+func (f *route53Fake) apply(changes []route53Change) string {
+	next := make(map[string]route53RRset, len(f.rrsets))
+	for key, rrset := range f.rrsets {
+		next[key] = rrset
+	}
+
+	for _, change := range changes {
+		rrset := change.ResourceRecordSet
+		rrset.Name = strings.ToLower(route53UnescapeName(rrset.Name)) + "."
+		key := rrset.key()
+		existing, ok := next[key]
+		describe := "[name='" + rrset.Name + "', type='" + rrset.Type + "']"
+
+		switch change.Action {
+		case "CREATE":
+			if ok {
+				return "Tried to create resource record set " + describe + " but it already exists"
+			}
+			next[key] = rrset
+		case "UPSERT":
+			next[key] = rrset
+		case "DELETE":
+			if !ok || !route53SameRRset(existing, rrset) {
+				return "Tried to delete resource record set " + describe + " but it was not found"
+			}
+			delete(next, key)
+		default:
+			return "Invalid action " + change.Action
+		}
+	}
+
+	f.rrsets = next
+	return ""
+}
+
+// listRRsets 按名称、类型和路由标识排序后从指定位置分页返回记录集，名称中的"*"按Route 53的方式转义
+func (f *route53Fake) listRRsets(w http.ResponseWriter, query url.Values) {
+	keys := make([]string, 0, len(f.rrsets))
+	for key := range f.rrsets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	start := ""
+	if name := query.Get("name"); name != "" {
+		start = strings.ToLower(route53UnescapeName(name)) + "/" + query.Get("type") + "/" + query.Get("identifier")
+	}
+	limit, _ := strconv.Atoi(query.Get("maxitems"))
+	if limit <= 0 || limit > f.pageSize {
+		limit = f.pageSize
+	}
+
+	type response struct {
+		XMLName              xml.Name       `xml:"ListResourceRecordSetsResponse"`
+		ResourceRecordSets   []route53RRset `xml:"ResourceRecordSets>ResourceRecordSet"`
+		IsTruncated          bool           `xml:"IsTruncated"`
+		NextRecordName       string         `xml:"NextRecordName,omitempty"`
+		NextRecordType       string         `xml:"NextRecordType,omitempty"`
+		NextRecordIdentifier string         `xml:"NextRecordIdentifier,omitempty"`
+	}
+	var result response
+
+	for _, key := range keys {
+		if key < start {
+			continue
+		}
+		rrset := f.rrsets[key]
+		rrset.Name = strings.ReplaceAll(rrset.Name, "*", `\052`)
+		if len(result.ResourceRecordSets) == limit {
+			result.IsTruncated = true
+			result.NextRecordName = rrset.Name
+			result.NextRecordType = rrset.Type
+			result.NextRecordIdentifier = rrset.SetIdentifier
+			break
+		}
+		result.ResourceRecordSets = append(result.ResourceRecordSets, rrset)
+	}
+	writeRoute53XML(w, result)
+}
+
+// route53SameRRset 判断两个记录集的TTL、权重、别名和记录值是否完全一致
+func route53SameRRset(a, b route53RRset) bool {
+	sameInt := func(x, y *int64) bool {
+		return (x == nil && y == nil) || (x != nil && y != nil && *x == *y)
+	}
+	if !sameInt(a.TTL, b.TTL) || !sameInt(a.Weight, b.Weight) {
+		return false
+	}
+	if (a.AliasTarget == nil) != (b.AliasTarget == nil) || (a.AliasTarget != nil && *a.AliasTarget != *b.AliasTarget) {
+		return false
+	}
+	av, bv := append([]string(nil), a.values()...), append([]string(nil), b.values()...)
+	sort.Strings(av)
+	sort.Strings(bv)
+	return strings.Join(av, "\n") == strings.Join(bv, "\n")
+}
+
+// verifyRoute53Signature 按AWS Signature Version 4规则独立计算签名并与Authorization请求头比较，校验通过时返回空字符串
+func verifyRoute53Signature(r *http.Request, body []byte) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") {
+		return "missing authorization"
+	}
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ", ") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			fields[key] = value
+		}
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if len(amzDate) != len("20060102T150405Z") {
+		return "invalid X-Amz-Date"
+	}
+	scope := amzDate[:8] + "/us-east-1/route53/aws4_request"
+	if fields["Credential"] != route53TestAccessKey+"/"+scope {
+		return "invalid credential scope"
+	}
+
+	bodyHash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(bodyHash[:])
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return "payload hash mismatch"
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	for _, required := range []string{"host", "x-amz-content-sha256", "x-amz-date"} {
+		if !containsHeader(signedHeaders, required) {
+			return required + " must be signed"
+		}
+	}
+	var headers strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	// 查询参数按RFC 3986编码后按键和值排序
+	query := r.URL.Query()
+	var pairs []string
+	for key, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, route53TestEscape(key)+"="+route53TestEscape(value))
+		}
+	}
+	sort.Strings(pairs)
+
+	canonical := r.Method + "\n" + r.URL.EscapedPath() + "\n" + strings.Join(pairs, "&") + "\n" +
+		headers.String() + "\n" + fields["SignedHeaders"] + "\n" + payloadHash
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := []byte("AWS4" + route53TestSecretKey)
+	for _, part := range []string{amzDate[:8], "us-east-1", "route53", "aws4_request"} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	if hex.EncodeToString(mac.Sum(nil)) != fields["Signature"] {
+		return "signature mismatch"
+	}
+	return ""
+}
+
+// route53TestEscape 按RFC 3986编码，只保留非保留字符
+func route53TestEscape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func writeRoute53XML(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "text/xml")
+	io.WriteString(w, xml.Header)
+	xml.NewEncoder(w).Encode(value)
+}
+
+func writeRoute53Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, `<ErrorResponse><Error><Type>Sender</Type><Code>`+code+`</Code><Message>`+message+`</Message></Error><RequestId>req-test</RequestId></ErrorResponse>`)
+}
+
+func TestRoute53CanonicalQuery(t *testing.T) {
+	query := url.Values{
+		"type":     {"A"},
+		"name":     {"*.example.com. a~b"},
+		"maxitems": {"300"},
+		"b":        {"2", "1"},
+	}
+	want := "b=1&b=2&maxitems=300&name=%2A.example.com.%20a~b&type=A"
+	if got := route53CanonicalQuery(query); got != want {
+		t.Fatalf("规范化查询字符串不一致:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestRoute53UnescapeName(t *testing.T) {
+	tests := map[string]string{
+		"example.com.":           "example.com",
+		`\052.example.com.`:      "*.example.com",
+		`\052.\052.example.com.`: "*.*.example.com",
+		`a\100b.example.com.`:    "a@b.example.com",
+		`\052`:                   "*",
+		`short\05.example.com.`:  `short\05.example.com`,
+		`\999.example.com.`:      `\999.example.com`,
+		`end-with-backslash\`:    `end-with-backslash\`,
+	}
+	for input, want := range tests {
+		if got := route53UnescapeName(input); got != want {
+			t.Errorf("route53UnescapeName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestRoute53MergeAndRemove(t *testing.T) {
+	record := DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300}
+	target := route53NewRRset("example.com", record)
+
+	merged, err := route53Merge(route53RRset{Name: target.Name, Type: "A"}, target, record)
+	if err != nil {
+		t.Fatalf("合并记录值失败: %v", err)
+	}
+	second := DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300}
+	merged, err = route53Merge(merged, route53NewRRset("example.com", second), second)
+	if err != nil {
+		t.Fatalf("合并第二个记录值失败: %v", err)
+	}
+	if values := merged.values(); len(values) != 2 || values[0] != "192.0.2.1" || values[1] != "192.0.2.2" {
+		t.Fatalf("合并后的记录值不一致: %v", values)
+	}
+	if _, err := route53Merge(merged, target, record); !errors.Is(err, ErrRecordConflict) {
+		t.Fatalf("重复的记录值应返回ErrRecordConflict，实际为: %v", err)
+	}
+
+	alias := DNSRecord{Name: "www", Type: "A", Extra: map[string]string{"alias_target": "lb.example.net"}}
+	if _, err := route53Merge(merged, route53NewRRset("example.com", alias), alias); err == nil {
+		t.Fatalf("已有普通记录时不应设置为别名")
+	}
+
+	// 记录集有多个记录值时以UPSERT移除单个值，最后一个值以与现有内容完全一致的DELETE删除
+	change := route53Remove(merged, 0)
+	if change.Action != "UPSERT" || len(change.ResourceRecordSet.values()) != 1 || change.ResourceRecordSet.values()[0] != "192.0.2.2" {
+		t.Fatalf("移除记录值的变更不一致: %+v", change)
+	}
+	last := change.ResourceRecordSet
+	change = route53Remove(last, 0)
+	if change.Action != "DELETE" || !route53SameRRset(change.ResourceRecordSet, last) {
+		t.Fatalf("删除记录集的变更应与现有记录集一致: %+v", change)
+	}
+}
+
+func TestRoute53RecordRoundTrip(t *testing.T) {
+	fake, provider := newRoute53Fake(t)
+	ctx := context.Background()
+
+	created, err := provider.BatchAddRecords(ctx, "example.com", []DNSRecord{
+		{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300},
+		{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300},
+		{Name: "*", Type: "A", Value: "192.0.2.9", TTL: 60},
+		{Name: "txt", Type: "TXT", Value: "hello world", TTL: 300},
+	})
+	if err != nil {
+		t.Fatalf("批量添加记录失败: %v", err)
+	}
+	if len(fake.changes) != 1 || len(fake.changes[0]) != 3 {
+		t.Fatalf("批量添加应合并为一个包含3个记录集的变更批次: %+v", fake.changes)
+	}
+
+	// 通配符记录在列表中以"\052"转义返回，需要还原后才能匹配记录ID
+	wildcard, err := provider.GetRecord(ctx, "example.com", created[2].ID)
+	if err != nil {
+		t.Fatalf("获取通配符记录失败: %v", err)
+	}
+	if wildcard.Name != "*" || wildcard.Value != "192.0.2.9" || wildcard.TTL != 60 {
+		t.Fatalf("通配符记录不一致: %+v", wildcard)
+	}
+
+	txt, err := provider.GetRecord(ctx, "example.com", created[3].ID)
+	if err != nil {
+		t.Fatalf("获取TXT记录失败: %v", err)
+	}
+	if txt.Value != "hello world" || fake.rrsets["txt.example.com/TXT/"].values()[0] != `"hello world"` {
+		t.Fatalf("TXT记录值应加引号存储并在读取时还原: %+v", txt)
+	}
+
+	if _, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300}); !errors.Is(err, ErrRecordConflict) {
+		t.Fatalf("重复添加应返回ErrRecordConflict，实际为: %v", err)
+	}
+
+	// 子域名变化时在同一个批次中从原记录集移除并写入新记录集
+	replaced, err := provider.ReplaceRecord(ctx, "example.com", created[0].ID, DNSRecord{Name: "api", Type: "A", Value: "192.0.2.1", TTL: 300})
+	if err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	if replaced.Name != "api" {
+		t.Fatalf("更新后的记录不一致: %+v", replaced)
+	}
+	if values := fake.rrsets["www.example.com/A/"].values(); len(values) != 1 || values[0] != "192.0.2.2" {
+		t.Fatalf("原记录集应只保留未修改的记录值: %v", values)
+	}
+	if _, err := provider.GetRecord(ctx, "example.com", created[0].ID); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("原记录ID应返回ErrRecordNotFound，实际为: %v", err)
+	}
+
+	for _, id := range []string{created[1].ID, replaced.ID, created[2].ID, created[3].ID} {
+		if err := provider.DeleteRecord(ctx, "example.com", id); err != nil {
+			t.Fatalf("删除记录%s失败: %v", id, err)
+		}
+	}
+	if len(fake.rrsets) != 0 {
+		t.Fatalf("删除全部记录后不应保留记录集: %v", fake.rrsets)
+	}
+}
+
+func TestRoute53DeleteMustMatch(t *testing.T) {
+	fake, provider := newRoute53Fake(t)
+	ctx := context.Background()
+
+	created, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 300})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+
+	// 与现有记录集TTL不一致的DELETE被拒绝，并归类为记录不存在
+	stale := fake.rrsets["www.example.com/A/"]
+	ttl := int64(600)
+	stale.TTL = &ttl
+	err = provider.changeRRsets(ctx, route53TestZoneID, []route53Change{{Action: "DELETE", ResourceRecordSet: stale}})
+	if !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("不一致的DELETE应返回ErrRecordNotFound，实际为: %v", err)
+	}
+
+	// 记录集在外部被修改后，删除操作提交的是最新内容
+	current := fake.rrsets["www.example.com/A/"]
+	current.TTL = &ttl
+	fake.rrsets["www.example.com/A/"] = current
+	if err := provider.DeleteRecord(ctx, "example.com", created.ID); err != nil {
+		t.Fatalf("删除记录失败: %v", err)
+	}
+	if len(fake.rrsets) != 0 {
+		t.Fatalf("记录集应被删除: %v", fake.rrsets)
+	}
+}
+
+func TestRoute53Pagination(t *testing.T) {
+	fake, provider := newRoute53Fake(t)
+	ctx := context.Background()
+	fake.pageSize = 2
+
+	var records []DNSRecord
+	for i := 0; i < 7; i++ {
+		records = append(records, DNSRecord{Name: "host" + strconv.Itoa(i), Type: "A", Value: "192.0.2.1", TTL: 300})
+	}
+	weighted := DNSRecord{Name: "host3", Type: "A", Value: "192.0.2.3", TTL: 300,
+		Extra: map[string]string{"set_identifier": "blue", "weight": "10"}}
+	records = append(records, weighted)
+	created, err := provider.BatchAddRecords(ctx, "example.com", records)
+	if err != nil {
+		t.Fatalf("批量添加记录失败: %v", err)
+	}
+
+	listed, err := provider.ListRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("获取记录列表失败: %v", err)
+	}
+	if len(listed) != len(records) {
+		t.Fatalf("期望%d条记录，实际为%d条", len(records), len(listed))
+	}
+
+	// 带路由标识的记录集需要越过同名同类型的其他记录集查找
+	got, err := provider.GetRecord(ctx, "example.com", created[len(created)-1].ID)
+	if err != nil {
+		t.Fatalf("获取加权记录失败: %v", err)
+	}
+	if got.Extra["set_identifier"] != "blue" || got.Extra["weight"] != "10" {
+		t.Fatalf("加权记录不一致: %+v", got)
+	}
+}
+
+func TestRoute53SignatureRejected(t *testing.T) {
+	_, provider := newRoute53Fake(t)
+	provider.config.APISecret = "wrong-secret"
+
+	if err := provider.TestConnection(context.Background()); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("签名错误应返回ErrAuthFailed，实际为: %v", err)
+	}
+}
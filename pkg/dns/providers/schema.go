@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidConfig 凭据配置不符合服务商的配置项要求
+var ErrInvalidConfig = errors.New("服务商配置无效")
+
+// FieldError 配置项的校验错误
+type FieldError struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// ConfigError 凭据配置校验失败的配置项，按配置项在表单中的顺序排列
+type ConfigError struct {
+	Fields []FieldError `json:"fields"`
+}
+
+// Error 实现error接口
+func (e *ConfigError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Message)
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidConfig, strings.Join(messages, "; "))
+}
+
+// Unwrap 返回ErrInvalidConfig，使errors.Is可以匹配
+func (e *ConfigError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// ValidateProviderConfig 按服务商注册的配置项校验凭据配置，用于在测试连接前给出明确的错误
+// 未在配置项中声明的参数不做校验
+func ValidateProviderConfig(providerType string, config map[string]string) error {
+	definition, exists := Lookup(providerType)
+	if !exists {
+		return fmt.Errorf("不支持的DNS服务商: %s", providerType)
+	}
+
+	present := func(keys []string) bool {
+		for _, key := range keys {
+			if strings.TrimSpace(config[key]) != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	var fieldErrors []FieldError
+	for _, field := range definition.ConfigSchema {
+		value := strings.TrimSpace(config[field.Key])
+		if value == "" {
+			required := field.Required || present(field.RequiredWith) ||
+				(len(field.RequiredWith) == 0 && len(field.RequiredUnless) > 0)
+			if required && !present(field.RequiredUnless) {
+				fieldErrors = append(fieldErrors, FieldError{Key: field.Key, Message: requiredMessage(definition.ConfigSchema, field)})
+			}
+			continue
+		}
+
+		if message := validateFieldValue(field, value); message != "" {
+			fieldErrors = append(fieldErrors, FieldError{Key: field.Key, Message: message})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ConfigError{Fields: fieldErrors}
+	}
+	return nil
+}
+
+// requiredMessage 生成必填项缺失的提示，可以互相替代的配置项一并列出
+func requiredMessage(schema []ConfigField, field ConfigField) string {
+	if len(field.RequiredUnless) == 0 || len(field.RequiredWith) > 0 {
+		return fmt.Sprintf("%s不能为空", field.Label)
+	}
+
+	labels := []string{field.Label}
+	for _, key := range field.RequiredUnless {
+		labels = append(labels, fieldLabel(schema, key))
+	}
+	return fmt.Sprintf("%s需要填写其中一项", strings.Join(labels, "或"))
+}
+
+// fieldLabel 获取配置项的显示名称，未在配置项中声明时使用配置项名称
+func fieldLabel(schema []ConfigField, key string) string {
+	for _, field := range schema {
+		if field.Key == key {
+			return field.Label
+		}
+	}
+	return key
+}
+
+// validateFieldValue 校验配置项的取值，校验通过时返回空字符串
+func validateFieldValue(field ConfigField, value string) string {
+	switch field.Type {
+	case FieldNumber:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Sprintf("%s需要是整数", field.Label)
+		}
+		if field.Min != nil && number < *field.Min {
+			return fmt.Sprintf("%s不能小于%d", field.Label, *field.Min)
+		}
+		if field.Max != nil && number > *field.Max {
+			return fmt.Sprintf("%s不能大于%d", field.Label, *field.Max)
+		}
+	case FieldBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Sprintf("%s需要是true或false", field.Label)
+		}
+	case FieldSelect:
+		for _, option := range field.Options {
+			if option == value {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%s只支持%s", field.Label, strings.Join(field.Options, "、"))
+	}
+	return ""
+}
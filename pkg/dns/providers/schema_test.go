@@ -0,0 +1,118 @@
+package providers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateProviderConfig(t *testing.T) {
+	registerForTest(t, ProviderDefinition{
+		Type: "test-schema",
+		ConfigSchema: []ConfigField{
+			{Key: "api_key", Label: "API Key", Type: FieldText, Required: true},
+			{Key: "role_arn", Label: "角色ARN", Type: FieldText},
+			{Key: "session", Label: "会话名称", Type: FieldText, RequiredWith: []string{"role_arn"}},
+			{Key: "token", Label: "API Token", Type: FieldText, RequiredUnless: []string{"api_secret"}},
+			{Key: "api_secret", Label: "API Secret", Type: FieldText},
+			{Key: "port", Label: "端口", Type: FieldNumber, Default: "53", Min: intPtr(1), Max: intPtr(65535)},
+			{Key: "enabled", Label: "启用", Type: FieldBoolean, Default: "false"},
+			{Key: "algorithm", Label: "算法", Type: FieldSelect, Default: "sha256", Options: []string{"sha256", "sha512"}},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		config map[string]string
+		want   []FieldError // 为nil时期望校验通过
+	}{
+		{
+			name:   "只填写必填项，带默认值的配置项留空",
+			config: map[string]string{"api_key": "key", "token": "token"},
+		},
+		{
+			name:   "必填项只有空白字符",
+			config: map[string]string{"api_key": "  ", "token": "token"},
+			want:   []FieldError{{Key: "api_key", Message: "API Key不能为空"}},
+		},
+		{
+			name:   "填写了RequiredWith中的配置项",
+			config: map[string]string{"api_key": "key", "token": "token", "role_arn": "arn"},
+			want:   []FieldError{{Key: "session", Message: "会话名称不能为空"}},
+		},
+		{
+			name:   "RequiredUnless中的配置项可以替代",
+			config: map[string]string{"api_key": "key", "api_secret": "secret"},
+		},
+		{
+			name:   "RequiredUnless的配置项都未填写",
+			config: map[string]string{"api_key": "key"},
+			want:   []FieldError{{Key: "token", Message: "API Token或API Secret需要填写其中一项"}},
+		},
+		{
+			name: "取值校验",
+			config: map[string]string{
+				"api_key": "key", "token": "token",
+				"port": "0", "enabled": "yes", "algorithm": "md5",
+			},
+			want: []FieldError{
+				{Key: "port", Message: "端口不能小于1"},
+				{Key: "enabled", Message: "启用需要是true或false"},
+				{Key: "algorithm", Message: "算法只支持sha256、sha512"},
+			},
+		},
+		{
+			name:   "数字超出上限",
+			config: map[string]string{"api_key": "key", "token": "token", "port": "65536"},
+			want:   []FieldError{{Key: "port", Message: "端口不能大于65535"}},
+		},
+		{
+			name:   "数字不是整数",
+			config: map[string]string{"api_key": "key", "token": "token", "port": "5.3"},
+			want:   []FieldError{{Key: "port", Message: "端口需要是整数"}},
+		},
+		{
+			name:   "未声明的配置项不校验",
+			config: map[string]string{"api_key": "key", "token": "token", "unknown": "!"},
+		},
+	}
+	for _, tt := range tests {
+		err := ValidateProviderConfig("test-schema", tt.config)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%s: 应校验通过，实际为: %v", tt.name, err)
+			}
+			continue
+		}
+
+		var configErr *ConfigError
+		if !errors.As(err, &configErr) || !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%s: 应返回ConfigError，实际为: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(configErr.Fields, tt.want) {
+			t.Errorf("%s: 校验错误不符合预期: %+v", tt.name, configErr.Fields)
+		}
+	}
+
+	if err := ValidateProviderConfig("test-missing", nil); err == nil || errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("未注册的服务商应返回不支持的错误，实际为: %v", err)
+	}
+}
+
+func TestConfigSchemaDefaults(t *testing.T) {
+	// 已注册服务商的默认值本身需要通过校验，select的默认值需要是可选值之一
+	for _, definition := range Definitions() {
+		for _, field := range definition.ConfigSchema {
+			if field.Default == "" {
+				continue
+			}
+			if message := validateFieldValue(field, field.Default); message != "" {
+				t.Errorf("%s的配置项%s默认值无效: %s", definition.Type, field.Key, message)
+			}
+			if field.Required {
+				t.Errorf("%s的配置项%s有默认值，不应设为必填", definition.Type, field.Key)
+			}
+		}
+	}
+}
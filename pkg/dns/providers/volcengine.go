@@ -0,0 +1,586 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VolcengineProvider 火山引擎云解析DNS（TrafficRoute）服务商
+type VolcengineProvider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	endpoint   string
+	region     string
+}
+
+const (
+	// volcengineService 签名使用的服务名
+	volcengineService = "DNS"
+	// volcengineVersion API版本
+	volcengineVersion = "2018-08-01"
+	// volcengineDefaultLine 默认解析线路
+	volcengineDefaultLine = "default"
+)
+
+// init 注册火山引擎DNS服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "volcengine",
+		DisplayName: "火山引擎DNS",
+		Description: "火山引擎TrafficRoute DNS",
+		SortOrder:   7,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA"},
+			SupportsBatch:        false,
+			SupportsLineTypes:    true,
+			MaxRecordsPerDomain:  10000,
+			MinTTL:               1,
+			MaxTTL:               86400,
+			RateLimit:            10,
+			RateBurst:            10,
+		},
+		ConfigSchema: accessKeySchema("Access Key ID", "Secret Access Key",
+			ConfigField{Key: "region", Label: "区域", Type: FieldText, Default: "cn-north-1"},
+			ConfigField{Key: "token", Label: "STS Token", Type: FieldText, Secret: true, Help: "使用STS临时凭据时填写"},
+		),
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewVolcengineProvider(config)
+		},
+	})
+}
+
+// NewVolcengineProvider 创建火山引擎DNS服务商实例
+func NewVolcengineProvider(config ProviderConfig) (*VolcengineProvider, error) {
+	if config.APIKey == "" || config.APISecret == "" {
+		return nil, fmt.Errorf("火山引擎DNS需要Access Key ID和Secret Access Key")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://open.volcengineapi.com"
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "cn-north-1"
+	}
+
+	return &VolcengineProvider{
+		config:   config,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		region:   region,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetName 获取服务商名称
+func (p *VolcengineProvider) GetName() string {
+	return "volcengine"
+}
+
+// ValidateConfig 验证API配置
+func (p *VolcengineProvider) ValidateConfig() error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("火山引擎Access Key ID不能为空")
+	}
+	if p.config.APISecret == "" {
+		return fmt.Errorf("火山引擎Secret Access Key不能为空")
+	}
+	return nil
+}
+
+// TestConnection 测试连接
+func (p *VolcengineProvider) TestConnection(ctx context.Context) error {
+	_, err := p.makeRequest(ctx, "GET", "ListZones", url.Values{"PageNumber": {"1"}, "PageSize": {"1"}}, nil)
+	return err
+}
+
+// volcengineZone 火山引擎API返回的域名
+type volcengineZone struct {
+	ZID         int64  `json:"ZID"`
+	ZoneName    string `json:"ZoneName"`
+	RecordCount int    `json:"RecordCount"`
+	Status      int    `json:"Status"`
+}
+
+// ListZones 获取账号下托管的全部域名
+func (p *VolcengineProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	return p.listZones(ctx, "")
+}
+
+// VerifyZone 校验域名已托管在火山引擎账号下
+func (p *VolcengineProvider) VerifyZone(ctx context.Context, domain string) (string, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(zoneID, 10), nil
+}
+
+// volcengineLineInfo 火山引擎API返回的解析线路，FatherValue为上级线路代码
+type volcengineLineInfo struct {
+	Name        string `json:"Name"`
+	Value       string `json:"Value"`
+	FatherValue string `json:"FatherValue"`
+}
+
+// ListLines 获取可用的解析线路，以上级线路名称作为Group
+func (p *VolcengineProvider) ListLines(ctx context.Context, domain string) ([]Line, error) {
+	response, err := p.makeRequest(ctx, "GET", "ListLines", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Lines []volcengineLineInfo `json:"Lines"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	names := make(map[string]string, len(result.Lines))
+	for _, line := range result.Lines {
+		names[line.Value] = line.Name
+	}
+
+	lines := make([]Line, 0, len(result.Lines))
+	for _, line := range result.Lines {
+		lines = append(lines, Line{
+			ID:    line.Value,
+			Code:  line.Value,
+			Name:  line.Name,
+			Group: names[line.FatherValue],
+		})
+	}
+
+	return lines, nil
+}
+
+// volcengineRecord 火山引擎API返回的记录
+type volcengineRecord struct {
+	RecordID string `json:"RecordID"`
+	Host     string `json:"Host"`
+	Type     string `json:"Type"`
+	Value    string `json:"Value"`
+	TTL      int    `json:"TTL"`
+	Line     string `json:"Line"`
+	Weight   int    `json:"Weight"`
+	Enable   bool   `json:"Enable"`
+}
+
+// toDNSRecord 转换为通用DNS记录
+func (r volcengineRecord) toDNSRecord() DNSRecord {
+	status := "active"
+	if !r.Enable {
+		status = "disabled"
+	}
+
+	record := DNSRecord{
+		ID:     r.RecordID,
+		Name:   r.Host,
+		Type:   r.Type,
+		Value:  r.Value,
+		TTL:    r.TTL,
+		Line:   r.Line,
+		Status: status,
+	}
+
+	// MX和SRV记录的优先级等字段包含在记录值中
+	fields := strings.Fields(r.Value)
+	switch {
+	case r.Type == "MX" && len(fields) == 2:
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Value = fields[1]
+	case r.Type == "SRV" && len(fields) == 4:
+		record.Priority, _ = strconv.Atoi(fields[0])
+		record.Weight, _ = strconv.Atoi(fields[1])
+		record.Port, _ = strconv.Atoi(fields[2])
+		record.Value = fields[3]
+	}
+
+	return record
+}
+
+// ListRecords 获取域名记录列表
+func (p *VolcengineProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *VolcengineProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	const pageSize = 500
+	var records []DNSRecord
+
+	for page := 1; ; page++ {
+		query := url.Values{
+			"ZID":        {strconv.FormatInt(zoneID, 10)},
+			"PageNumber": {strconv.Itoa(page)},
+			"PageSize":   {strconv.Itoa(pageSize)},
+		}
+		if filter.Name != "" {
+			query.Set("Host", filter.Name)
+			query.Set("SearchMode", "exact")
+		}
+		if filter.Type != "" {
+			query.Set("Type", strings.ToUpper(filter.Type))
+		}
+
+		response, err := p.makeRequest(ctx, "GET", "ListRecords", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Records    []volcengineRecord `json:"Records"`
+			TotalCount int                `json:"TotalCount"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, record := range result.Records {
+			records = append(records, record.toDNSRecord())
+		}
+
+		if len(result.Records) < pageSize || len(records) >= result.TotalCount {
+			break
+		}
+	}
+
+	return filterRecords(records, filter), nil
+}
+
+// AddRecord 添加DNS记录
+func (p *VolcengineProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	return p.addRecord(ctx, zoneID, record)
+}
+
+// UpdateRecord 更新DNS记录
+func (p *VolcengineProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	data := volcengineRecordBody(record)
+	data["RecordID"] = recordID
+
+	_, err := p.makeRequest(ctx, "POST", "UpdateRecord", nil, data)
+	return err
+}
+
+// DeleteRecord 删除DNS记录
+func (p *VolcengineProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	_, err := p.makeRequest(ctx, "POST", "DeleteRecord", nil, map[string]interface{}{
+		"RecordID": recordID,
+	})
+	return err
+}
+
+// GetRecord 获取单个记录详情
+func (p *VolcengineProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	response, err := p.makeRequest(ctx, "GET", "QueryRecord", url.Values{"RecordID": {recordID}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result volcengineRecord
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	record := result.toDNSRecord()
+	return &record, nil
+}
+
+// BatchAddRecords 批量添加DNS记录
+func (p *VolcengineProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	zoneID, err := p.getZoneID(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("获取域名ID失败: %w", err)
+	}
+
+	var results []DNSRecord
+	var errors []error
+
+	for _, record := range records {
+		result, err := p.addRecord(ctx, zoneID, record)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	if len(errors) > 0 {
+		return results, fmt.Errorf("批量添加记录时发生错误: %v", errors)
+	}
+
+	return results, nil
+}
+
+// addRecord 在指定域名下添加记录
+func (p *VolcengineProvider) addRecord(ctx context.Context, zoneID int64, record DNSRecord) (*DNSRecord, error) {
+	data := volcengineRecordBody(record)
+	data["ZID"] = zoneID
+
+	response, err := p.makeRequest(ctx, "POST", "CreateRecord", nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		RecordID string `json:"RecordID"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	created := record
+	created.ID = result.RecordID
+	created.Line = volcengineLine(record.Line)
+	created.Status = "active"
+	return &created, nil
+}
+
+// listZones 分页获取域名列表，key不为空时按关键字搜索
+func (p *VolcengineProvider) listZones(ctx context.Context, key string) ([]Zone, error) {
+	const pageSize = 100
+	var zones []Zone
+
+	for page := 1; ; page++ {
+		query := url.Values{
+			"PageNumber": {strconv.Itoa(page)},
+			"PageSize":   {strconv.Itoa(pageSize)},
+		}
+		if key != "" {
+			query.Set("Key", key)
+		}
+
+		response, err := p.makeRequest(ctx, "GET", "ListZones", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Zones []volcengineZone `json:"Zones"`
+			Total int              `json:"Total"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, zone := range result.Zones {
+			zones = append(zones, Zone{
+				ID:          strconv.FormatInt(zone.ZID, 10),
+				Name:        zone.ZoneName,
+				Status:      "active",
+				RecordCount: zone.RecordCount,
+			})
+		}
+
+		if len(result.Zones) < pageSize || len(zones) >= result.Total {
+			break
+		}
+	}
+
+	return zones, nil
+}
+
+// getZoneID 获取域名的ZID，结果按凭据缓存
+func (p *VolcengineProvider) getZoneID(ctx context.Context, domain string) (int64, error) {
+	zoneID, err := cachedZoneID(ctx, "volcengine", p.config, domain, p.lookupZoneID)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(zoneID, 10, 64)
+}
+
+// lookupZoneID 查询域名的ZID
+func (p *VolcengineProvider) lookupZoneID(ctx context.Context, domain string) (string, error) {
+	zones, err := p.listZones(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+
+	for _, zone := range zones {
+		if zone.Name == domain {
+			return zone.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
+}
+
+// makeRequest 发起API请求，GET请求参数放在查询字符串中，POST请求参数以JSON格式放在请求体中
+func (p *VolcengineProvider) makeRequest(ctx context.Context, method, action string, query url.Values, data interface{}) ([]byte, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("Action", action)
+	query.Set("Version", volcengineVersion)
+
+	payload := []byte{}
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("序列化请求数据失败: %w", err)
+		}
+		payload = jsonData
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint+"/?"+query.Encode(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	p.sign(req, payload, time.Now().UTC())
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发起请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result struct {
+		ResponseMetadata struct {
+			RequestID string `json:"RequestId"`
+			Error     *struct {
+				Code    string `json:"Code"`
+				Message string `json:"Message"`
+			} `json:"Error"`
+		} `json:"ResponseMetadata"`
+		Result json.RawMessage `json:"Result"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, newStatusError("火山引擎DNS", resp, string(body))
+	}
+
+	if apiErr := result.ResponseMetadata.Error; apiErr != nil && apiErr.Code != "" {
+		return nil, newHTTPError("火山引擎DNS", resp, apiErr.Code, apiErr.Message, result.ResponseMetadata.RequestID)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newStatusError("火山引擎DNS", resp, string(body))
+	}
+
+	return result.Result, nil
+}
+
+// sign 按火山引擎HMAC-SHA256签名规则为请求添加签名相关请求头
+func (p *VolcengineProvider) sign(req *http.Request, payload []byte, now time.Time) {
+	xDate := now.Format("20060102T150405Z")
+	shortDate := xDate[:8]
+	payloadHash := sha256Hex(string(payload))
+
+	req.Header.Set("X-Date", xDate)
+	req.Header.Set("X-Content-Sha256", payloadHash)
+	if p.config.Token != "" {
+		req.Header.Set("X-Security-Token", p.config.Token)
+	}
+
+	// 参与签名的请求头
+	headers := map[string]string{
+		"host":             req.URL.Host,
+		"content-type":     req.Header.Get("Content-Type"),
+		"x-date":           xDate,
+		"x-content-sha256": payloadHash,
+	}
+	if p.config.Token != "" {
+		headers["x-security-token"] = p.config.Token
+	}
+
+	signedHeaders := make([]string, 0, len(headers))
+	for key := range headers {
+		signedHeaders = append(signedHeaders, key)
+	}
+	sort.Strings(signedHeaders)
+
+	var canonicalHeaders strings.Builder
+	for _, key := range signedHeaders {
+		canonicalHeaders.WriteString(key + ":" + strings.TrimSpace(headers[key]) + "\n")
+	}
+
+	canonicalRequest := req.Method + "\n" +
+		"/\n" +
+		volcengineCanonicalQuery(req.URL.Query()) + "\n" +
+		canonicalHeaders.String() + "\n" +
+		strings.Join(signedHeaders, ";") + "\n" +
+		payloadHash
+
+	credentialScope := shortDate + "/" + p.region + "/" + volcengineService + "/request"
+	stringToSign := "HMAC-SHA256\n" +
+		xDate + "\n" +
+		credentialScope + "\n" +
+		sha256Hex(canonicalRequest)
+
+	kDate := hmacSha256([]byte(p.config.APISecret), shortDate)
+	kRegion := hmacSha256(kDate, p.region)
+	kService := hmacSha256(kRegion, volcengineService)
+	kSigning := hmacSha256(kService, "request")
+	signature := hex.EncodeToString(hmacSha256(kSigning, stringToSign))
+
+	req.Header.Set("Authorization", "HMAC-SHA256 Credential="+p.config.APIKey+"/"+credentialScope+
+		", SignedHeaders="+strings.Join(signedHeaders, ";")+
+		", Signature="+signature)
+}
+
+// volcengineCanonicalQuery 规范化查询字符串，空格编码为%20
+func volcengineCanonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// volcengineRecordBody 构建创建和更新记录的请求参数
+func volcengineRecordBody(record DNSRecord) map[string]interface{} {
+	value := record.Value
+	switch strings.ToUpper(record.Type) {
+	case "MX":
+		value = fmt.Sprintf("%d %s", record.Priority, record.Value)
+	case "SRV":
+		value = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Value)
+	}
+
+	host := record.Name
+	if host == "" {
+		host = "@"
+	}
+
+	return map[string]interface{}{
+		"Host":  host,
+		"Type":  strings.ToUpper(record.Type),
+		"Value": value,
+		"TTL":   record.TTL,
+		"Line":  volcengineLine(record.Line),
+	}
+}
+
+// volcengineLine 规范化解析线路，未指定时使用默认线路
+func volcengineLine(line string) string {
+	if line == "" {
+		return volcengineDefaultLine
+	}
+	return line
+}
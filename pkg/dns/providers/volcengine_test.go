@@ -0,0 +1,363 @@
+package providers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	volcengineTestAccessKey = "AKLTtest"
+	volcengineTestSecretKey = "volc-test-secret"
+	volcengineTestRegion    = "cn-beijing"
+)
+
+// volcengineFake 火山引擎DNS接口的内存实现，校验每个请求的HMAC-SHA256签名
+type volcengineFake struct {
+	mu      sync.Mutex
+	zones   []volcengineZone
+	records map[string]volcengineRecord
+	nextID  int
+	actions map[string]int
+}
+
+func newVolcengineFake(t *testing.T) (*volcengineFake, *VolcengineProvider) {
+	t.Helper()
+	fake := &volcengineFake{
+		zones:   []volcengineZone{{ZID: 1001, ZoneName: "example.com"}},
+		records: make(map[string]volcengineRecord),
+		actions: make(map[string]int),
+	}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	provider, err := NewVolcengineProvider(ProviderConfig{
+		APIKey:    volcengineTestAccessKey,
+		APISecret: volcengineTestSecretKey,
+		Region:    volcengineTestRegion,
+		Endpoint:  server.URL,
+	})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	return fake, provider
+}
+
+func (f *volcengineFake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if message := verifyVolcengineSignature(r, body); message != "" {
+		writeVolcengineResult(w, http.StatusUnauthorized, "SignatureDoesNotMatch", message, nil)
+		return
+	}
+
+	query := r.URL.Query()
+	var params map[string]interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &params); err != nil {
+			writeVolcengineResult(w, http.StatusBadRequest, "InvalidParameter", "invalid body", nil)
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	action := query.Get("Action")
+	f.actions[action]++
+
+	switch action {
+	case "ListZones":
+		var zones []volcengineZone
+		for _, zone := range f.zones {
+			if key := query.Get("Key"); key == "" || strings.Contains(zone.ZoneName, key) {
+				zones = append(zones, zone)
+			}
+		}
+		page, total := volcenginePage(query.Get("PageNumber"), query.Get("PageSize"), len(zones))
+		writeVolcengineResult(w, http.StatusOK, "", "", map[string]interface{}{"Zones": zones[page[0]:page[1]], "Total": total})
+	case "ListLines":
+		writeVolcengineResult(w, http.StatusOK, "", "", map[string]interface{}{"Lines": []volcengineLineInfo{
+			{Name: "默认", Value: "default"},
+			{Name: "电信", Value: "telecom"},
+			{Name: "北京电信", Value: "telecom_beijing", FatherValue: "telecom"},
+		}})
+	case "ListRecords":
+		var records []volcengineRecord
+		for _, id := range f.sortedIDs() {
+			record := f.records[id]
+			if host := query.Get("Host"); host != "" && record.Host != host {
+				continue
+			}
+			if recordType := query.Get("Type"); recordType != "" && record.Type != recordType {
+				continue
+			}
+			records = append(records, record)
+		}
+		page, total := volcenginePage(query.Get("PageNumber"), query.Get("PageSize"), len(records))
+		writeVolcengineResult(w, http.StatusOK, "", "", map[string]interface{}{"Records": records[page[0]:page[1]], "TotalCount": total})
+	case "CreateRecord":
+		f.nextID++
+		record := volcengineRecordFromParams(params)
+		record.RecordID = strconv.Itoa(f.nextID)
+		record.Enable = true
+		f.records[record.RecordID] = record
+		writeVolcengineResult(w, http.StatusOK, "", "", map[string]string{"RecordID": record.RecordID})
+	case "QueryRecord", "UpdateRecord", "DeleteRecord":
+		id := query.Get("RecordID")
+		if id == "" {
+			id, _ = params["RecordID"].(string)
+		}
+		existing, ok := f.records[id]
+		if !ok {
+			writeVolcengineResult(w, http.StatusNotFound, "RecordNotFound", "record not found", nil)
+			return
+		}
+		switch action {
+		case "QueryRecord":
+			writeVolcengineResult(w, http.StatusOK, "", "", existing)
+		case "UpdateRecord":
+			record := volcengineRecordFromParams(params)
+			record.RecordID = id
+			record.Enable = existing.Enable
+			f.records[id] = record
+			writeVolcengineResult(w, http.StatusOK, "", "", record)
+		case "DeleteRecord":
+			delete(f.records, id)
+			writeVolcengineResult(w, http.StatusOK, "", "", nil)
+		}
+	default:
+		writeVolcengineResult(w, http.StatusBadRequest, "InvalidActionOrVersion", "unknown action", nil)
+	}
+}
+
+func (f *volcengineFake) sortedIDs() []string {
+	ids := make([]string, 0, len(f.records))
+	for id := range f.records {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+	return ids
+}
+
+// volcenginePage 根据页码和每页数量计算切片范围
+func volcenginePage(pageNumber, pageSize string, total int) ([2]int, int) {
+	page, _ := strconv.Atoi(pageNumber)
+	size, _ := strconv.Atoi(pageSize)
+	start := (page - 1) * size
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+	return [2]int{start, end}, total
+}
+
+func volcengineRecordFromParams(params map[string]interface{}) volcengineRecord {
+	record := volcengineRecord{}
+	record.Host, _ = params["Host"].(string)
+	record.Type, _ = params["Type"].(string)
+	record.Value, _ = params["Value"].(string)
+	record.Line, _ = params["Line"].(string)
+	if ttl, ok := params["TTL"].(float64); ok {
+		record.TTL = int(ttl)
+	}
+	return record
+}
+
+// verifyVolcengineSignature 按火山引擎签名规则独立计算签名并与Authorization请求头比较，校验通过时返回空字符串
+func verifyVolcengineSignature(r *http.Request, body []byte) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "HMAC-SHA256 ") {
+		return "missing authorization"
+	}
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(auth, "HMAC-SHA256 "), ", ") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			fields[key] = value
+		}
+	}
+
+	xDate := r.Header.Get("X-Date")
+	if len(xDate) != len("20060102T150405Z") {
+		return "invalid X-Date"
+	}
+	scope := xDate[:8] + "/" + volcengineTestRegion + "/DNS/request"
+	if fields["Credential"] != volcengineTestAccessKey+"/"+scope {
+		return "invalid credential scope"
+	}
+
+	bodyHash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(bodyHash[:])
+	if r.Header.Get("X-Content-Sha256") != payloadHash {
+		return "payload hash mismatch"
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	if strings.Join(signedHeaders, ";") != "content-type;host;x-content-sha256;x-date" {
+		return "unexpected signed headers"
+	}
+	var headers strings.Builder
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonical := r.Method + "\n/\n" + strings.ReplaceAll(r.URL.Query().Encode(), "+", "%20") + "\n" +
+		headers.String() + "\n" + fields["SignedHeaders"] + "\n" + payloadHash
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	stringToSign := "HMAC-SHA256\n" + xDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := []byte(volcengineTestSecretKey)
+	for _, part := range []string{xDate[:8], volcengineTestRegion, "DNS", "request"} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	if hex.EncodeToString(mac.Sum(nil)) != fields["Signature"] {
+		return "signature mismatch"
+	}
+	return ""
+}
+
+func writeVolcengineResult(w http.ResponseWriter, status int, code, message string, result interface{}) {
+	metadata := map[string]interface{}{"RequestId": "req-test"}
+	if code != "" {
+		metadata["Error"] = map[string]string{"Code": code, "Message": message}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"ResponseMetadata": metadata, "Result": result})
+}
+
+func TestVolcengineRecordRoundTrip(t *testing.T) {
+	fake, provider := newVolcengineFake(t)
+	ctx := context.Background()
+
+	created, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "www", Type: "A", Value: "192.0.2.1", TTL: 600})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+	if created.Line != "default" || fake.records[created.ID].Line != "default" {
+		t.Fatalf("未指定线路时应使用默认线路，实际为: %q", fake.records[created.ID].Line)
+	}
+
+	mx, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "@", Type: "MX", Value: "mail.example.com", TTL: 600, Priority: 10, Line: "telecom"})
+	if err != nil {
+		t.Fatalf("添加MX记录失败: %v", err)
+	}
+	if value := fake.records[mx.ID].Value; value != "10 mail.example.com" {
+		t.Fatalf("MX记录值应包含优先级，实际为: %q", value)
+	}
+
+	got, err := provider.GetRecord(ctx, "example.com", mx.ID)
+	if err != nil {
+		t.Fatalf("获取记录失败: %v", err)
+	}
+	if got.Name != "@" || got.Value != "mail.example.com" || got.Priority != 10 || got.Line != "telecom" || got.Status != "active" {
+		t.Fatalf("获取的记录不一致: %+v", got)
+	}
+
+	if err := provider.UpdateRecord(ctx, "example.com", created.ID, DNSRecord{Name: "www", Type: "A", Value: "192.0.2.2", TTL: 300}); err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	records, err := provider.ListRecordsFiltered(ctx, "example.com", RecordFilter{Name: "www", Type: "a"})
+	if err != nil {
+		t.Fatalf("获取记录列表失败: %v", err)
+	}
+	if len(records) != 1 || records[0].Value != "192.0.2.2" || records[0].TTL != 300 {
+		t.Fatalf("更新后的记录不一致: %+v", records)
+	}
+
+	if err := provider.DeleteRecord(ctx, "example.com", created.ID); err != nil {
+		t.Fatalf("删除记录失败: %v", err)
+	}
+	if _, err := provider.GetRecord(ctx, "example.com", created.ID); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("删除后获取记录应返回ErrRecordNotFound，实际为: %v", err)
+	}
+}
+
+func TestVolcenginePagination(t *testing.T) {
+	fake, provider := newVolcengineFake(t)
+	ctx := context.Background()
+
+	for i := 0; i < 150; i++ {
+		fake.zones = append(fake.zones, volcengineZone{ZID: int64(2000 + i), ZoneName: "zone" + strconv.Itoa(i) + ".net"})
+	}
+	zones, err := provider.ListZones(ctx)
+	if err != nil {
+		t.Fatalf("获取域名列表失败: %v", err)
+	}
+	if len(zones) != 151 {
+		t.Fatalf("期望151个域名，实际为%d个", len(zones))
+	}
+
+	for i := 0; i < 1050; i++ {
+		id := strconv.Itoa(i + 1)
+		fake.records[id] = volcengineRecord{RecordID: id, Host: "h" + id, Type: "A", Value: "192.0.2.1", TTL: 600, Line: "default", Enable: true}
+	}
+	fake.nextID = 1050
+
+	fake.actions["ListRecords"] = 0
+	records, err := provider.ListRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("获取记录列表失败: %v", err)
+	}
+	if len(records) != 1050 {
+		t.Fatalf("期望1050条记录，实际为%d条", len(records))
+	}
+	if pages := fake.actions["ListRecords"]; pages != 3 {
+		t.Fatalf("期望分3页获取记录，实际请求%d次", pages)
+	}
+}
+
+func TestVolcengineListLines(t *testing.T) {
+	_, provider := newVolcengineFake(t)
+
+	lines, err := provider.ListLines(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("获取解析线路失败: %v", err)
+	}
+	want := []Line{
+		{ID: "default", Code: "default", Name: "默认"},
+		{ID: "telecom", Code: "telecom", Name: "电信"},
+		{ID: "telecom_beijing", Code: "telecom_beijing", Name: "北京电信", Group: "电信"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("解析线路数量不一致: %+v", lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("解析线路不一致: %+v", lines[i])
+		}
+	}
+}
+
+func TestVolcengineSignatureRejected(t *testing.T) {
+	_, provider := newVolcengineFake(t)
+	provider.config.APISecret = "wrong-secret"
+
+	if err := provider.TestConnection(context.Background()); !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("签名错误应返回ErrAuthFailed，实际为: %v", err)
+	}
+}
@@ -0,0 +1,512 @@
+package providers
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+// WestProvider 西部数码域名解析服务商
+// 西部数码修改记录后记录ID会发生变化，适配器不使用其原生ID，
+// 而是以"主机记录/记录类型/线路和记录值摘要"作为持久的记录ID，操作时按该ID查找当前的原生记录
+type WestProvider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	endpoint   string
+}
+
+// westLines 通用线路名称与西部数码线路代码的对应关系
+var westLines = map[string]string{
+	"default": "",
+	"telecom": "LTEL",
+	"unicom":  "LCNC",
+	"mobile":  "LMOB",
+	"edu":     "LEDU",
+	"search":  "LSEO",
+	"默认":      "",
+	"电信":      "LTEL",
+	"联通":      "LCNC",
+	"移动":      "LMOB",
+	"教育网":     "LEDU",
+	"搜索引擎":    "LSEO",
+}
+
+// init 注册西部数码DNS服务商
+func init() {
+	Register(ProviderDefinition{
+		Type:        "west",
+		DisplayName: "西部数码DNS",
+		Description: "西部数码域名解析服务",
+		SortOrder:   6,
+		Features: ProviderFeatures{
+			SupportedRecordTypes: []string{"A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV"},
+			SupportsBatch:        false,
+			SupportsLineTypes:    true,
+			MaxRecordsPerDomain:  1000,
+			MinTTL:               60,
+			MaxTTL:               86400,
+			RateLimit:            5,
+			RateBurst:            5,
+		},
+		ConfigSchema: accessKeySchema("用户名", "API密码"),
+		Constructor: func(config ProviderConfig) (DNSProvider, error) {
+			return NewWestProvider(config)
+		},
+	})
+}
+
+// NewWestProvider 创建西部数码DNS服务商实例，APIKey为用户名，APISecret为API密码
+func NewWestProvider(config ProviderConfig) (*WestProvider, error) {
+	if config.APIKey == "" || config.APISecret == "" {
+		return nil, fmt.Errorf("西部数码DNS需要用户名和API密码")
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://api.west.cn/api/v2/domain/"
+	}
+
+	return &WestProvider{
+		config:   config,
+		endpoint: endpoint,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetName 获取服务商名称
+func (p *WestProvider) GetName() string {
+	return "west"
+}
+
+// ValidateConfig 验证API配置
+func (p *WestProvider) ValidateConfig() error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("西部数码用户名不能为空")
+	}
+	if p.config.APISecret == "" {
+		return fmt.Errorf("西部数码API密码不能为空")
+	}
+	return nil
+}
+
+// TestConnection 测试连接
+func (p *WestProvider) TestConnection(ctx context.Context) error {
+	_, err := p.makeRequest(ctx, "getdomains", url.Values{"limit": {"1"}, "pageno": {"1"}})
+	return err
+}
+
+// ListZones 获取账号下的全部域名
+func (p *WestProvider) ListZones(ctx context.Context) ([]Zone, error) {
+	const pageSize = 100
+	var zones []Zone
+
+	for page := 1; ; page++ {
+		response, err := p.makeRequest(ctx, "getdomains", url.Values{
+			"limit":  {strconv.Itoa(pageSize)},
+			"pageno": {strconv.Itoa(page)},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Total int `json:"total"`
+			Items []struct {
+				Domain string `json:"domain"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		for _, item := range result.Items {
+			zones = append(zones, Zone{
+				ID:     item.Domain,
+				Name:   item.Domain,
+				Status: "active",
+			})
+		}
+
+		if len(result.Items) < pageSize || len(zones) >= result.Total {
+			break
+		}
+	}
+
+	return zones, nil
+}
+
+// westRecord 西部数码API返回的解析记录
+type westRecord struct {
+	ID    int64  `json:"id"`
+	Item  string `json:"item"`
+	Value string `json:"value"`
+	Type  string `json:"type"`
+	Level int    `json:"level"`
+	TTL   int    `json:"ttl"`
+	Line  string `json:"line"`
+	Pause int    `json:"pause"`
+}
+
+// toDNSRecord 转换为通用DNS记录，记录ID使用持久ID
+func (r westRecord) toDNSRecord() DNSRecord {
+	status := "active"
+	if r.Pause != 0 {
+		status = "disabled"
+	}
+
+	return DNSRecord{
+		ID:       westRecordID(r.Item, r.Type, r.Line, r.Value),
+		Name:     r.Item,
+		Type:     r.Type,
+		Value:    r.Value,
+		TTL:      r.TTL,
+		Priority: r.Level,
+		Line:     westLineName(r.Line),
+		Status:   status,
+	}
+}
+
+// ListRecords 获取域名记录列表
+func (p *WestProvider) ListRecords(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return p.ListRecordsFiltered(ctx, domain, RecordFilter{})
+}
+
+// ListRecordsFiltered 按子域名或记录类型获取域名记录列表
+func (p *WestProvider) ListRecordsFiltered(ctx context.Context, domain string, filter RecordFilter) ([]DNSRecord, error) {
+	items, err := p.listRecords(ctx, domain, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]DNSRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, item.toDNSRecord())
+	}
+	return filterRecords(records, filter), nil
+}
+
+// AddRecord 添加DNS记录
+func (p *WestProvider) AddRecord(ctx context.Context, domain string, record DNSRecord) (*DNSRecord, error) {
+	params := westRecordParams(domain, record)
+	params.Set("host", westHost(record.Name))
+	params.Set("type", strings.ToUpper(record.Type))
+
+	if _, err := p.makeRequest(ctx, "adddnsrecord", params); err != nil {
+		return nil, err
+	}
+
+	return westCreatedRecord(record), nil
+}
+
+// UpdateRecord 更新DNS记录
+func (p *WestProvider) UpdateRecord(ctx context.Context, domain string, recordID string, record DNSRecord) error {
+	_, err := p.ReplaceRecord(ctx, domain, recordID, record)
+	return err
+}
+
+// ReplaceRecord 更新DNS记录并返回新的记录，持久ID随主机记录、类型、线路和记录值变化
+func (p *WestProvider) ReplaceRecord(ctx context.Context, domain string, recordID string, record DNSRecord) (*DNSRecord, error) {
+	existing, err := p.findRecord(ctx, domain, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 西部数码不支持修改主机记录和类型，只能先添加新记录再删除原记录
+	if !strings.EqualFold(existing.Item, westHost(record.Name)) || !strings.EqualFold(existing.Type, record.Type) {
+		created, err := p.AddRecord(ctx, domain, record)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.deleteNative(ctx, domain, existing.ID); err != nil {
+			return nil, err
+		}
+		return created, nil
+	}
+
+	params := westRecordParams(domain, record)
+	params.Set("id", strconv.FormatInt(existing.ID, 10))
+	if _, err := p.makeRequest(ctx, "moddnsrecord", params); err != nil {
+		return nil, err
+	}
+
+	return westCreatedRecord(record), nil
+}
+
+// DeleteRecord 删除DNS记录
+func (p *WestProvider) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	existing, err := p.findRecord(ctx, domain, recordID)
+	if err != nil {
+		return err
+	}
+
+	return p.deleteNative(ctx, domain, existing.ID)
+}
+
+// GetRecord 获取单个记录详情
+func (p *WestProvider) GetRecord(ctx context.Context, domain string, recordID string) (*DNSRecord, error) {
+	existing, err := p.findRecord(ctx, domain, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	record := existing.toDNSRecord()
+	return &record, nil
+}
+
+// BatchAddRecords 批量添加DNS记录
+func (p *WestProvider) BatchAddRecords(ctx context.Context, domain string, records []DNSRecord) ([]DNSRecord, error) {
+	var results []DNSRecord
+	var errors []error
+
+	for _, record := range records {
+		result, err := p.AddRecord(ctx, domain, record)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	if len(errors) > 0 {
+		return results, fmt.Errorf("批量添加记录时发生错误: %v", errors)
+	}
+
+	return results, nil
+}
+
+// findRecord 根据持久ID查找当前的原生记录
+func (p *WestProvider) findRecord(ctx context.Context, domain, recordID string) (*westRecord, error) {
+	parts := strings.SplitN(recordID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("无效的记录ID: %s", recordID)
+	}
+
+	items, err := p.listRecords(ctx, domain, RecordFilter{Name: parts[0], Type: parts[1]})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		if westRecordID(items[i].Item, items[i].Type, items[i].Line, items[i].Value) == recordID {
+			return &items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrRecordNotFound, recordID)
+}
+
+// listRecords 分页获取原生解析记录
+func (p *WestProvider) listRecords(ctx context.Context, domain string, filter RecordFilter) ([]westRecord, error) {
+	const pageSize = 500
+	var records []westRecord
+
+	for page := 1; ; page++ {
+		params := url.Values{
+			"domain": {domain},
+			"limit":  {strconv.Itoa(pageSize)},
+			"pageno": {strconv.Itoa(page)},
+		}
+		if filter.Name != "" {
+			params.Set("host", westHost(filter.Name))
+		}
+		if filter.Type != "" {
+			params.Set("type", strings.ToUpper(filter.Type))
+		}
+
+		response, err := p.makeRequest(ctx, "getdnsrecord", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Total int          `json:"total"`
+			Items []westRecord `json:"items"`
+		}
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+
+		records = append(records, result.Items...)
+
+		if len(result.Items) < pageSize || len(records) >= result.Total {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// deleteNative 按原生记录ID删除记录
+func (p *WestProvider) deleteNative(ctx context.Context, domain string, id int64) error {
+	_, err := p.makeRequest(ctx, "deldnsrecord", url.Values{
+		"domain": {domain},
+		"id":     {strconv.FormatInt(id, 10)},
+	})
+	return err
+}
+
+// makeRequest 发起API请求，请求参数和响应内容均为GBK编码
+func (p *WestProvider) makeRequest(ctx context.Context, action string, params url.Values) ([]byte, error) {
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	sum := md5.Sum([]byte(p.config.APIKey + p.config.APISecret + timestamp))
+
+	form := url.Values{}
+	for key, values := range params {
+		for _, value := range values {
+			encoded, err := simplifiedchinese.GBK.NewEncoder().String(value)
+			if err != nil {
+				return nil, fmt.Errorf("参数%s无法转换为GBK编码: %v", key, err)
+			}
+			form.Add(key, encoded)
+		}
+	}
+	form.Set("username", p.config.APIKey)
+	form.Set("time", timestamp)
+	form.Set("token", hex.EncodeToString(sum[:]))
+
+	requestURL := p.endpoint + "?act=" + url.QueryEscape(action)
+	req, err := http.NewRequestWithContext(ctx, "POST", requestURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=gbk")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("发起请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	body, err = westDecodeBody(resp.Header.Get("Content-Type"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newStatusError("西部数码", resp, string(body))
+	}
+
+	var result struct {
+		Result   int             `json:"result"`
+		ClientID string          `json:"clientid"`
+		Msg      string          `json:"msg"`
+		ErrCode  int             `json:"errcode"`
+		Data     json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	if result.Result != 200 {
+		return nil, newHTTPError("西部数码", resp, strconv.Itoa(result.ErrCode), result.Msg, result.ClientID)
+	}
+
+	if len(result.Data) == 0 {
+		return []byte("{}"), nil
+	}
+	return result.Data, nil
+}
+
+// westDecodeBody 将GBK编码的响应转换为UTF-8，响应已是UTF-8时原样返回
+func westDecodeBody(contentType string, body []byte) ([]byte, error) {
+	charset := ""
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		charset = strings.ToLower(params["charset"])
+	}
+
+	if charset == "utf-8" || (charset == "" && utf8.Valid(body)) {
+		return body, nil
+	}
+
+	decoded, err := simplifiedchinese.GBK.NewDecoder().Bytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("转换GBK响应失败: %w", err)
+	}
+	return decoded, nil
+}
+
+// westRecordParams 构建添加和修改记录的公共参数
+func westRecordParams(domain string, record DNSRecord) url.Values {
+	params := url.Values{
+		"domain": {domain},
+		"value":  {record.Value},
+		"ttl":    {strconv.Itoa(record.TTL)},
+		"line":   {westLine(record.Line)},
+	}
+	if strings.ToUpper(record.Type) == "MX" {
+		params.Set("level", strconv.Itoa(record.Priority))
+	}
+	return params
+}
+
+// westCreatedRecord 构建写入成功后的记录，记录ID使用持久ID
+func westCreatedRecord(record DNSRecord) *DNSRecord {
+	line := westLine(record.Line)
+	created := record
+	created.Name = westHost(record.Name)
+	created.Type = strings.ToUpper(record.Type)
+	created.ID = westRecordID(created.Name, created.Type, line, record.Value)
+	created.Line = westLineName(line)
+	created.Status = "active"
+	return &created
+}
+
+// westRecordID 生成持久记录ID，格式为"主机记录/记录类型/线路和记录值摘要"
+func westRecordID(host, recordType, line, value string) string {
+	return strings.ToLower(host) + "/" + strings.ToUpper(recordType) + "/" + valueDigest(line+"|"+value)
+}
+
+// westHost 将子域名转换为西部数码的主机记录，根域名使用"@"
+func westHost(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+// westLine 将线路名称转换为西部数码线路代码，未识别的线路原样传递
+func westLine(line string) string {
+	if code, ok := westLines[strings.ToLower(line)]; ok {
+		return code
+	}
+	return line
+}
+
+// westLineName 将西部数码线路代码转换为通用线路名称
+func westLineName(code string) string {
+	switch strings.ToUpper(code) {
+	case "":
+		return "default"
+	case "LTEL":
+		return "telecom"
+	case "LCNC":
+		return "unicom"
+	case "LMOB":
+		return "mobile"
+	case "LEDU":
+		return "edu"
+	case "LSEO":
+		return "search"
+	default:
+		return code
+	}
+}
@@ -0,0 +1,228 @@
+package providers
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+const (
+	westTestUsername = "westuser"
+	westTestPassword = "west-api-password"
+)
+
+// westFake 西部数码解析接口的内存实现，请求参数和响应均为GBK编码，修改记录后原生记录ID会变化
+type westFake struct {
+	t        *testing.T
+	mu       sync.Mutex
+	records  []westRecord
+	nextID   int64
+	charset  string // 响应Content-Type中的字符集，为空时不声明字符集
+	lastHost string // 最近一次添加记录的主机记录（已从GBK解码）
+}
+
+func newWestFake(t *testing.T) (*westFake, *WestProvider) {
+	t.Helper()
+	fake := &westFake{t: t, nextID: 100, charset: "gbk"}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	provider, err := NewWestProvider(ProviderConfig{APIKey: westTestUsername, APISecret: westTestPassword, Endpoint: server.URL + "/api/v2/domain/"})
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	return fake, provider
+}
+
+func (f *westFake) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r.ParseForm()
+	form := make(map[string]string)
+	for key, values := range r.PostForm {
+		value, err := simplifiedchinese.GBK.NewDecoder().String(values[0])
+		if err != nil {
+			f.t.Errorf("参数%s不是GBK编码: %v", key, err)
+		}
+		form[key] = value
+	}
+
+	sum := md5.Sum([]byte(form["username"] + westTestPassword + form["time"]))
+	if form["username"] != westTestUsername || form["token"] != hex.EncodeToString(sum[:]) {
+		f.reply(w, map[string]interface{}{"result": 500, "clientid": "cid-auth", "errcode": 10001, "msg": "身份验证失败"})
+		return
+	}
+
+	switch r.URL.Query().Get("act") {
+	case "getdnsrecord":
+		var items []westRecord
+		for _, record := range f.records {
+			if host := form["host"]; host != "" && record.Item != host {
+				continue
+			}
+			if recordType := form["type"]; recordType != "" && record.Type != recordType {
+				continue
+			}
+			items = append(items, record)
+		}
+		f.reply(w, map[string]interface{}{"result": 200, "data": map[string]interface{}{"total": len(items), "items": items}})
+	case "adddnsrecord":
+		f.nextID++
+		ttl, _ := strconv.Atoi(form["ttl"])
+		level, _ := strconv.Atoi(form["level"])
+		f.records = append(f.records, westRecord{ID: f.nextID, Item: form["host"], Type: form["type"], Value: form["value"], TTL: ttl, Level: level, Line: form["line"]})
+		f.lastHost = form["host"]
+		f.reply(w, map[string]interface{}{"result": 200, "data": map[string]int64{"id": f.nextID}})
+	case "moddnsrecord":
+		for i := range f.records {
+			if strconv.FormatInt(f.records[i].ID, 10) == form["id"] {
+				// 西部数码修改记录后分配新的记录ID
+				f.nextID++
+				f.records[i].ID = f.nextID
+				f.records[i].Value = form["value"]
+				f.records[i].TTL, _ = strconv.Atoi(form["ttl"])
+				f.reply(w, map[string]interface{}{"result": 200})
+				return
+			}
+		}
+		f.reply(w, map[string]interface{}{"result": 500, "errcode": 20004, "msg": "解析记录不存在"})
+	case "deldnsrecord":
+		for i := range f.records {
+			if strconv.FormatInt(f.records[i].ID, 10) == form["id"] {
+				f.records = append(f.records[:i], f.records[i+1:]...)
+				f.reply(w, map[string]interface{}{"result": 200})
+				return
+			}
+		}
+		f.reply(w, map[string]interface{}{"result": 500, "errcode": 20004, "msg": "解析记录不存在"})
+	default:
+		f.reply(w, map[string]interface{}{"result": 500, "errcode": 10000, "msg": "未知的操作"})
+	}
+}
+
+// reply 以GBK编码返回JSON响应
+func (f *westFake) reply(w http.ResponseWriter, value interface{}) {
+	body, _ := json.Marshal(value)
+	encoded, err := simplifiedchinese.GBK.NewEncoder().Bytes(body)
+	if err != nil {
+		f.t.Fatalf("响应无法转换为GBK编码: %v", err)
+	}
+	contentType := "application/json"
+	if f.charset != "" {
+		contentType += "; charset=" + f.charset
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(encoded)
+}
+
+func TestWestDecodeBody(t *testing.T) {
+	gbk, _ := simplifiedchinese.GBK.NewEncoder().String(`{"msg":"解析记录不存在"}`)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"声明GBK", "application/json; charset=GBK", gbk},
+		{"未声明字符集的GBK", "application/json", gbk},
+		{"声明UTF-8", "application/json; charset=utf-8", `{"msg":"解析记录不存在"}`},
+		{"未声明字符集的UTF-8", "", `{"msg":"解析记录不存在"}`},
+	}
+	for _, tt := range tests {
+		decoded, err := westDecodeBody(tt.contentType, []byte(tt.body))
+		if err != nil || string(decoded) != `{"msg":"解析记录不存在"}` {
+			t.Errorf("%s: 解码结果不一致: %s, %v", tt.name, decoded, err)
+		}
+	}
+}
+
+func TestWestDurableRecordID(t *testing.T) {
+	fake, provider := newWestFake(t)
+	ctx := context.Background()
+
+	created, err := provider.AddRecord(ctx, "example.com", DNSRecord{Name: "中文", Type: "TXT", Value: "你好", TTL: 600, Line: "telecom"})
+	if err != nil {
+		t.Fatalf("添加记录失败: %v", err)
+	}
+	if fake.lastHost != "中文" {
+		t.Fatalf("请求参数应以GBK编码传递，服务器解码为%q", fake.lastHost)
+	}
+	if created.ID != westRecordID("中文", "TXT", "LTEL", "你好") {
+		t.Fatalf("记录ID应为持久ID，实际为%s", created.ID)
+	}
+
+	got, err := provider.GetRecord(ctx, "example.com", created.ID)
+	if err != nil || got.Value != "你好" || got.Line != "telecom" {
+		t.Fatalf("GBK响应解码后的记录不一致: %+v, %v", got, err)
+	}
+
+	// 只修改TTL时原生ID变化，持久ID不变
+	nativeID := fake.records[0].ID
+	updated, err := provider.ReplaceRecord(ctx, "example.com", created.ID, DNSRecord{Name: "中文", Type: "TXT", Value: "你好", TTL: 1200, Line: "telecom"})
+	if err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	if fake.records[0].ID == nativeID || updated.ID != created.ID {
+		t.Fatalf("原生ID应变化而持久ID不变: 原生%d->%d，持久%s->%s", nativeID, fake.records[0].ID, created.ID, updated.ID)
+	}
+	if got, err := provider.GetRecord(ctx, "example.com", created.ID); err != nil || got.TTL != 1200 {
+		t.Fatalf("按持久ID获取修改后的记录失败: %+v, %v", got, err)
+	}
+
+	// 修改记录值后持久ID随之变化，原ID不再有效
+	changed, err := provider.ReplaceRecord(ctx, "example.com", created.ID, DNSRecord{Name: "中文", Type: "TXT", Value: "再见", TTL: 1200, Line: "telecom"})
+	if err != nil {
+		t.Fatalf("更新记录失败: %v", err)
+	}
+	if changed.ID == created.ID {
+		t.Fatalf("记录值变化后持久ID应变化")
+	}
+	if _, err := provider.GetRecord(ctx, "example.com", created.ID); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("原持久ID应返回ErrRecordNotFound，实际为: %v", err)
+	}
+
+	// 主机记录变化时添加新记录并删除原记录
+	moved, err := provider.ReplaceRecord(ctx, "example.com", changed.ID, DNSRecord{Name: "www", Type: "TXT", Value: "再见", TTL: 1200})
+	if err != nil {
+		t.Fatalf("修改主机记录失败: %v", err)
+	}
+	if len(fake.records) != 1 || fake.records[0].Item != "www" || moved.Line != "default" {
+		t.Fatalf("修改主机记录后的记录不一致: %+v, %+v", fake.records, moved)
+	}
+
+	if err := provider.DeleteRecord(ctx, "example.com", moved.ID); err != nil {
+		t.Fatalf("删除记录失败: %v", err)
+	}
+	if len(fake.records) != 0 {
+		t.Fatalf("记录应已删除: %+v", fake.records)
+	}
+}
+
+func TestWestErrors(t *testing.T) {
+	fake, provider := newWestFake(t)
+	ctx := context.Background()
+
+	// 未声明字符集的GBK错误信息也能正确解码
+	fake.charset = ""
+	provider.config.APISecret = "wrong-password"
+	err := provider.TestConnection(ctx)
+	var providerErr *ProviderError
+	if !errors.As(err, &providerErr) || providerErr.Message != "身份验证失败" || providerErr.Code != "10001" || providerErr.RequestID != "cid-auth" {
+		t.Fatalf("错误信息应从GBK解码并保留错误码和请求ID，实际为: %v", err)
+	}
+
+	if _, err := provider.GetRecord(ctx, "example.com", "invalid"); err == nil || !strings.Contains(err.Error(), "无效的记录ID") {
+		t.Fatalf("无效的持久ID应返回错误，实际为: %v", err)
+	}
+}
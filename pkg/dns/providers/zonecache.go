@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// zoneCacheTTL 域名ID的缓存时间，域名ID在域名删除前不会变化
+	zoneCacheTTL = 10 * time.Minute
+	// zoneCacheNegativeTTL 域名不存在结果的缓存时间，较短以便尽快发现新接入的域名
+	zoneCacheNegativeTTL = time.Minute
+	// zoneCacheSweepSize 缓存条目超过该数量时写入前清理过期条目
+	zoneCacheSweepSize = 10000
+)
+
+// zoneCacheEntry 域名ID缓存条目，notFound为true时表示服务商返回域名不存在
+type zoneCacheEntry struct {
+	id       string
+	notFound bool
+	expires  time.Time
+}
+
+// zoneIDCache 服务商域名ID缓存，按凭据和域名在服务商实例之间共享
+// 每次记录操作都需要先查询域名ID，缓存后写操作只消耗一次API配额
+// 缓存不放在实例中：ProviderManager缓存的实例在凭据更新后重建，未绑定凭据的域名仍按请求创建实例，
+// 多条凭据记录也可能使用同一组密钥，按凭据内容共享可以让这些实例复用查询结果
+type zoneIDCache struct {
+	mu      sync.RWMutex
+	entries map[string]zoneCacheEntry
+	now     func() time.Time // 当前时间，测试中替换以控制过期
+
+	hits          atomic.Uint64
+	negativeHits  atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// ZoneCacheStats 域名ID缓存的命中统计
+type ZoneCacheStats struct {
+	Entries       int     `json:"entries"`
+	Hits          uint64  `json:"hits"`
+	NegativeHits  uint64  `json:"negative_hits"`
+	Misses        uint64  `json:"misses"`
+	Invalidations uint64  `json:"invalidations"`
+	HitRate       float64 `json:"hit_rate"`
+}
+
+// zoneIDs 全局域名ID缓存
+var zoneIDs = newZoneIDCache()
+
+// newZoneIDCache 创建使用系统时间的域名ID缓存
+func newZoneIDCache() *zoneIDCache {
+	return &zoneIDCache{entries: make(map[string]zoneCacheEntry), now: time.Now}
+}
+
+// GetZoneCacheStats 获取域名ID缓存的命中统计
+func GetZoneCacheStats() ZoneCacheStats {
+	zoneIDs.mu.RLock()
+	entries := len(zoneIDs.entries)
+	zoneIDs.mu.RUnlock()
+
+	stats := ZoneCacheStats{
+		Entries:       entries,
+		Hits:          zoneIDs.hits.Load(),
+		NegativeHits:  zoneIDs.negativeHits.Load(),
+		Misses:        zoneIDs.misses.Load(),
+		Invalidations: zoneIDs.invalidations.Load(),
+	}
+	if total := stats.Hits + stats.NegativeHits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits+stats.NegativeHits) / float64(total)
+	}
+	return stats
+}
+
+// ClearZoneCache 清空域名ID缓存，统计数据保留
+func ClearZoneCache() {
+	zoneIDs.mu.Lock()
+	zoneIDs.entries = make(map[string]zoneCacheEntry)
+	zoneIDs.mu.Unlock()
+}
+
+// zoneCacheKey 生成域名ID缓存的键，scope为credentialKey生成的凭据标识
+func zoneCacheKey(scope, domain string) string {
+	return scope + "/" + strings.ToLower(strings.TrimSuffix(domain, "."))
+}
+
+// cachedZoneID 从缓存获取域名ID，未命中时调用lookup查询服务商并缓存结果
+// 域名不存在的结果短时间缓存，其他错误不缓存
+func cachedZoneID(ctx context.Context, providerType string, config ProviderConfig, domain string, lookup func(ctx context.Context, domain string) (string, error)) (string, error) {
+	key := zoneCacheKey(credentialKey(providerType, config), domain)
+	now := zoneIDs.now()
+
+	zoneIDs.mu.RLock()
+	entry, ok := zoneIDs.entries[key]
+	zoneIDs.mu.RUnlock()
+	if ok && now.Before(entry.expires) {
+		if entry.notFound {
+			zoneIDs.negativeHits.Add(1)
+			return "", fmt.Errorf("%w: %s", ErrZoneNotFound, domain)
+		}
+		zoneIDs.hits.Add(1)
+		return entry.id, nil
+	}
+	zoneIDs.misses.Add(1)
+
+	id, err := lookup(ctx, domain)
+	switch {
+	case err == nil:
+		zoneIDs.store(key, zoneCacheEntry{id: id, expires: now.Add(zoneCacheTTL)}, now)
+	case errors.Is(err, ErrZoneNotFound):
+		zoneIDs.store(key, zoneCacheEntry{notFound: true, expires: now.Add(zoneCacheNegativeTTL)}, now)
+	}
+	return id, err
+}
+
+// invalidateZoneID 服务商返回域名不存在时删除缓存的域名ID，返回是否删除了条目
+// 域名不存在的缓存结果保留，避免缓存自身返回的错误清除缓存
+func invalidateZoneID(scope, domain string) bool {
+	key := zoneCacheKey(scope, domain)
+
+	zoneIDs.mu.Lock()
+	defer zoneIDs.mu.Unlock()
+	entry, ok := zoneIDs.entries[key]
+	if !ok || entry.notFound {
+		return false
+	}
+	delete(zoneIDs.entries, key)
+	zoneIDs.invalidations.Add(1)
+	return true
+}
+
+// store 写入缓存条目，条目过多时先清理过期条目
+func (c *zoneIDCache) store(key string, entry zoneCacheEntry, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= zoneCacheSweepSize {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[key] = entry
+}
@@ -0,0 +1,184 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// zoneCacheTestClock 可手动推进的时钟
+type zoneCacheTestClock struct {
+	now time.Time
+}
+
+func (c *zoneCacheTestClock) Now() time.Time {
+	return c.now
+}
+
+func (c *zoneCacheTestClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// useTestZoneCache 将全局域名ID缓存替换为使用测试时钟的空缓存，测试结束后恢复
+func useTestZoneCache(t *testing.T) *zoneCacheTestClock {
+	t.Helper()
+	clock := &zoneCacheTestClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	previous := zoneIDs
+	zoneIDs = newZoneIDCache()
+	zoneIDs.now = clock.Now
+	t.Cleanup(func() { zoneIDs = previous })
+	return clock
+}
+
+// zoneLookup 记录调用次数的域名ID查询函数
+type zoneLookup struct {
+	calls int
+	id    string
+	err   error
+}
+
+func (l *zoneLookup) lookup(ctx context.Context, domain string) (string, error) {
+	l.calls++
+	if l.err != nil {
+		return "", l.err
+	}
+	return l.id, nil
+}
+
+func TestCachedZoneIDHitAndExpiry(t *testing.T) {
+	clock := useTestZoneCache(t)
+	ctx := context.Background()
+	config := ProviderConfig{APIKey: "key", APISecret: "secret"}
+	lookup := &zoneLookup{id: "zone-1"}
+
+	for _, domain := range []string{"example.com", "Example.COM.", "example.com"} {
+		id, err := cachedZoneID(ctx, "mock", config, domain, lookup.lookup)
+		if err != nil || id != "zone-1" {
+			t.Fatalf("获取域名ID失败: %s, %v", id, err)
+		}
+	}
+	// 域名不区分大小写，末尾的点被忽略
+	if lookup.calls != 1 {
+		t.Fatalf("缓存有效期内应只查询一次，实际查询%d次", lookup.calls)
+	}
+
+	clock.Advance(zoneCacheTTL - time.Second)
+	cachedZoneID(ctx, "mock", config, "example.com", lookup.lookup)
+	if lookup.calls != 1 {
+		t.Fatalf("缓存过期前不应重新查询")
+	}
+
+	clock.Advance(time.Second)
+	lookup.id = "zone-2"
+	if id, _ := cachedZoneID(ctx, "mock", config, "example.com", lookup.lookup); id != "zone-2" || lookup.calls != 2 {
+		t.Fatalf("缓存过期后应重新查询，实际为%s，查询%d次", id, lookup.calls)
+	}
+
+	stats := GetZoneCacheStats()
+	want := ZoneCacheStats{Entries: 1, Hits: 3, Misses: 2, HitRate: 0.6}
+	if stats != want {
+		t.Fatalf("缓存统计不符合预期: %+v", stats)
+	}
+}
+
+func TestCachedZoneIDNegative(t *testing.T) {
+	clock := useTestZoneCache(t)
+	ctx := context.Background()
+	config := ProviderConfig{APIKey: "key", APISecret: "secret"}
+
+	// 其他错误不缓存
+	failing := &zoneLookup{err: errors.New("network error")}
+	for i := 0; i < 2; i++ {
+		if _, err := cachedZoneID(ctx, "mock", config, "example.com", failing.lookup); err == nil {
+			t.Fatalf("查询失败时应返回错误")
+		}
+	}
+	if failing.calls != 2 {
+		t.Fatalf("查询失败的结果不应缓存，实际查询%d次", failing.calls)
+	}
+
+	// 域名不存在的结果按较短的时间缓存
+	missing := &zoneLookup{err: ErrZoneNotFound}
+	for i := 0; i < 2; i++ {
+		if _, err := cachedZoneID(ctx, "mock", config, "example.com", missing.lookup); !errors.Is(err, ErrZoneNotFound) {
+			t.Fatalf("应返回ErrZoneNotFound，实际为: %v", err)
+		}
+	}
+	if missing.calls != 1 {
+		t.Fatalf("域名不存在的结果应被缓存，实际查询%d次", missing.calls)
+	}
+
+	clock.Advance(zoneCacheNegativeTTL)
+	found := &zoneLookup{id: "zone-1"}
+	if id, err := cachedZoneID(ctx, "mock", config, "example.com", found.lookup); err != nil || id != "zone-1" || found.calls != 1 {
+		t.Fatalf("域名不存在的缓存过期后应重新查询: %s, %v", id, err)
+	}
+
+	stats := GetZoneCacheStats()
+	if stats.NegativeHits != 1 || stats.Hits != 0 || stats.Misses != 4 {
+		t.Fatalf("缓存统计不符合预期: %+v", stats)
+	}
+}
+
+func TestCachedZoneIDCredentialIsolation(t *testing.T) {
+	useTestZoneCache(t)
+	ctx := context.Background()
+
+	first := &zoneLookup{id: "zone-a"}
+	second := &zoneLookup{id: "zone-b"}
+	configA := ProviderConfig{APIKey: "key", APISecret: "secret-a"}
+	configB := ProviderConfig{APIKey: "key", APISecret: "secret-b"}
+
+	idA, _ := cachedZoneID(ctx, "mock", configA, "example.com", first.lookup)
+	idB, _ := cachedZoneID(ctx, "mock", configB, "example.com", second.lookup)
+	if idA != "zone-a" || idB != "zone-b" {
+		t.Fatalf("不同凭据的缓存不应共用: %s, %s", idA, idB)
+	}
+
+	// 相同凭据不同服务商类型也分开缓存
+	other := &zoneLookup{id: "zone-c"}
+	if id, _ := cachedZoneID(ctx, "dnsla", configA, "example.com", other.lookup); id != "zone-c" {
+		t.Fatalf("不同服务商的缓存不应共用: %s", id)
+	}
+	if first.calls != 1 || second.calls != 1 || other.calls != 1 || GetZoneCacheStats().Entries != 3 {
+		t.Fatalf("每个凭据应各查询一次: %d, %d, %d", first.calls, second.calls, other.calls)
+	}
+}
+
+func TestInvalidateZoneID(t *testing.T) {
+	useTestZoneCache(t)
+	ctx := context.Background()
+	config := ProviderConfig{APIKey: "key", APISecret: "secret"}
+	scope := credentialKey("mock", config)
+
+	lookup := &zoneLookup{id: "zone-1"}
+	cachedZoneID(ctx, "mock", config, "example.com", lookup.lookup)
+	if !invalidateZoneID(scope, "EXAMPLE.com") {
+		t.Fatalf("应删除缓存的域名ID")
+	}
+	if invalidateZoneID(scope, "example.com") {
+		t.Fatalf("条目不存在时不应重复计数")
+	}
+	cachedZoneID(ctx, "mock", config, "example.com", lookup.lookup)
+	if lookup.calls != 2 {
+		t.Fatalf("失效后应重新查询，实际查询%d次", lookup.calls)
+	}
+
+	// 域名不存在的缓存结果不被删除
+	missing := &zoneLookup{err: ErrZoneNotFound}
+	cachedZoneID(ctx, "mock", config, "missing.com", missing.lookup)
+	if invalidateZoneID(scope, "missing.com") {
+		t.Fatalf("域名不存在的缓存结果不应被删除")
+	}
+
+	if stats := GetZoneCacheStats(); stats.Invalidations != 1 || stats.Entries != 2 {
+		t.Fatalf("缓存统计不符合预期: %+v", stats)
+	}
+
+	// 清空缓存后统计数据保留
+	ClearZoneCache()
+	if stats := GetZoneCacheStats(); stats.Entries != 0 || stats.Invalidations != 1 || stats.Misses != 3 {
+		t.Fatalf("清空缓存后的统计不符合预期: %+v", stats)
+	}
+}
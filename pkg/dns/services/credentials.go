@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"domain-max/pkg/dns/models"
+	"domain-max/pkg/dns/providers"
+	"domain-max/pkg/utils"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrCredentialNotFound DNS服务商凭据不存在或无权使用
+var ErrCredentialNotFound = errors.New("DNS服务商凭据不存在")
+
+// loadCredential 加载用户有权用于接入域名的DNS服务商凭据，非管理员只能使用自己的凭据
+// 默认凭据对应管理员的服务商账号，允许非管理员使用会暴露账号下其他用户的域名，
+// 已接入的域名通过域名上绑定的凭据操作记录，不经过此处校验
+func loadCredential(db *gorm.DB, providerID, userID uint, isAdmin bool) (*models.DNSProvider, error) {
+	var credential models.DNSProvider
+	query := db.Where("id = ?", providerID)
+	if !isAdmin {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if err := query.First(&credential).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCredentialNotFound
+		}
+		return nil, err
+	}
+
+	if credential.Config == "" {
+		return nil, fmt.Errorf("%w: 凭据尚未配置", ErrCredentialNotFound)
+	}
+	return &credential, nil
+}
+
+// NewCredentialLoader 创建从数据库加载并解密DNS服务商凭据的加载器，供ProviderManager使用
+func NewCredentialLoader(db *gorm.DB, encService *utils.EncryptionService) providers.CredentialLoader {
+	return func(ctx context.Context, credentialID uint) (string, map[string]string, error) {
+		var credential models.DNSProvider
+		if err := db.WithContext(ctx).First(&credential, credentialID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", nil, ErrCredentialNotFound
+			}
+			return "", nil, err
+		}
+		if credential.Config == "" {
+			return "", nil, fmt.Errorf("%w: 凭据尚未配置", ErrCredentialNotFound)
+		}
+
+		config, err := encService.DecryptJSON(credential.Config)
+		if err != nil {
+			return "", nil, errors.New("凭据解密失败")
+		}
+		return credential.Type, config, nil
+	}
+}
+
+// providerInstance 从服务商管理器获取凭据对应的DNS服务商实例
+func providerInstance(ctx context.Context, manager *providers.ProviderManager, credentialID uint) (providers.DNSProvider, error) {
+	provider, err := manager.GetProvider(ctx, credentialID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProviderUnavailable, err)
+	}
+	return provider, nil
+}
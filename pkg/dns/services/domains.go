@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"domain-max/pkg/dns/models"
+	"domain-max/pkg/dns/providers"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrDomainExists 域名已被接入
+	ErrDomainExists = errors.New("域名已存在")
+	// ErrDomainInUse 域名下仍有DNS记录
+	ErrDomainInUse = errors.New("域名下仍有DNS记录")
+	// ErrInvalidDomain 域名格式错误
+	ErrInvalidDomain = errors.New("域名格式错误")
+	// ErrZoneNotFound 域名未托管在服务商账号下
+	ErrZoneNotFound = errors.New("域名未在DNS服务商处托管")
+)
+
+// DomainService 域名接入服务，负责域名与DNS服务商凭据的绑定
+type DomainService struct {
+	DB              *gorm.DB
+	ProviderManager *providers.ProviderManager
+}
+
+// NewDomainService 创建域名接入服务
+func NewDomainService(db *gorm.DB, manager *providers.ProviderManager) *DomainService {
+	return &DomainService{
+		DB:              db,
+		ProviderManager: manager,
+	}
+}
+
+// ImportResult 域名导入结果
+type ImportResult struct {
+	Imported []models.Domain `json:"imported"`
+	Skipped  []string        `json:"skipped"`
+}
+
+// ListDomains 获取域名列表，非管理员只能看到自己的域名
+func (s *DomainService) ListDomains(userID uint, isAdmin bool) ([]models.Domain, error) {
+	var domains []models.Domain
+	query := s.DB.Model(&models.Domain{})
+	if !isAdmin {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if err := query.Order("domain_name ASC").Find(&domains).Error; err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// GetDomain 获取单个域名
+func (s *DomainService) GetDomain(id, userID uint, isAdmin bool) (*models.Domain, error) {
+	var domain models.Domain
+	query := s.DB.Where("id = ?", id)
+	if !isAdmin {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if err := query.First(&domain).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDomainNotFound
+		}
+		return nil, err
+	}
+	return &domain, nil
+}
+
+// CreateDomain 接入域名：校验域名已托管在绑定的服务商账号下后保存
+func (s *DomainService) CreateDomain(ctx context.Context, userID uint, isAdmin bool, req models.CreateDomainRequest) (*models.Domain, error) {
+	domainName := normalizeDomainName(req.DomainName)
+	if err := models.ValidateDomainName(domainName); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDomain, err)
+	}
+
+	if err := s.ensureDomainAvailable(domainName); err != nil {
+		return nil, err
+	}
+
+	credential, err := loadCredential(s.DB, req.DNSProviderID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := providerInstance(ctx, s.ProviderManager, credential.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneID, err := verifyZone(ctx, provider, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := newDomain(userID, credential, domainName, zoneID)
+	if err := s.DB.Create(domain).Error; err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+// ImportDomains 导入服务商账号下的全部域名，已接入的域名会被跳过
+func (s *DomainService) ImportDomains(ctx context.Context, userID uint, isAdmin bool, req models.ImportDomainsRequest) (*ImportResult, error) {
+	credential, err := loadCredential(s.DB, req.DNSProviderID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := providerInstance(ctx, s.ProviderManager, credential.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := provider.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProviderOperation, err)
+	}
+
+	result := &ImportResult{
+		Imported: []models.Domain{},
+		Skipped:  []string{},
+	}
+	for _, zone := range zones {
+		domainName := normalizeDomainName(zone.Name)
+		if err := s.ensureDomainAvailable(domainName); err != nil {
+			result.Skipped = append(result.Skipped, domainName)
+			continue
+		}
+
+		domain := newDomain(userID, credential, domainName, zone.ID)
+		if err := s.DB.Create(domain).Error; err != nil {
+			result.Skipped = append(result.Skipped, domainName)
+			continue
+		}
+		result.Imported = append(result.Imported, *domain)
+	}
+
+	return result, nil
+}
+
+// DeleteDomain 删除域名，域名下仍有记录时拒绝删除
+func (s *DomainService) DeleteDomain(id, userID uint, isAdmin bool) error {
+	domain, err := s.GetDomain(id, userID, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	if err := s.DB.Model(&models.DNSRecord{}).Where("domain_id = ?", domain.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: 共%d条，请先删除记录", ErrDomainInUse, count)
+	}
+
+	// 域名名称是唯一索引，永久删除以便之后重新接入
+	return s.DB.Unscoped().Delete(domain).Error
+}
+
+// ensureDomainAvailable 检查域名尚未被接入
+func (s *DomainService) ensureDomainAvailable(domainName string) error {
+	var count int64
+	if err := s.DB.Model(&models.Domain{}).Where("domain_name = ?", domainName).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDomainExists
+	}
+	return nil
+}
+
+// verifyZone 校验域名已托管在服务商账号下，不支持校验接口的服务商通过读取记录列表判断
+func verifyZone(ctx context.Context, provider providers.DNSProvider, domainName string) (string, error) {
+	if verifier, ok := provider.(providers.ZoneVerifier); ok {
+		zoneID, err := verifier.VerifyZone(ctx, domainName)
+		if err != nil {
+			return "", zoneError(err)
+		}
+		return zoneID, nil
+	}
+
+	if _, err := provider.ListRecords(ctx, domainName); err != nil {
+		return "", zoneError(err)
+	}
+	return "", nil
+}
+
+// zoneError 区分校验失败的原因，认证失败、接口地址错误、限流等服务商错误不应视为域名未托管
+func zoneError(err error) error {
+	if errors.Is(err, providers.ErrAuthFailed) || errors.Is(err, providers.ErrInvalidConfig) || errors.Is(err, providers.ErrQuotaExceeded) ||
+		errors.Is(err, providers.ErrCircuitOpen) || providers.IsRetryable(err) {
+		return fmt.Errorf("%w: %w", ErrProviderOperation, err)
+	}
+	return fmt.Errorf("%w: %w", ErrZoneNotFound, err)
+}
+
+// newDomain 构建绑定到服务商凭据的域名模型
+func newDomain(userID uint, credential *models.DNSProvider, domainName, zoneID string) *models.Domain {
+	providerID := credential.ID
+	return &models.Domain{
+		UserID:        userID,
+		DomainName:    domainName,
+		Platform:      credential.Type,
+		DNSProviderID: &providerID,
+		ZoneID:        zoneID,
+		IsActive:      true,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// normalizeDomainName 统一域名格式：小写并去掉末尾的点
+func normalizeDomainName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
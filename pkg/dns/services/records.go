@@ -0,0 +1,793 @@
+package services
+
+import (
+	"context"
+	"domain-max/pkg/dns/models"
+	"domain-max/pkg/dns/providers"
+	"domain-max/pkg/utils"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrDomainNotFound 域名不存在或无权访问
+	ErrDomainNotFound = errors.New("域名不存在")
+	// ErrRecordNotFound 记录不存在或无权访问
+	ErrRecordNotFound = errors.New("DNS记录不存在")
+	// ErrInvalidRecord 记录内容未通过校验
+	ErrInvalidRecord = errors.New("DNS记录无效")
+	// ErrProviderUnavailable 无法为域名创建DNS服务商实例
+	ErrProviderUnavailable = errors.New("DNS服务商不可用")
+	// ErrProviderOperation DNS服务商接口调用失败
+	ErrProviderOperation = errors.New("DNS服务商操作失败")
+)
+
+// RecordService DNS记录服务，负责在数据库和DNS服务商之间同步记录变更
+type RecordService struct {
+	DB                *gorm.DB
+	ProviderManager   *providers.ProviderManager
+	EncryptionService *utils.EncryptionService
+}
+
+// NewRecordService 创建DNS记录服务
+func NewRecordService(db *gorm.DB, manager *providers.ProviderManager, encService *utils.EncryptionService) *RecordService {
+	return &RecordService{
+		DB:                db,
+		ProviderManager:   manager,
+		EncryptionService: encService,
+	}
+}
+
+// RecordQuery DNS记录查询条件
+type RecordQuery struct {
+	UserID   uint
+	IsAdmin  bool
+	DomainID uint
+	Domain   string
+	Type     string
+	Page     int
+	Limit    int
+}
+
+// BatchFailure 批量操作中单条记录的失败信息
+type BatchFailure struct {
+	ID    uint   `json:"id"`
+	Error string `json:"error"`
+}
+
+// ListRecords 分页查询DNS记录
+func (s *RecordService) ListRecords(q RecordQuery) ([]models.DNSRecord, int64, error) {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 || q.Limit > 100 {
+		q.Limit = 20
+	}
+
+	query := s.DB.Model(&models.DNSRecord{})
+	if !q.IsAdmin {
+		query = query.Where("dns_records.user_id = ?", q.UserID)
+	}
+	if q.DomainID > 0 {
+		query = query.Where("dns_records.domain_id = ?", q.DomainID)
+	}
+	if q.Domain != "" {
+		query = query.Joins("JOIN domains ON domains.id = dns_records.domain_id").
+			Where("domains.domain_name = ?", q.Domain)
+	}
+	if q.Type != "" {
+		query = query.Where("dns_records.type = ?", strings.ToUpper(q.Type))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []models.DNSRecord
+	err := query.Preload("Domain").
+		Order("dns_records.id DESC").
+		Offset((q.Page - 1) * q.Limit).
+		Limit(q.Limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// GetRecord 获取单条DNS记录
+func (s *RecordService) GetRecord(id, userID uint, isAdmin bool) (*models.DNSRecord, error) {
+	var record models.DNSRecord
+	query := s.DB.Preload("Domain").Where("id = ?", id)
+	if !isAdmin {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if err := query.First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// CreateRecord 创建DNS记录：先在事务中写入数据库，再调用服务商接口，服务商失败时回滚事务
+func (s *RecordService) CreateRecord(ctx context.Context, userID uint, isAdmin bool, req models.CreateDNSRecordRequest) (*models.DNSRecord, error) {
+	domain, err := s.loadDomain(req.DomainID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	record := newRecordFromRequest(domain, userID, req)
+	if err := validateRecord(&record, req.AllowPrivateIP); err != nil {
+		return nil, err
+	}
+	if err := applyProviderFeatures(&record, domain.Platform, req.TTL > 0); err != nil {
+		return nil, err
+	}
+
+	provider, err := s.providerForDomain(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *providers.DNSRecord
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		record.Status = "pending"
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+
+		result, err := provider.AddRecord(ctx, domain.DomainName, toProviderRecord(&record))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrProviderOperation, err)
+		}
+		created = result
+
+		record.ExternalID = created.ID
+		record.Status = "active"
+		record.Extra = mergeProviderExtra(record.Extra, created.Extra)
+		if err := tx.Model(&record).Updates(map[string]interface{}{
+			"external_id": record.ExternalID,
+			"status":      record.Status,
+			"extra":       record.Extra,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(newSubDomain(&record)).Error
+	})
+	if err != nil {
+		// 服务商已创建成功但数据库提交失败时，删除服务商侧的记录保持一致
+		if created != nil {
+			s.compensate("删除", func() error {
+				return provider.DeleteRecord(ctx, domain.DomainName, created.ID)
+			})
+		}
+		return nil, err
+	}
+
+	record.Domain = *domain
+	return &record, nil
+}
+
+// UpdateRecord 更新DNS记录，数据库提交失败时将服务商侧记录恢复为原值
+func (s *RecordService) UpdateRecord(ctx context.Context, id, userID uint, isAdmin bool, req models.UpdateDNSRecordRequest) (*models.DNSRecord, error) {
+	existing, err := s.GetRecord(id, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	domain := existing.Domain
+
+	updated := *existing
+	applyUpdateRequest(&updated, req)
+	if err := validateRecord(&updated, req.AllowPrivateIP); err != nil {
+		return nil, err
+	}
+	if err := applyProviderFeatures(&updated, domain.Platform, req.TTL > 0); err != nil {
+		return nil, err
+	}
+
+	provider, err := s.providerForDomain(ctx, &domain)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := false
+	externalID := existing.ExternalID
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.DNSRecord{}).Where("id = ?", existing.ID).Updates(map[string]interface{}{
+			"subdomain":  updated.Subdomain,
+			"type":       updated.Type,
+			"value":      updated.Value,
+			"ttl":        updated.TTL,
+			"priority":   updated.Priority,
+			"weight":     updated.Weight,
+			"port":       updated.Port,
+			"line":       updated.Line,
+			"comment":    updated.Comment,
+			"extra":      updated.Extra,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := subDomainWhere(tx, existing).
+			Updates(map[string]interface{}{
+				"sub_domain_name": updated.Subdomain,
+				"record_type":     updated.Type,
+				"record_value":    updated.Value,
+				"ttl":             updated.TTL,
+			}).Error; err != nil {
+			return err
+		}
+
+		// 暂停时已删除服务商侧记录的记录只更新数据库，启用时按更新后的内容重新创建
+		if existing.ExternalID == "" {
+			return nil
+		}
+
+		result, err := providers.UpdateRecordWithResult(ctx, provider, domain.DomainName, existing.ExternalID, toProviderRecord(&updated))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrProviderOperation, err)
+		}
+		applied = true
+
+		// 部分服务商的记录ID随记录值变化，需要同步保存新的ID
+		if result.ID == "" || result.ID == existing.ExternalID {
+			return nil
+		}
+		externalID = result.ID
+		if err := tx.Model(&models.DNSRecord{}).Where("id = ?", existing.ID).
+			Update("external_id", externalID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SubDomain{}).
+			Where("domain_id = ? AND external_id = ?", domain.ID, existing.ExternalID).
+			Update("external_id", externalID).Error
+	})
+	if err != nil {
+		if applied {
+			s.compensate("恢复", func() error {
+				_, err := providers.UpdateRecordWithResult(ctx, provider, domain.DomainName, externalID, toProviderRecord(existing))
+				return err
+			})
+		}
+		return nil, err
+	}
+
+	updated.ExternalID = externalID
+	return &updated, nil
+}
+
+// DeleteRecord 删除DNS记录，数据库提交失败时在服务商侧重新创建该记录
+func (s *RecordService) DeleteRecord(ctx context.Context, id, userID uint, isAdmin bool) error {
+	existing, err := s.GetRecord(id, userID, isAdmin)
+	if err != nil {
+		return err
+	}
+	domain := existing.Domain
+
+	provider, err := s.providerForDomain(ctx, &domain)
+	if err != nil {
+		return err
+	}
+
+	applied := false
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.DNSRecord{}, existing.ID).Error; err != nil {
+			return err
+		}
+		if err := subDomainWhere(tx, existing).Delete(&models.SubDomain{}).Error; err != nil {
+			return err
+		}
+
+		if existing.ExternalID == "" {
+			return nil
+		}
+		if err := provider.DeleteRecord(ctx, domain.DomainName, existing.ExternalID); err != nil {
+			// 服务商侧记录已被删除（例如在服务商控制台手动删除）时只删除数据库中的记录
+			if errors.Is(err, providers.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("%w: %w", ErrProviderOperation, err)
+		}
+		applied = true
+		return nil
+	})
+	if err != nil && applied {
+		// 记录仍保留在数据库中，需要在服务商侧重建并更新外部ID
+		s.compensate("重建", func() error {
+			restored, err := provider.AddRecord(ctx, domain.DomainName, toProviderRecord(existing))
+			if err != nil {
+				return err
+			}
+			return s.updateExternalID(existing, restored.ID)
+		})
+	}
+	return err
+}
+
+// SetRecordStatus 启用或暂停DNS记录
+// 服务商支持暂停记录时调用服务商接口；不支持或对该记录返回ErrUnsupported时暂停会删除服务商侧记录并清空外部ID，启用时按数据库中保存的记录重新创建
+func (s *RecordService) SetRecordStatus(ctx context.Context, id, userID uint, isAdmin bool, enabled bool) (*models.DNSRecord, error) {
+	existing, err := s.GetRecord(id, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	domain := existing.Domain
+
+	status := "inactive"
+	if enabled {
+		status = "active"
+	}
+	if existing.Status == status {
+		return existing, nil
+	}
+
+	provider, err := s.providerForDomain(ctx, &domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// 外部ID为空说明暂停时已删除服务商侧记录，即使服务商支持暂停也需要重新创建
+	native := providers.SupportsRecordStatus(provider) && existing.ExternalID != ""
+	setter, _ := provider.(providers.RecordStatusSetter)
+
+	// 启用时重新创建和暂停失败后重建的都是启用状态的记录，不能沿用数据库中记录的状态
+	enabledRecord := toProviderRecord(existing)
+	enabledRecord.Status = "active"
+
+	applied := false
+	externalID := existing.ExternalID
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.DNSRecord{}).Where("id = ?", existing.ID).Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+
+		if native {
+			err := setter.SetRecordStatus(ctx, domain.DomainName, existing.ExternalID, enabled)
+			switch {
+			case errors.Is(err, providers.ErrUnsupported):
+				// 服务商不能单独设置该记录的状态（例如华为云多值记录集），改为删除和重新创建
+				native = false
+			case err != nil:
+				return fmt.Errorf("%w: %w", ErrProviderOperation, err)
+			}
+		}
+
+		switch {
+		case native:
+		case enabled:
+			created, err := provider.AddRecord(ctx, domain.DomainName, enabledRecord)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrProviderOperation, err)
+			}
+			externalID = created.ID
+		default:
+			if err := provider.DeleteRecord(ctx, domain.DomainName, existing.ExternalID); err != nil {
+				return fmt.Errorf("%w: %w", ErrProviderOperation, err)
+			}
+			externalID = ""
+		}
+		applied = true
+
+		if err := tx.Model(&models.DNSRecord{}).Where("id = ?", existing.ID).
+			Update("external_id", externalID).Error; err != nil {
+			return err
+		}
+		return subDomainWhere(tx, existing).Updates(map[string]interface{}{
+			"status":      status,
+			"external_id": externalID,
+		}).Error
+	})
+	if err != nil {
+		if applied {
+			switch {
+			case native:
+				s.compensate("恢复状态", func() error {
+					return setter.SetRecordStatus(ctx, domain.DomainName, existing.ExternalID, !enabled)
+				})
+			case enabled:
+				s.compensate("删除", func() error {
+					return provider.DeleteRecord(ctx, domain.DomainName, externalID)
+				})
+			default:
+				// 记录仍保留在数据库中，需要在服务商侧重建并更新外部ID
+				s.compensate("重建", func() error {
+					restored, err := provider.AddRecord(ctx, domain.DomainName, enabledRecord)
+					if err != nil {
+						return err
+					}
+					return s.updateExternalID(existing, restored.ID)
+				})
+			}
+		}
+		return nil, err
+	}
+
+	existing.Status = status
+	existing.ExternalID = externalID
+	return existing, nil
+}
+
+// BatchCreateRecords 批量创建DNS记录，任意一条失败时整体回滚
+func (s *RecordService) BatchCreateRecords(ctx context.Context, userID uint, isAdmin bool, reqs []models.CreateDNSRecordRequest) ([]models.DNSRecord, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("%w: 记录列表不能为空", ErrInvalidRecord)
+	}
+
+	// 批量接口要求所有记录属于同一个域名，以便使用服务商的批量能力
+	domainID := reqs[0].DomainID
+	for _, req := range reqs {
+		if req.DomainID != domainID {
+			return nil, fmt.Errorf("%w: 批量创建的记录必须属于同一个域名", ErrInvalidRecord)
+		}
+	}
+
+	domain, err := s.loadDomain(domainID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]models.DNSRecord, 0, len(reqs))
+	for i, req := range reqs {
+		record := newRecordFromRequest(domain, userID, req)
+		if err := validateRecord(&record, req.AllowPrivateIP); err != nil {
+			return nil, fmt.Errorf("第%d条记录: %w", i+1, err)
+		}
+		if err := applyProviderFeatures(&record, domain.Platform, req.TTL > 0); err != nil {
+			return nil, fmt.Errorf("第%d条记录: %w", i+1, err)
+		}
+		records = append(records, record)
+	}
+
+	provider, err := s.providerForDomain(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []providers.DNSRecord
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		providerRecords := make([]providers.DNSRecord, 0, len(records))
+		for i := range records {
+			records[i].Status = "pending"
+			if err := tx.Create(&records[i]).Error; err != nil {
+				return err
+			}
+			providerRecords = append(providerRecords, toProviderRecord(&records[i]))
+		}
+
+		var batchErr error
+		created, batchErr = provider.BatchAddRecords(ctx, domain.DomainName, providerRecords)
+		if batchErr != nil {
+			return fmt.Errorf("%w: %w", ErrProviderOperation, batchErr)
+		}
+		if len(created) != len(records) {
+			return fmt.Errorf("%w: 服务商返回的记录数量不一致", ErrProviderOperation)
+		}
+
+		for i := range records {
+			records[i].ExternalID = created[i].ID
+			records[i].Status = "active"
+			records[i].Extra = mergeProviderExtra(records[i].Extra, created[i].Extra)
+			if err := tx.Model(&records[i]).Updates(map[string]interface{}{
+				"external_id": records[i].ExternalID,
+				"status":      records[i].Status,
+				"extra":       records[i].Extra,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(newSubDomain(&records[i])).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// 删除服务商侧已经创建成功的记录
+		for _, record := range created {
+			recordID := record.ID
+			s.compensate("删除", func() error {
+				return provider.DeleteRecord(ctx, domain.DomainName, recordID)
+			})
+		}
+		return nil, err
+	}
+
+	for i := range records {
+		records[i].Domain = *domain
+	}
+	return records, nil
+}
+
+// BatchDeleteRecords 批量删除DNS记录，每条记录独立保证一致性并返回失败明细
+func (s *RecordService) BatchDeleteRecords(ctx context.Context, ids []uint, userID uint, isAdmin bool) ([]uint, []BatchFailure) {
+	var deleted []uint
+	var failures []BatchFailure
+
+	for _, id := range ids {
+		if err := s.DeleteRecord(ctx, id, userID, isAdmin); err != nil {
+			failures = append(failures, BatchFailure{ID: id, Error: err.Error()})
+			continue
+		}
+		deleted = append(deleted, id)
+	}
+
+	return deleted, failures
+}
+
+// loadDomain 加载当前用户有权操作的域名
+func (s *RecordService) loadDomain(domainID, userID uint, isAdmin bool) (*models.Domain, error) {
+	var domain models.Domain
+	query := s.DB.Where("id = ?", domainID)
+	if !isAdmin {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if err := query.First(&domain).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDomainNotFound
+		}
+		return nil, err
+	}
+
+	if !domain.IsActive {
+		return nil, fmt.Errorf("%w: 域名已停用", ErrDomainNotFound)
+	}
+	return &domain, nil
+}
+
+// providerForDomain 获取域名对应的DNS服务商实例
+// 优先使用服务商管理器中缓存的绑定凭据实例，未绑定凭据时使用域名上保存的平台和密钥创建实例
+func (s *RecordService) providerForDomain(ctx context.Context, domain *models.Domain) (providers.DNSProvider, error) {
+	if domain.DNSProviderID != nil {
+		return providerInstance(ctx, s.ProviderManager, *domain.DNSProviderID)
+	}
+
+	config := map[string]string{}
+
+	if domain.APIKey != "" {
+		apiKey, err := s.EncryptionService.Decrypt(domain.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: 凭据解密失败", ErrProviderUnavailable)
+		}
+		config["api_key"] = apiKey
+	}
+	if domain.APISecret != "" {
+		apiSecret, err := s.EncryptionService.Decrypt(domain.APISecret)
+		if err != nil {
+			return nil, fmt.Errorf("%w: 凭据解密失败", ErrProviderUnavailable)
+		}
+		config["api_secret"] = apiSecret
+	}
+
+	provider, err := s.ProviderManager.Factory().CreateProvider(domain.Platform, config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProviderUnavailable, err)
+	}
+	return provider, nil
+}
+
+// updateExternalID 更新记录在服务商侧的ID
+func (s *RecordService) updateExternalID(record *models.DNSRecord, externalID string) error {
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.DNSRecord{}).Where("id = ?", record.ID).
+			Update("external_id", externalID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SubDomain{}).
+			Where("domain_id = ? AND external_id = ?", record.DomainID, record.ExternalID).
+			Update("external_id", externalID).Error
+	})
+}
+
+// subDomainWhere 查询记录对应的子域名，暂停时删除了服务商侧记录的记录外部ID为空，需要同时按名称、类型和记录值匹配，
+// 避免同名同类型的多条暂停记录被一起修改
+func subDomainWhere(tx *gorm.DB, record *models.DNSRecord) *gorm.DB {
+	query := tx.Model(&models.SubDomain{}).Where("domain_id = ? AND external_id = ?", record.DomainID, record.ExternalID)
+	if record.ExternalID == "" {
+		query = query.Where("sub_domain_name = ? AND record_type = ? AND record_value = ?", record.Subdomain, record.Type, record.Value)
+	}
+	return query
+}
+
+// compensate 执行补偿操作，失败时只记录日志，需要人工介入
+func (s *RecordService) compensate(action string, fn func() error) {
+	if err := fn(); err != nil {
+		log.Printf("DNS记录补偿操作(%s)失败，数据库与服务商可能不一致: %v", action, err)
+	}
+}
+
+// newRecordFromRequest 根据创建请求构建记录模型
+func newRecordFromRequest(domain *models.Domain, userID uint, req models.CreateDNSRecordRequest) models.DNSRecord {
+	ttl := req.TTL
+	if ttl == 0 {
+		ttl = 600
+	}
+
+	return models.DNSRecord{
+		UserID:    userID,
+		DomainID:  domain.ID,
+		Subdomain: strings.TrimSpace(req.Subdomain),
+		Type:      strings.ToUpper(req.Type),
+		Value:     strings.TrimSpace(req.Value),
+		TTL:       ttl,
+		Priority:  req.Priority,
+		Weight:    req.Weight,
+		Port:      req.Port,
+		Line:      strings.TrimSpace(req.Line),
+		Comment:   req.Comment,
+		Extra:     models.RecordExtra(req.Extra),
+		Status:    "active",
+	}
+}
+
+// applyUpdateRequest 将更新请求中提供的字段合并到记录中
+func applyUpdateRequest(record *models.DNSRecord, req models.UpdateDNSRecordRequest) {
+	if req.Subdomain != "" {
+		record.Subdomain = strings.TrimSpace(req.Subdomain)
+	}
+	if req.Type != "" {
+		record.Type = strings.ToUpper(req.Type)
+	}
+	if req.Value != "" {
+		record.Value = strings.TrimSpace(req.Value)
+	}
+	if req.TTL > 0 {
+		record.TTL = req.TTL
+	}
+	if req.Priority > 0 {
+		record.Priority = req.Priority
+	}
+	if req.Weight > 0 {
+		record.Weight = req.Weight
+	}
+	if req.Port > 0 {
+		record.Port = req.Port
+	}
+	if req.Line != "" {
+		record.Line = strings.TrimSpace(req.Line)
+	}
+	if req.Comment != "" {
+		record.Comment = req.Comment
+	}
+	if len(req.Extra) > 0 {
+		extra := make(models.RecordExtra, len(record.Extra)+len(req.Extra))
+		for key, value := range record.Extra {
+			extra[key] = value
+		}
+		for key, value := range req.Extra {
+			extra[key] = value
+		}
+		record.Extra = extra
+	}
+}
+
+// validateRecord 规范化记录值并校验记录
+func validateRecord(record *models.DNSRecord, allowPrivateIP bool) error {
+	normalizeRecordValue(record)
+
+	if err := record.ValidateDNSRecord(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+
+	if !allowPrivateIP && (record.Type == "A" || record.Type == "AAAA") {
+		if ip := net.ParseIP(record.Value); ip != nil && (ip.IsPrivate() || ip.IsLoopback()) {
+			return fmt.Errorf("%w: 不允许使用私有IP地址", ErrInvalidRecord)
+		}
+	}
+	return nil
+}
+
+// applyProviderFeatures 按服务商功能特性校验TTL，未指定TTL且默认值低于服务商下限时使用下限
+func applyProviderFeatures(record *models.DNSRecord, platform string, ttlProvided bool) error {
+	features := providers.GetProviderFeatures(platform)
+	if !ttlProvided && record.TTL < features.MinTTL {
+		record.TTL = features.MinTTL
+	}
+
+	if record.TTL < features.MinTTL || record.TTL > features.MaxTTL {
+		return fmt.Errorf("%w: 该服务商的TTL范围为%d-%d秒", ErrInvalidRecord, features.MinTTL, features.MaxTTL)
+	}
+	return nil
+}
+
+// normalizeRecordValue 统一MX和SRV记录的存储格式
+// 数据库中保存完整的记录值（如"10 mx.example.com"），优先级等字段与记录值保持同步
+func normalizeRecordValue(record *models.DNSRecord) {
+	parts := strings.Fields(record.Value)
+
+	switch record.Type {
+	case "MX":
+		if len(parts) == 1 {
+			record.Value = fmt.Sprintf("%d %s", record.Priority, parts[0])
+		} else if len(parts) == 2 {
+			if priority, err := strconv.Atoi(parts[0]); err == nil {
+				record.Priority = priority
+			}
+		}
+	case "SRV":
+		if len(parts) == 1 {
+			record.Value = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, parts[0])
+		} else if len(parts) == 4 {
+			if priority, err := strconv.Atoi(parts[0]); err == nil {
+				record.Priority = priority
+			}
+			if weight, err := strconv.Atoi(parts[1]); err == nil {
+				record.Weight = weight
+			}
+			if port, err := strconv.Atoi(parts[2]); err == nil {
+				record.Port = port
+			}
+		}
+	}
+}
+
+// toProviderRecord 将数据库记录转换为服务商记录，MX和SRV记录只传递目标主机
+func toProviderRecord(record *models.DNSRecord) providers.DNSRecord {
+	value := record.Value
+	parts := strings.Fields(value)
+
+	switch {
+	case record.Type == "MX" && len(parts) == 2:
+		value = parts[1]
+	case record.Type == "SRV" && len(parts) == 4:
+		value = parts[3]
+	}
+
+	return providers.DNSRecord{
+		ID:       record.ExternalID,
+		Name:     record.Subdomain,
+		Type:     record.Type,
+		Value:    value,
+		TTL:      record.TTL,
+		Priority: record.Priority,
+		Weight:   record.Weight,
+		Port:     record.Port,
+		Line:     record.Line,
+		Status:   record.Status,
+		Extra:    record.Extra,
+	}
+}
+
+// mergeProviderExtra 合并服务商返回的扩展属性，服务商返回的值优先
+func mergeProviderExtra(extra models.RecordExtra, returned map[string]string) models.RecordExtra {
+	if len(returned) == 0 {
+		return extra
+	}
+	merged := make(models.RecordExtra, len(extra)+len(returned))
+	for key, value := range extra {
+		merged[key] = value
+	}
+	for key, value := range returned {
+		merged[key] = value
+	}
+	return merged
+}
+
+// newSubDomain 根据DNS记录构建子域名模型
+func newSubDomain(record *models.DNSRecord) *models.SubDomain {
+	return &models.SubDomain{
+		DomainID:      record.DomainID,
+		SubDomainName: record.Subdomain,
+		RecordType:    record.Type,
+		RecordValue:   record.Value,
+		TTL:           record.TTL,
+		Status:        record.Status,
+		ExternalID:    record.ExternalID,
+	}
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"domain-max/pkg/dns/models"
+	"domain-max/pkg/dns/providers"
+	"domain-max/pkg/utils"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// statuslessProviderType 不支持暂停记录的测试服务商，暂停和启用走删除和重新创建
+const statuslessProviderType = "mock-statusless"
+
+// invalidConfigProviderType 创建实例时总是返回配置错误的测试服务商
+const invalidConfigProviderType = "mock-invalid-config"
+
+// statuslessProvider 只暴露DNSProvider接口的模拟服务商
+type statuslessProvider struct {
+	providers.DNSProvider
+}
+
+func init() {
+	providers.Register(providers.ProviderDefinition{
+		Type:        statuslessProviderType,
+		DisplayName: "不支持暂停的模拟DNS",
+		Development: true,
+		Features:    providers.GetProviderFeatures("mock"),
+		Constructor: func(config providers.ProviderConfig) (providers.DNSProvider, error) {
+			mock, err := providers.NewMockProvider(config)
+			if err != nil {
+				return nil, err
+			}
+			return statuslessProvider{mock}, nil
+		},
+	})
+	providers.Register(providers.ProviderDefinition{
+		Type:        invalidConfigProviderType,
+		DisplayName: "配置错误的模拟DNS",
+		Development: true,
+		Features:    providers.GetProviderFeatures("mock"),
+		Constructor: func(config providers.ProviderConfig) (providers.DNSProvider, error) {
+			return nil, &providers.ConfigError{Fields: []providers.FieldError{{Key: "api_secret", Message: "API Secret不能为空"}}}
+		},
+	})
+}
+
+// newTestRecordService 创建使用内存数据库的记录服务，域名example.com托管在以测试名称为账号的模拟服务商中
+func newTestRecordService(t *testing.T, platform string) (*RecordService, *models.Domain) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+	// 内存数据库每个连接是独立的数据库，只使用一个连接
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := db.AutoMigrate(&models.Domain{}, &models.DNSRecord{}, &models.SubDomain{}); err != nil {
+		t.Fatalf("创建数据表失败: %v", err)
+	}
+
+	encryption, err := utils.NewEncryptionService("0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatalf("创建加密服务失败: %v", err)
+	}
+	apiKey, _ := encryption.Encrypt("records-" + t.Name())
+
+	domain := &models.Domain{UserID: 1, DomainName: "example.com", Platform: platform, APIKey: apiKey, IsActive: true}
+	if err := db.Create(domain).Error; err != nil {
+		t.Fatalf("创建域名失败: %v", err)
+	}
+
+	manager := providers.NewProviderManager(providers.NewProviderFactory(), nil)
+	return NewRecordService(db, manager, encryption), domain
+}
+
+// subDomainOf 按外部ID以外的字段查询记录对应的子域名
+func subDomainOf(t *testing.T, s *RecordService, record *models.DNSRecord) models.SubDomain {
+	t.Helper()
+	var subDomain models.SubDomain
+	err := s.DB.Where("domain_id = ? AND sub_domain_name = ? AND record_type = ? AND record_value = ?",
+		record.DomainID, record.Subdomain, record.Type, record.Value).First(&subDomain).Error
+	if err != nil {
+		t.Fatalf("查询子域名失败: %v", err)
+	}
+	return subDomain
+}
+
+func TestSetRecordStatusSameNameRecords(t *testing.T) {
+	s, domain := newTestRecordService(t, statuslessProviderType)
+	ctx := context.Background()
+
+	var records []*models.DNSRecord
+	for _, value := range []string{"192.0.2.1", "192.0.2.2"} {
+		record, err := s.CreateRecord(ctx, 1, false, models.CreateDNSRecordRequest{
+			DomainID: domain.ID, Subdomain: "www", Type: "A", Value: value, TTL: 600,
+		})
+		if err != nil {
+			t.Fatalf("创建记录失败: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	// 服务商不支持暂停时删除服务商侧记录，两条记录的外部ID都被清空
+	for _, record := range records {
+		paused, err := s.SetRecordStatus(ctx, record.ID, 1, false, false)
+		if err != nil {
+			t.Fatalf("暂停记录失败: %v", err)
+		}
+		if paused.ExternalID != "" || paused.Status != "inactive" {
+			t.Fatalf("暂停后的记录不符合预期: %+v", paused)
+		}
+	}
+
+	enabled, err := s.SetRecordStatus(ctx, records[0].ID, 1, false, true)
+	if err != nil {
+		t.Fatalf("启用记录失败: %v", err)
+	}
+	if enabled.ExternalID == "" {
+		t.Fatalf("启用后应保存新的外部ID")
+	}
+
+	// 只有被启用的记录对应的子域名更新外部ID和状态
+	first := subDomainOf(t, s, records[0])
+	if first.ExternalID != enabled.ExternalID || first.Status != "active" {
+		t.Fatalf("启用的记录对应的子域名不一致: %+v", first)
+	}
+	second := subDomainOf(t, s, records[1])
+	if second.ExternalID != "" || second.Status != "inactive" {
+		t.Fatalf("仍处于暂停状态的记录对应的子域名不应被修改: %+v", second)
+	}
+
+	provider, err := s.providerForDomain(ctx, domain)
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	remote, err := provider.ListRecords(ctx, domain.DomainName)
+	if err != nil {
+		t.Fatalf("获取服务商记录失败: %v", err)
+	}
+	if len(remote) != 1 || remote[0].ID != enabled.ExternalID || remote[0].Value != "192.0.2.1" {
+		t.Fatalf("服务商侧应只有启用的记录: %+v", remote)
+	}
+}
+
+func TestDeleteRecordMissingAtProvider(t *testing.T) {
+	s, domain := newTestRecordService(t, "mock")
+	ctx := context.Background()
+
+	record, err := s.CreateRecord(ctx, 1, false, models.CreateDNSRecordRequest{
+		DomainID: domain.ID, Subdomain: "www", Type: "A", Value: "192.0.2.1", TTL: 600,
+	})
+	if err != nil {
+		t.Fatalf("创建记录失败: %v", err)
+	}
+
+	// 记录已在服务商控制台被删除
+	provider, err := s.providerForDomain(ctx, domain)
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	if err := provider.DeleteRecord(ctx, domain.DomainName, record.ExternalID); err != nil {
+		t.Fatalf("删除服务商侧记录失败: %v", err)
+	}
+
+	if err := s.DeleteRecord(ctx, record.ID, 1, false); err != nil {
+		t.Fatalf("服务商侧记录不存在时应只删除数据库记录: %v", err)
+	}
+	if _, err := s.GetRecord(record.ID, 1, false); !errors.Is(err, ErrRecordNotFound) {
+		t.Fatalf("数据库记录应已删除，实际为: %v", err)
+	}
+	var count int64
+	s.DB.Model(&models.SubDomain{}).Where("domain_id = ?", domain.ID).Count(&count)
+	if count != 0 {
+		t.Fatalf("子域名应已删除，剩余%d条", count)
+	}
+}
+
+func TestSetRecordStatusKeepsLine(t *testing.T) {
+	s, domain := newTestRecordService(t, statuslessProviderType)
+	ctx := context.Background()
+
+	record, err := s.CreateRecord(ctx, 1, false, models.CreateDNSRecordRequest{
+		DomainID: domain.ID, Subdomain: "www", Type: "A", Value: "192.0.2.1", TTL: 600, Line: "telecom",
+	})
+	if err != nil {
+		t.Fatalf("创建记录失败: %v", err)
+	}
+	if _, err := s.SetRecordStatus(ctx, record.ID, 1, false, false); err != nil {
+		t.Fatalf("暂停记录失败: %v", err)
+	}
+	enabled, err := s.SetRecordStatus(ctx, record.ID, 1, false, true)
+	if err != nil {
+		t.Fatalf("启用记录失败: %v", err)
+	}
+
+	// 重新创建的记录使用数据库中保存的线路，而不是默认线路
+	provider, err := s.providerForDomain(ctx, domain)
+	if err != nil {
+		t.Fatalf("创建服务商失败: %v", err)
+	}
+	remote, err := provider.GetRecord(ctx, domain.DomainName, enabled.ExternalID)
+	if err != nil {
+		t.Fatalf("获取服务商记录失败: %v", err)
+	}
+	if remote.Line != "telecom" {
+		t.Fatalf("重新创建的记录线路应为telecom，实际为%q", remote.Line)
+	}
+}
+
+func TestApplyProviderFeaturesMinTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         int
+		ttlProvided bool
+		want        int
+		wantErr     bool
+	}{
+		{"未指定TTL时使用服务商下限", 600, false, 3600, false},
+		{"未指定TTL且默认值高于下限", 7200, false, 7200, false},
+		{"指定的TTL低于下限", 600, true, 600, true},
+		{"指定的TTL在范围内", 3600, true, 3600, false},
+		{"指定的TTL高于上限", 2592002, true, 2592002, true},
+	}
+	for _, tt := range tests {
+		record := &models.DNSRecord{Type: "A", TTL: tt.ttl}
+		err := applyProviderFeatures(record, "namesilo", tt.ttlProvided)
+		if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidRecord)) {
+			t.Errorf("%s: 错误不符合预期: %v", tt.name, err)
+		}
+		if record.TTL != tt.want {
+			t.Errorf("%s: TTL应为%d，实际为%d", tt.name, tt.want, record.TTL)
+		}
+	}
+}
+
+func TestProviderUnavailableKeepsKind(t *testing.T) {
+	s, domain := newTestRecordService(t, invalidConfigProviderType)
+
+	_, err := s.providerForDomain(context.Background(), domain)
+	if !errors.Is(err, ErrProviderUnavailable) || !errors.Is(err, providers.ErrInvalidConfig) {
+		t.Fatalf("创建实例失败的错误应同时匹配ErrProviderUnavailable和服务商错误分类，实际为: %v", err)
+	}
+}